@@ -13,19 +13,38 @@ import (
 	httpDelivery "sense-backend/internal/delivery/http"
 	authHandler "sense-backend/internal/delivery/http/handlers"
 	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
 	"sense-backend/internal/infrastructure/ai"
 	"sense-backend/internal/infrastructure/database"
+	"sense-backend/internal/infrastructure/events"
+	"sense-backend/internal/infrastructure/jobs"
 	"sense-backend/internal/infrastructure/jwt"
+	"sense-backend/internal/infrastructure/mail"
+	"sense-backend/internal/infrastructure/realtime"
 	"sense-backend/internal/infrastructure/repository"
+	"sense-backend/internal/infrastructure/searchindex"
+	"sense-backend/internal/infrastructure/webhook"
 	aiUsecase "sense-backend/internal/usecase/ai"
 	authUsecase "sense-backend/internal/usecase/auth"
+	blockUsecase "sense-backend/internal/usecase/block"
 	commentUsecase "sense-backend/internal/usecase/comment"
+	communityUsecase "sense-backend/internal/usecase/community"
+	digestUsecase "sense-backend/internal/usecase/digest"
 	feedUsecase "sense-backend/internal/usecase/feed"
+	jobUsecase "sense-backend/internal/usecase/job"
 	mediaUsecase "sense-backend/internal/usecase/media"
+	messageUsecase "sense-backend/internal/usecase/message"
 	notificationUsecase "sense-backend/internal/usecase/notification"
+	outboxUsecase "sense-backend/internal/usecase/outbox"
 	profileUsecase "sense-backend/internal/usecase/profile"
 	publicationUsecase "sense-backend/internal/usecase/publication"
+	realtimeUsecase "sense-backend/internal/usecase/realtime"
+	savedSearchUsecase "sense-backend/internal/usecase/savedsearch"
 	searchUsecase "sense-backend/internal/usecase/search"
+	suggestionUsecase "sense-backend/internal/usecase/suggestion"
+	syndicationUsecase "sense-backend/internal/usecase/syndication"
+	trendingUsecase "sense-backend/internal/usecase/trending"
+	webhookUsecase "sense-backend/internal/usecase/webhook"
 	"sense-backend/pkg/config"
 	"sense-backend/pkg/logger"
 
@@ -61,6 +80,19 @@ func main() {
 	recommendationRepo := repository.NewRecommendationRepository(dbPool)
 	tagRepo := repository.NewTagRepository(dbPool)
 	notificationRepo := repository.NewNotificationRepository(dbPool)
+	notificationSettingsRepo := repository.NewNotificationSettingsRepository(dbPool)
+	trendingRepo := repository.NewTrendingRepository(dbPool)
+	blockRepo := repository.NewBlockRepository(dbPool)
+	suggestionRepo := repository.NewSuggestionRepository(dbPool)
+	communityRepo := repository.NewCommunityRepository(dbPool)
+	messageRepo := repository.NewMessageRepository(dbPool)
+	digestRepo := repository.NewDigestRepository(dbPool)
+	webhookRepo := repository.NewWebhookRepository(dbPool)
+	outboxRepo := repository.NewOutboxRepository(dbPool)
+	jobRepo := repository.NewJobRepository(dbPool)
+	reindexTaskRepo := repository.NewReindexTaskRepository(dbPool)
+	savedSearchRepo := repository.NewSavedSearchRepository(dbPool)
+	searchLogRepo := repository.NewSearchLogRepository(dbPool)
 
 	// Initialize JWT service
 	tokenSvc := jwt.NewTokenService(&cfg.JWT)
@@ -68,16 +100,123 @@ func main() {
 	// Initialize AI client
 	aiClient := ai.NewClient(cfg.AI.ServiceURL)
 
+	// Initialize mailer
+	mailer, err := mail.NewMailer(&cfg.Mail)
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to initialize mailer")
+	}
+
+	// Initialize webhook sender
+	webhookParams := webhook.DefaultParams
+	webhookParams.AllowPrivateNetworks = cfg.Webhooks.AllowPrivateNetworks
+	webhookSender := webhook.NewSender(webhookParams)
+
+	// Initialize realtime hub; replicas fan events out to each other through Postgres LISTEN/NOTIFY
+	realtimeParams := realtime.DefaultParams
+	realtimeParams.Retention = time.Duration(cfg.Realtime.RetentionMinutes) * time.Minute
+	realtimeHub := realtime.NewHub(dbPool, realtimeParams, func(err error) {
+		appLogger.WithError(err).Error("Realtime delivery failed")
+	})
+	// Notification changes are pushed to the recipient's live connections
+	notificationRepo = realtime.NewNotificationRepository(notificationRepo, notificationSettingsRepo, realtimeHub, func(err error) {
+		appLogger.WithError(err).Error("Failed to push notification")
+	})
+
+	// Initialize background job runner; handlers are registered with the use cases below
+	jobParams := jobs.DefaultParams
+	jobParams.Queues = cfg.Jobs.Queues
+	jobParams.Retention = time.Duration(cfg.Jobs.RetentionDays) * 24 * time.Hour
+	jobRunner := jobs.NewRunner(jobRepo, jobParams, func(err error) {
+		appLogger.WithError(err).Error("Background job failed")
+	})
+
+	// Initialize search index; the embedded one lives in this process, so it suits a single replica
+	var searchIndex domain.SearchIndex
+	var embeddedIndex *searchindex.Index
+	switch cfg.Search.Backend {
+	case "embedded":
+		embeddedIndex, err = searchindex.Open(cfg.Search.IndexPath)
+		if err != nil {
+			appLogger.WithError(err).Fatal("Failed to open search index")
+		}
+		searchIndex = embeddedIndex
+	case "postgres":
+		searchIndex = repository.NewSearchIndex(dbPool)
+	default:
+		appLogger.Fatalf("Unknown search backend %q", cfg.Search.Backend)
+	}
+
+	// Initialize domain event bus
+	eventBus := events.NewBus(func(err error) {
+		appLogger.WithError(err).Error("Failed to handle domain event")
+	})
+
 	// Initialize use cases
 	authUC := authUsecase.NewUseCase(userRepo, tokenSvc)
-	publicationUC := publicationUsecase.NewUseCase(publicationRepo, userRepo, mediaRepo)
-	commentUC := commentUsecase.NewUseCase(commentRepo)
-	profileUC := profileUsecase.NewUseCase(userRepo)
-	feedUC := feedUsecase.NewUseCase(publicationRepo)
+	publicationUC := publicationUsecase.NewUseCase(publicationRepo, userRepo, mediaRepo, communityRepo)
+	commentUC := commentUsecase.NewUseCase(commentRepo, eventBus)
+	profileUC := profileUsecase.NewUseCase(userRepo, blockRepo, eventBus)
+	feedUC := feedUsecase.NewUseCase(publicationRepo, recommendationRepo)
 	mediaUC := mediaUsecase.NewUseCase(mediaRepo)
 	aiUC := aiUsecase.NewUseCase(aiClient, recommendationRepo, publicationRepo)
-	searchUC := searchUsecase.NewUseCase(publicationRepo, userRepo, tagRepo)
-	notificationUC := notificationUsecase.NewUseCase(notificationRepo)
+	searchUC := searchUsecase.NewUseCase(publicationRepo, userRepo, tagRepo, searchIndex, searchLogRepo, reindexTaskRepo, jobRunner)
+	jobRunner.Register(searchUsecase.JobReindex, searchUC.Reindex, jobs.Options{MaxAttempts: 3, Timeout: 30 * time.Minute})
+	jobRunner.Register(searchUsecase.JobPruneQueryLog, searchUC.PruneQueryLog, jobs.Options{MaxAttempts: 3, Timeout: 10 * time.Minute})
+	if err := jobRunner.Schedule("search-query-log", "@daily", searchUsecase.JobPruneQueryLog, nil); err != nil {
+		appLogger.WithError(err).Fatal("Failed to schedule search query log pruning")
+	}
+	eventBus.Subscribe(searchUC.Handle, searchUsecase.HandledEvents...)
+	savedSearchParams := savedSearchUsecase.DefaultParams
+	savedSearchUC := savedSearchUsecase.NewUseCase(savedSearchRepo, publicationRepo, searchIndex, eventBus, savedSearchParams)
+	jobRunner.Register(savedSearchUsecase.JobRun, savedSearchUC.Run, jobs.Options{MaxAttempts: 3, Timeout: 10 * time.Minute})
+	if err := jobRunner.Schedule("saved-searches", cfg.Search.SavedSchedule, savedSearchUsecase.JobRun, nil); err != nil {
+		appLogger.WithError(err).Fatal("Failed to schedule saved searches")
+	}
+	notificationUC := notificationUsecase.NewUseCase(notificationRepo, notificationSettingsRepo)
+	producerParams := notificationUsecase.DefaultProducerParams
+	producerParams.Locale = cfg.Notifications.Locale
+	producerParams.AggregateWindow = time.Duration(cfg.Notifications.AggregateWindowHours) * time.Hour
+	notificationProducer := notificationUsecase.NewProducer(notificationRepo, notificationSettingsRepo, publicationRepo, commentRepo, communityRepo, savedSearchRepo, userRepo, blockRepo, producerParams)
+	eventBus.Subscribe(notificationProducer.Handle, notificationUsecase.HandledEvents...)
+	trendingParams := trendingUsecase.DefaultParams
+	trendingParams.Window = time.Duration(cfg.Trending.WindowHours) * time.Hour
+	trendingParams.HalfLife = time.Duration(cfg.Trending.HalfLifeHours) * time.Hour
+	trendingUC := trendingUsecase.NewUseCase(trendingRepo, trendingParams)
+	jobRunner.Register(trendingUsecase.JobRefresh, trendingUC.Run, jobs.Options{MaxAttempts: 3, Timeout: 10 * time.Minute})
+	if err := jobRunner.Schedule("trending", cfg.Trending.Schedule, trendingUsecase.JobRefresh, nil); err != nil {
+		appLogger.WithError(err).Fatal("Failed to schedule trending refresh")
+	}
+	syndicationUC := syndicationUsecase.NewUseCase(publicationRepo, userRepo, tagRepo)
+	blockUC := blockUsecase.NewUseCase(blockRepo, userRepo)
+	suggestionUC := suggestionUsecase.NewUseCase(suggestionRepo, userRepo, suggestionUsecase.DefaultWeights)
+	communityUC := communityUsecase.NewUseCase(communityRepo, publicationRepo, userRepo, blockRepo, eventBus)
+	messageUC := messageUsecase.NewUseCase(messageRepo, userRepo, blockRepo, mediaRepo, realtimeHub)
+	realtimeUC := realtimeUsecase.NewUseCase(realtimeHub, realtimeHub, publicationRepo, commentRepo, notificationRepo, blockRepo)
+	eventBus.Subscribe(realtimeUC.Handle, realtimeUsecase.HandledEvents...)
+	webhookUC := webhookUsecase.NewUseCase(webhookRepo, publicationRepo, commentRepo, blockRepo, webhookSender, jobRunner, webhookUsecase.DefaultParams)
+	eventBus.Subscribe(webhookUC.Handle, webhookUsecase.HandledEvents...)
+	// Deliveries retry with their own backoff, so a failed run is not retried
+	jobRunner.Register(webhookUsecase.JobDeliver, webhookUC.Run, jobs.Options{MaxAttempts: 1, Timeout: 10 * time.Minute})
+	if err := jobRunner.Schedule("webhook-retries", cfg.Webhooks.RetrySchedule, webhookUsecase.JobDeliver, nil); err != nil {
+		appLogger.WithError(err).Fatal("Failed to schedule webhook retries")
+	}
+	digestUC := digestUsecase.NewUseCase(digestRepo, notificationRepo, notificationSettingsRepo, publicationRepo, mailer, digestUsecase.Params{
+		Locale:    cfg.Notifications.Locale,
+		PublicURL: cfg.Server.PublicURL,
+		Secret:    []byte(cfg.Digest.Secret),
+	})
+	// Failed digests are released for the next scheduled run, so a failed run is not retried
+	jobRunner.Register(digestUsecase.JobSend, digestUC.Run, jobs.Options{MaxAttempts: 1, Timeout: 30 * time.Minute})
+	if err := jobRunner.Schedule("digests", cfg.Digest.Schedule, digestUsecase.JobSend, nil); err != nil {
+		appLogger.WithError(err).Fatal("Failed to schedule email digests")
+	}
+	jobUC := jobUsecase.NewUseCase(jobRepo, userRepo)
+
+	// Events written to the outbox by repositories reach the bus subscribers through the dispatcher
+	outboxParams := outboxUsecase.DefaultParams
+	outboxParams.Retention = time.Duration(cfg.Outbox.RetentionHours) * time.Hour
+	outboxParams.MaxAttempts = cfg.Outbox.MaxAttempts
+	outboxUC := outboxUsecase.NewUseCase(outboxRepo, eventBus, outboxParams)
 
 	// Initialize validator
 	validator := validator.New()
@@ -92,14 +231,45 @@ func main() {
 	aiH := authHandler.NewAIHandler(aiUC, validator)
 	searchH := authHandler.NewSearchHandler(searchUC, validator)
 	notificationH := authHandler.NewNotificationHandler(notificationUC, validator)
+	trendingH := authHandler.NewTrendingHandler(trendingUC, validator)
+	syndicationH := authHandler.NewSyndicationHandler(syndicationUC, cfg.Server.PublicURL)
+	blockH := authHandler.NewBlockHandler(blockUC, validator)
+	suggestionH := authHandler.NewSuggestionHandler(suggestionUC, validator)
+	communityH := authHandler.NewCommunityHandler(communityUC, validator)
+	messageH := authHandler.NewMessageHandler(messageUC, validator)
+	realtimeH := authHandler.NewRealtimeHandler(realtimeUC)
+	digestH := authHandler.NewDigestHandler(digestUC)
+	webhookH := authHandler.NewWebhookHandler(webhookUC, validator)
+	jobH := authHandler.NewJobHandler(jobUC)
+	savedSearchH := authHandler.NewSavedSearchHandler(savedSearchUC, validator)
 
 	// Initialize router
-	router := httpDelivery.NewRouter(validator, appLogger, tokenSvc, authH, publicationH, commentH, profileH, feedH, mediaH, aiH, searchH, notificationH)
+	router := httpDelivery.NewRouter(validator, appLogger, tokenSvc, authH, publicationH, commentH, profileH, feedH, mediaH, aiH, searchH, notificationH, trendingH, syndicationH, blockH, suggestionH, communityH, messageH, realtimeH, digestH, webhookH, jobH, savedSearchH)
 	muxRouter := router.SetupRoutes()
 
 	// Apply CORS middleware
 	handler := middleware.CORSMiddleware(muxRouter)
 
+	// Start background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go realtimeHub.Run(workersCtx)
+
+	if !cfg.Jobs.Disabled {
+		go jobRunner.Run(workersCtx)
+	}
+
+	go outboxUC.RunDispatcher(workersCtx, time.Duration(cfg.Outbox.PollInterval)*time.Millisecond, func(err error) {
+		appLogger.WithError(err).Error("Failed to dispatch outbox events")
+	})
+
+	if embeddedIndex != nil {
+		go embeddedIndex.RunFlusher(workersCtx, time.Duration(cfg.Search.FlushInterval)*time.Second, func(err error) {
+			appLogger.WithError(err).Error("Failed to write search index")
+		})
+	}
+
 	// Setup server
 	srv := &http.Server{
 		Handler:      handler,
@@ -115,6 +285,7 @@ func main() {
 	go func() {
 		<-c
 		appLogger.Info("Shutting down server...")
+		stopWorkers()
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -128,4 +299,11 @@ func main() {
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		appLogger.WithError(err).Fatal("Server failed to start")
 	}
+
+	// Changes made since the last periodic flush are written before exit
+	if embeddedIndex != nil {
+		if err := embeddedIndex.Flush(); err != nil {
+			appLogger.WithError(err).Error("Failed to write search index")
+		}
+	}
 }
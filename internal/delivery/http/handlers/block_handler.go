@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"sense-backend/internal/delivery/http/middleware"
+	blockUsecase "sense-backend/internal/usecase/block"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// BlockHandler handles block and mute endpoints
+type BlockHandler struct {
+	blockUC   *blockUsecase.UseCase
+	validator *validator.Validate
+}
+
+// NewBlockHandler creates a new block handler
+func NewBlockHandler(blockUC *blockUsecase.UseCase, validator *validator.Validate) *BlockHandler {
+	return &BlockHandler{
+		blockUC:   blockUC,
+		validator: validator,
+	}
+}
+
+// RegisterBlockRoutes registers block routes
+func (h *BlockHandler) RegisterBlockRoutes(r *mux.Router) {
+	r.HandleFunc("", h.GetBlocked).Methods("GET")
+	r.HandleFunc("/{id}", h.Block).Methods("POST")
+	r.HandleFunc("/{id}", h.Unblock).Methods("DELETE")
+}
+
+// RegisterMuteRoutes registers mute routes
+func (h *BlockHandler) RegisterMuteRoutes(r *mux.Router) {
+	r.HandleFunc("", h.GetMuted).Methods("GET")
+	r.HandleFunc("/{id}", h.Mute).Methods("POST")
+	r.HandleFunc("/{id}", h.Unmute).Methods("DELETE")
+}
+
+// Block handles POST /block/{id}
+func (h *BlockHandler) Block(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.blockUC.Block(r.Context(), userID, vars["id"]); err != nil {
+		if errors.Is(err, blockUsecase.ErrSelfBlock) {
+			WriteError(w, http.StatusBadRequest, "validation_error", "Нельзя заблокировать себя", nil)
+			return
+		}
+		WriteError(w, http.StatusNotFound, "not_found", "Пользователь не найден", nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, map[string]string{
+		"message": "Пользователь заблокирован",
+	})
+}
+
+// Unblock handles DELETE /block/{id}
+func (h *BlockHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.blockUC.Unblock(r.Context(), userID, vars["id"]); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBlocked handles GET /block
+func (h *BlockHandler) GetBlocked(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	users, total, err := h.blockUC.GetBlocked(r.Context(), userID, limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Mute handles POST /mute/{id}
+func (h *BlockHandler) Mute(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.blockUC.Mute(r.Context(), userID, vars["id"]); err != nil {
+		if errors.Is(err, blockUsecase.ErrSelfMute) {
+			WriteError(w, http.StatusBadRequest, "validation_error", "Нельзя скрыть себя", nil)
+			return
+		}
+		WriteError(w, http.StatusNotFound, "not_found", "Пользователь не найден", nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, map[string]string{
+		"message": "Пользователь скрыт",
+	})
+}
+
+// Unmute handles DELETE /mute/{id}
+func (h *BlockHandler) Unmute(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.blockUC.Unmute(r.Context(), userID, vars["id"]); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMuted handles GET /mute
+func (h *BlockHandler) GetMuted(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	users, total, err := h.blockUC.GetMuted(r.Context(), userID, limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
@@ -39,7 +39,12 @@ func (h *CommentHandler) GetByPublication(w http.ResponseWriter, r *http.Request
 
 	limit, offset := getPagination(r)
 
-	comments, total, err := h.commentUC.GetByPublication(r.Context(), publicationID, limit, offset)
+	var viewerUserIDPtr *string
+	if viewerUserID := middleware.GetUserID(r.Context()); viewerUserID != "" {
+		viewerUserIDPtr = &viewerUserID
+	}
+
+	comments, total, err := h.commentUC.GetByPublication(r.Context(), publicationID, viewerUserIDPtr, limit, offset)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, "not_found", "Публикация не найдена", nil)
 		return
@@ -77,6 +82,9 @@ func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	comment, err := h.commentUC.Create(r.Context(), publicationID, userID, &req)
 	if err != nil {
+		if writeIfBlocked(w, err) {
+			return
+		}
 		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
 		return
 	}
@@ -196,6 +204,9 @@ func (h *CommentHandler) Reply(w http.ResponseWriter, r *http.Request) {
 
 	comment, err := h.commentUC.Create(r.Context(), parentComment.PublicationID, userID, &req)
 	if err != nil {
+		if writeIfBlocked(w, err) {
+			return
+		}
 		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
 		return
 	}
@@ -216,6 +227,9 @@ func (h *CommentHandler) Like(w http.ResponseWriter, r *http.Request) {
 
 	liked, count, err := h.commentUC.Like(r.Context(), id, userID)
 	if err != nil {
+		if writeIfBlocked(w, err) {
+			return
+		}
 		WriteError(w, http.StatusNotFound, "not_found", "Комментарий не найден", nil)
 		return
 	}
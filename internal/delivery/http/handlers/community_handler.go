@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	communityUsecase "sense-backend/internal/usecase/community"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// CommunityHandler handles community endpoints
+type CommunityHandler struct {
+	communityUC *communityUsecase.UseCase
+	validator   *validator.Validate
+}
+
+// NewCommunityHandler creates a new community handler
+func NewCommunityHandler(communityUC *communityUsecase.UseCase, validator *validator.Validate) *CommunityHandler {
+	return &CommunityHandler{
+		communityUC: communityUC,
+		validator:   validator,
+	}
+}
+
+// RegisterRoutes registers community routes
+func (h *CommunityHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("", h.List).Methods("GET")
+	r.HandleFunc("", h.Create).Methods("POST")
+	r.HandleFunc("/{id}", h.Get).Methods("GET")
+	r.HandleFunc("/{id}", h.Update).Methods("PUT")
+	r.HandleFunc("/{id}", h.Delete).Methods("DELETE")
+	r.HandleFunc("/{id}/feed", h.GetFeed).Methods("GET")
+	r.HandleFunc("/{id}/join", h.Join).Methods("POST")
+	r.HandleFunc("/{id}/join", h.Leave).Methods("DELETE")
+	r.HandleFunc("/{id}/members", h.GetMembers).Methods("GET")
+	r.HandleFunc("/{id}/members/{userId}", h.RemoveMember).Methods("DELETE")
+	r.HandleFunc("/{id}/members/{userId}/role", h.SetRole).Methods("PUT")
+	r.HandleFunc("/{id}/requests", h.GetJoinRequests).Methods("GET")
+	r.HandleFunc("/{id}/requests/{userId}/approve", h.ApproveJoinRequest).Methods("POST")
+	r.HandleFunc("/{id}/requests/{userId}/reject", h.RejectJoinRequest).Methods("POST")
+	r.HandleFunc("/{id}/invites/{userId}", h.Invite).Methods("POST")
+}
+
+// writeCommunityError maps community use case errors to responses
+func writeCommunityError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, communityUsecase.ErrNotMember):
+		WriteError(w, http.StatusForbidden, "forbidden", "Доступно только участникам сообщества", nil)
+	case errors.Is(err, communityUsecase.ErrInsufficientRole):
+		WriteError(w, http.StatusForbidden, "forbidden", "Недостаточно прав", nil)
+	case errors.Is(err, communityUsecase.ErrInviteRequired):
+		WriteError(w, http.StatusForbidden, "forbidden", "Вступить можно только по приглашению", nil)
+	case errors.Is(err, communityUsecase.ErrOwnerCannotLeave):
+		WriteError(w, http.StatusBadRequest, "validation_error", "Владелец не может покинуть сообщество", nil)
+	case errors.Is(err, communityUsecase.ErrJoinRequestNotFound):
+		WriteError(w, http.StatusNotFound, "not_found", "Заявка не найдена", nil)
+	case errors.Is(err, communityUsecase.ErrAlreadyMember):
+		WriteError(w, http.StatusConflict, "conflict", "Пользователь уже состоит в сообществе", nil)
+	case writeIfBlocked(w, err):
+	default:
+		WriteError(w, http.StatusNotFound, "not_found", "Сообщество не найдено", nil)
+	}
+}
+
+// List handles GET /communities
+func (h *CommunityHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	communities, total, err := h.communityUC.List(r.Context(), r.URL.Query().Get("q"), userID, limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  communities,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Create handles POST /communities
+func (h *CommunityHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req communityUsecase.CreateRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	community, err := h.communityUC.Create(r.Context(), userID, &req)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, community)
+}
+
+// Get handles GET /communities/{id}
+func (h *CommunityHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	community, err := h.communityUC.Get(r.Context(), vars["id"], userID)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, "not_found", "Сообщество не найдено", nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, community)
+}
+
+// Update handles PUT /communities/{id}
+func (h *CommunityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req communityUsecase.UpdateRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	vars := mux.Vars(r)
+	community, err := h.communityUC.Update(r.Context(), vars["id"], userID, &req)
+	if err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, community)
+}
+
+// Delete handles DELETE /communities/{id}
+func (h *CommunityHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.Delete(r.Context(), vars["id"], userID); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFeed handles GET /communities/{id}/feed
+func (h *CommunityHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	vars := mux.Vars(r)
+	publications, total, err := h.communityUC.GetFeed(r.Context(), vars["id"], userID, limit, offset)
+	if err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  publications,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Join handles POST /communities/{id}/join
+func (h *CommunityHandler) Join(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	status, err := h.communityUC.Join(r.Context(), vars["id"], userID)
+	if err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	if status == communityUsecase.JoinStatusPending {
+		WriteJSON(w, http.StatusAccepted, map[string]string{
+			"message": "Заявка на вступление отправлена",
+			"status":  string(status),
+		})
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, map[string]string{
+		"message": "Вы вступили в сообщество",
+		"status":  string(status),
+	})
+}
+
+// Leave handles DELETE /communities/{id}/join
+func (h *CommunityHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.Leave(r.Context(), vars["id"], userID); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMembers handles GET /communities/{id}/members
+func (h *CommunityHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	limit, offset := getPagination(r)
+
+	vars := mux.Vars(r)
+	members, total, err := h.communityUC.GetMembers(r.Context(), vars["id"], limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  members,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// RemoveMember handles DELETE /communities/{id}/members/{userId}
+func (h *CommunityHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.RemoveMember(r.Context(), vars["id"], userID, vars["userId"]); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetRole handles PUT /communities/{id}/members/{userId}/role
+func (h *CommunityHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req communityUsecase.SetRoleRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.SetRole(r.Context(), vars["id"], userID, vars["userId"], req.Role); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]domain.CommunityRole{
+		"role": req.Role,
+	})
+}
+
+// GetJoinRequests handles GET /communities/{id}/requests
+func (h *CommunityHandler) GetJoinRequests(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	vars := mux.Vars(r)
+	requests, total, err := h.communityUC.GetJoinRequests(r.Context(), vars["id"], userID, limit, offset)
+	if err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  requests,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ApproveJoinRequest handles POST /communities/{id}/requests/{userId}/approve
+func (h *CommunityHandler) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.ApproveJoinRequest(r.Context(), vars["id"], userID, vars["userId"]); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Заявка принята",
+	})
+}
+
+// RejectJoinRequest handles POST /communities/{id}/requests/{userId}/reject
+func (h *CommunityHandler) RejectJoinRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.RejectJoinRequest(r.Context(), vars["id"], userID, vars["userId"]); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Invite handles POST /communities/{id}/invites/{userId}
+func (h *CommunityHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.communityUC.Invite(r.Context(), vars["id"], userID, vars["userId"]); err != nil {
+		writeCommunityError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, map[string]string{
+		"message": "Приглашение отправлено",
+	})
+}
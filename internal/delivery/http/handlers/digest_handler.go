@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	digestUsecase "sense-backend/internal/usecase/digest"
+)
+
+// DigestHandler handles email digest endpoints
+type DigestHandler struct {
+	digestUC *digestUsecase.UseCase
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(digestUC *digestUsecase.UseCase) *DigestHandler {
+	return &DigestHandler{digestUC: digestUC}
+}
+
+// Unsubscribe handles GET and POST /unsubscribe?token=; the link comes from a digest email, so no
+// authentication is needed. POST serves one-click unsubscribe from mail clients
+func (h *DigestHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Не указан токен отписки", nil)
+		return
+	}
+
+	if err := h.digestUC.Unsubscribe(r.Context(), token); err != nil {
+		if errors.Is(err, digestUsecase.ErrInvalidToken) {
+			WriteError(w, http.StatusBadRequest, "validation_error", "Неверная ссылка для отписки", nil)
+			return
+		}
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Вы отписались от email-дайджеста",
+	})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -31,6 +32,7 @@ func NewFeedHandler(feedUC *feedUsecase.UseCase, validator *validator.Validate)
 func (h *FeedHandler) RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("", h.GetFeed).Methods("GET")
 	r.HandleFunc("/me", h.GetMe).Methods("GET")
+	r.HandleFunc("/for-you", h.GetForYou).Methods("GET")
 	r.HandleFunc("/me/saved", h.GetSaved).Methods("GET")
 	r.HandleFunc("/user/{id}", h.GetUser).Methods("GET")
 }
@@ -60,6 +62,39 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetForYou handles GET /feed/for-you
+func (h *FeedHandler) GetForYou(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = feedUsecase.StrategyBalanced
+	}
+
+	publications, total, err := h.feedUC.GetRankedFeed(r.Context(), userID, strategy, limit, offset)
+	if err != nil {
+		if errors.Is(err, feedUsecase.ErrUnknownStrategy) {
+			WriteError(w, http.StatusBadRequest, "validation_error", "Неизвестная стратегия ленты", nil)
+			return
+		}
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":    publications,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"strategy": strategy,
+	})
+}
+
 // GetMe handles GET /feed/me
 func (h *FeedHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
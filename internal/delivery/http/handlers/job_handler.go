@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	jobUsecase "sense-backend/internal/usecase/job"
+
+	"github.com/gorilla/mux"
+)
+
+// JobHandler handles background job administration endpoints
+type JobHandler struct {
+	jobUC *jobUsecase.UseCase
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobUC *jobUsecase.UseCase) *JobHandler {
+	return &JobHandler{
+		jobUC: jobUC,
+	}
+}
+
+// RegisterRoutes registers job routes
+func (h *JobHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("", h.List).Methods("GET")
+	r.HandleFunc("/stats", h.Stats).Methods("GET")
+	r.HandleFunc("/{id}", h.Get).Methods("GET")
+	r.HandleFunc("/{id}/retry", h.Retry).Methods("POST")
+}
+
+// List handles GET /admin/jobs
+func (h *JobHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	filters := &domain.JobFilters{}
+	if queue := r.URL.Query().Get("queue"); queue != "" {
+		filters.Queue = &queue
+	}
+	if jobType := r.URL.Query().Get("type"); jobType != "" {
+		filters.Type = &jobType
+	}
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := domain.JobStatus(status)
+		filters.Status = &s
+	}
+
+	jobs, total, err := h.jobUC.List(r.Context(), userID, filters, limit, offset)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  jobs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Stats handles GET /admin/jobs/stats
+func (h *JobHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	stats, err := h.jobUC.Stats(r.Context(), userID)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": stats,
+	})
+}
+
+// Get handles GET /admin/jobs/{id}
+func (h *JobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	job, err := h.jobUC.Get(r.Context(), userID, vars["id"])
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, job)
+}
+
+// Retry handles POST /admin/jobs/{id}/retry
+func (h *JobHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	job, err := h.jobUC.Retry(r.Context(), userID, vars["id"])
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, job)
+}
+
+// writeJobError maps job errors to responses
+func writeJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSuperUserRequired):
+		WriteError(w, http.StatusForbidden, "forbidden", "Недостаточно прав", nil)
+	case errors.Is(err, domain.ErrJobNotFound):
+		WriteError(w, http.StatusNotFound, "not_found", "Задача не найдена", nil)
+	case errors.Is(err, domain.ErrJobNotFailed):
+		WriteError(w, http.StatusConflict, "conflict", "Повторить можно только завершившуюся ошибкой задачу", nil)
+	default:
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+	}
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	messageUsecase "sense-backend/internal/usecase/message"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// MessageHandler handles direct message endpoints
+type MessageHandler struct {
+	messageUC *messageUsecase.UseCase
+	validator *validator.Validate
+}
+
+// NewMessageHandler creates a new message handler
+func NewMessageHandler(messageUC *messageUsecase.UseCase, validator *validator.Validate) *MessageHandler {
+	return &MessageHandler{
+		messageUC: messageUC,
+		validator: validator,
+	}
+}
+
+// RegisterRoutes registers conversation routes
+func (h *MessageHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("", h.GetConversations).Methods("GET")
+	r.HandleFunc("", h.CreateConversation).Methods("POST")
+	r.HandleFunc("/settings", h.GetSettings).Methods("GET")
+	r.HandleFunc("/settings", h.UpdateSettings).Methods("PUT")
+	r.HandleFunc("/{id}", h.GetConversation).Methods("GET")
+	r.HandleFunc("/{id}", h.Leave).Methods("DELETE")
+	r.HandleFunc("/{id}/messages", h.GetMessages).Methods("GET")
+	r.HandleFunc("/{id}/messages", h.Send).Methods("POST")
+	r.HandleFunc("/{id}/read", h.MarkRead).Methods("POST")
+}
+
+// writeMessageError maps message use case errors to responses
+func writeMessageError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, messageUsecase.ErrConversationNotFound):
+		WriteError(w, http.StatusNotFound, "not_found", "Переписка не найдена", nil)
+	case errors.Is(err, domain.ErrMessagingNotAllowed):
+		WriteError(w, http.StatusForbidden, "forbidden", "Пользователь принимает сообщения только от тех, на кого подписан", nil)
+	case errors.Is(err, messageUsecase.ErrSelfConversation):
+		WriteError(w, http.StatusBadRequest, "validation_error", "Нельзя начать переписку с собой", nil)
+	case errors.Is(err, messageUsecase.ErrTooManyMembers):
+		WriteError(w, http.StatusBadRequest, "validation_error", "Слишком много участников", nil)
+	case errors.Is(err, messageUsecase.ErrEmptyMessage):
+		WriteError(w, http.StatusBadRequest, "validation_error", "Сообщение не может быть пустым", nil)
+	case errors.Is(err, messageUsecase.ErrNotGroup):
+		WriteError(w, http.StatusBadRequest, "validation_error", "Покинуть можно только групповую переписку", nil)
+	case writeIfBlocked(w, err):
+	default:
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+	}
+}
+
+// GetConversations handles GET /conversations
+func (h *MessageHandler) GetConversations(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	conversations, total, err := h.messageUC.GetConversations(r.Context(), userID, limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  conversations,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// CreateConversation handles POST /conversations
+func (h *MessageHandler) CreateConversation(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req messageUsecase.CreateConversationRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	conversation, err := h.messageUC.CreateConversation(r.Context(), userID, &req)
+	if err != nil {
+		writeMessageError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, conversation)
+}
+
+// GetConversation handles GET /conversations/{id}
+func (h *MessageHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	conversation, err := h.messageUC.GetConversation(r.Context(), vars["id"], userID)
+	if err != nil {
+		writeMessageError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, conversation)
+}
+
+// Leave handles DELETE /conversations/{id}
+func (h *MessageHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.messageUC.Leave(r.Context(), vars["id"], userID); err != nil {
+		writeMessageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMessages handles GET /conversations/{id}/messages
+func (h *MessageHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	vars := mux.Vars(r)
+	messages, total, err := h.messageUC.GetMessages(r.Context(), vars["id"], userID, limit, offset)
+	if err != nil {
+		writeMessageError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  messages,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Send handles POST /conversations/{id}/messages
+func (h *MessageHandler) Send(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req messageUsecase.SendRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	vars := mux.Vars(r)
+	message, err := h.messageUC.Send(r.Context(), vars["id"], userID, &req)
+	if err != nil {
+		writeMessageError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, message)
+}
+
+// MarkRead handles POST /conversations/{id}/read
+func (h *MessageHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.messageUC.MarkRead(r.Context(), vars["id"], userID); err != nil {
+		writeMessageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSettings handles GET /conversations/settings
+func (h *MessageHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	settings, err := h.messageUC.GetSettings(r.Context(), userID)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /conversations/settings
+func (h *MessageHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req messageUsecase.SettingsRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	settings, err := h.messageUC.UpdateSettings(r.Context(), userID, &req)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, settings)
+}
@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
 	notificationUsecase "sense-backend/internal/usecase/notification"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
 )
 
 // NotificationHandler handles notification endpoints
@@ -24,6 +27,21 @@ func NewNotificationHandler(notificationUC *notificationUsecase.UseCase, validat
 	}
 }
 
+// RegisterRoutes registers notification routes
+func (h *NotificationHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("", h.GetNotifications).Methods("GET")
+	r.HandleFunc("/unread-count", h.GetUnreadCount).Methods("GET")
+	r.HandleFunc("/read-all", h.MarkAllAsRead).Methods("POST")
+	r.HandleFunc("/{id}/read", h.MarkAsRead).Methods("POST")
+	r.HandleFunc("/{id}", h.Delete).Methods("DELETE")
+}
+
+// RegisterSettingsRoutes registers notification settings routes under /profile
+func (h *NotificationHandler) RegisterSettingsRoutes(r *mux.Router) {
+	r.HandleFunc("/me/notification-settings", h.GetSettings).Methods("GET")
+	r.HandleFunc("/me/notification-settings", h.UpdateSettings).Methods("PUT")
+}
+
 // GetNotifications handles GET /notifications
 func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
@@ -54,3 +72,137 @@ func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Re
 		"offset": offset,
 	})
 }
+
+// GetUnreadCount handles GET /notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	count, err := h.notificationUC.CountUnread(r.Context(), userID)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]int{
+		"count": count,
+	})
+}
+
+// MarkAsRead handles POST /notifications/{id}/read
+func (h *NotificationHandler) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.notificationUC.MarkAsRead(r.Context(), userID, vars["id"]); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllAsRead handles POST /notifications/read-all
+func (h *NotificationHandler) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	if err := h.notificationUC.MarkAllAsRead(r.Context(), userID); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete handles DELETE /notifications/{id}
+func (h *NotificationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.notificationUC.Delete(r.Context(), userID, vars["id"]); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSettings handles GET /profile/me/notification-settings
+func (h *NotificationHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	settings, err := h.notificationUC.GetSettings(r.Context(), userID)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, settings)
+}
+
+// UpdateSettings handles PUT /profile/me/notification-settings
+func (h *NotificationHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req notificationUsecase.SettingsRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	settings, err := h.notificationUC.UpdateSettings(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, notificationUsecase.ErrUnknownNotificationType):
+			WriteError(w, http.StatusBadRequest, "validation_error", "Этот тип уведомлений нельзя настроить", nil)
+		case errors.Is(err, notificationUsecase.ErrInvalidTimeZone):
+			WriteError(w, http.StatusBadRequest, "validation_error", "Неизвестный часовой пояс", nil)
+		case errors.Is(err, notificationUsecase.ErrInvalidQuietHours):
+			WriteError(w, http.StatusBadRequest, "validation_error", "Тихие часы задаются разными значениями в формате ЧЧ:ММ", nil)
+		default:
+			WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		}
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, settings)
+}
+
+// writeNotificationError maps notification errors to responses; another user's
+// notification is reported as missing so its existence is not disclosed
+func writeNotificationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		WriteError(w, http.StatusNotFound, "not_found", "Уведомление не найдено", nil)
+		return
+	}
+	WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+}
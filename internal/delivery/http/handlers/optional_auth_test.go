@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	"sense-backend/internal/infrastructure/jwt"
+	feedUsecase "sense-backend/internal/usecase/feed"
+	"sense-backend/internal/usecase/mocks"
+	searchUsecase "sense-backend/internal/usecase/search"
+	"sense-backend/pkg/config"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// blockedBy stands in for notBlockedCondition: alice blocked bob, anonymous viewers block nobody
+var blockedBy = map[string]string{"alice": "bob"}
+
+var publicationsByAuthor = []*domain.PublicationWithLikeStatus{
+	{Publication: domain.Publication{ID: "p-bob", AuthorID: "bob"}},
+	{Publication: domain.Publication{ID: "p-carol", AuthorID: "carol"}},
+}
+
+func visibleTo(viewerUserID *string) []*domain.PublicationWithLikeStatus {
+	var visible []*domain.PublicationWithLikeStatus
+	for _, publication := range publicationsByAuthor {
+		if viewerUserID == nil || blockedBy[*viewerUserID] != publication.AuthorID {
+			visible = append(visible, publication)
+		}
+	}
+	return visible
+}
+
+func newOptionalAuthRouter(ctrl *gomock.Controller, tokenSvc *jwt.TokenService) *mux.Router {
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	publicationRepo.EXPECT().GetFeed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, viewerUserID *string, _ *domain.FeedFilters, _, _ int) ([]*domain.PublicationWithLikeStatus, int, error) {
+			visible := visibleTo(viewerUserID)
+			return visible, len(visible), nil
+		})
+	publicationRepo.EXPECT().GetByIDs(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, _ []string, viewerUserID *string) ([]*domain.PublicationWithLikeStatus, error) {
+			return visibleTo(viewerUserID), nil
+		})
+
+	index := mocks.NewMockSearchIndex(ctrl)
+	index.EXPECT().Query(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]*domain.SearchHit{{ID: "p-bob"}, {ID: "p-carol"}}, 2, nil)
+	queryLog := mocks.NewMockSearchLogRepository(ctrl)
+	queryLog.EXPECT().LogQuery(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	feedH := NewFeedHandler(feedUsecase.NewUseCase(publicationRepo, nil), validator.New())
+	searchH := NewSearchHandler(searchUsecase.NewUseCase(publicationRepo, nil, nil, index, queryLog, nil, nil), validator.New())
+
+	r := mux.NewRouter()
+	r.Use(middleware.OptionalAuthMiddleware(tokenSvc))
+	r.HandleFunc("/feed", feedH.GetFeed).Methods("GET")
+	r.HandleFunc("/search", searchH.SearchPublications).Methods("GET")
+	return r
+}
+
+func getPublicationIDs(t *testing.T, r http.Handler, path, token string) []string {
+	req := httptest.NewRequest("GET", path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	ids := make([]string, len(resp.Items))
+	for i, item := range resp.Items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestOptionalAuth_HidesBlockedAuthorsOnPublicRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenSvc := jwt.NewTokenService(&config.JWTConfig{Secret: "test-secret", Expiry: 3600})
+	token, err := tokenSvc.GenerateToken("alice", "alice", "user")
+	require.NoError(t, err)
+	r := newOptionalAuthRouter(ctrl, tokenSvc)
+
+	for _, path := range []string{"/feed", "/search?q=test"} {
+		assert.Equal(t, []string{"p-bob", "p-carol"}, getPublicationIDs(t, r, path, ""), path)
+		assert.Equal(t, []string{"p-carol"}, getPublicationIDs(t, r, path, token), path)
+		// An invalid token does not fail a public route, the request stays anonymous
+		assert.Equal(t, []string{"p-bob", "p-carol"}, getPublicationIDs(t, r, path, "invalid"), path)
+	}
+}
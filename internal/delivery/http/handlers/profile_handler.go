@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
 	profileUsecase "sense-backend/internal/usecase/profile"
 
 	"github.com/go-playground/validator/v10"
@@ -30,10 +33,16 @@ func (h *ProfileHandler) RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("/me", h.UpdateMe).Methods("POST")
 	r.HandleFunc("/{id}", h.Get).Methods("GET")
 	r.HandleFunc("/{id}/stats", h.GetStats).Methods("GET")
+	r.HandleFunc("/{id}/followers", h.GetFollowers).Methods("GET")
+	r.HandleFunc("/{id}/following", h.GetFollowing).Methods("GET")
+	r.HandleFunc("/{id}/mutuals", h.GetMutuals).Methods("GET")
 }
 
 // RegisterFollowRoutes registers follow routes (separate because they don't use /profile prefix)
 func (h *ProfileHandler) RegisterFollowRoutes(r *mux.Router) {
+	r.HandleFunc("/requests", h.GetFollowRequests).Methods("GET")
+	r.HandleFunc("/requests/{id}/approve", h.ApproveFollowRequest).Methods("POST")
+	r.HandleFunc("/requests/{id}/reject", h.RejectFollowRequest).Methods("POST")
 	r.HandleFunc("/{id}", h.Follow).Methods("POST")
 	r.HandleFunc("/{id}", h.Unfollow).Methods("DELETE")
 }
@@ -87,31 +96,37 @@ func (h *ProfileHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 func (h *ProfileHandler) Get(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
+	currentUserID := middleware.GetUserID(r.Context())
 
-	profile, err := h.profileUC.GetProfile(r.Context(), id)
+	profile, err := h.profileUC.GetVisibleProfile(r.Context(), id, currentUserID)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, "not_found", "Профиль не найден", nil)
 		return
 	}
 
-	// Check if current user is following this profile
-	currentUserID := middleware.GetUserID(r.Context())
+	// Check if current user is following this profile or waits for approval
 	isFollowing := false
+	followRequested := false
 	if currentUserID != "" && currentUserID != id {
 		isFollowing, _ = h.profileUC.IsFollowing(r.Context(), currentUserID, id)
+		if !isFollowing && profile.IsPrivate {
+			followRequested, _ = h.profileUC.HasFollowRequest(r.Context(), currentUserID, id)
+		}
 	}
 
 	// Create response with is_following field
 	response := map[string]interface{}{
-		"id":            profile.ID,
-		"username":      profile.Username,
-		"email":         profile.Email,
-		"phone":         profile.Phone,
-		"icon_url":      profile.IconURL,
-		"description":   profile.Description,
-		"role":          profile.Role,
-		"registered_at": profile.RegisteredAt,
-		"is_following":  isFollowing,
+		"id":               profile.ID,
+		"username":         profile.Username,
+		"email":            profile.Email,
+		"phone":            profile.Phone,
+		"icon_url":         profile.IconURL,
+		"description":      profile.Description,
+		"role":             profile.Role,
+		"registered_at":    profile.RegisteredAt,
+		"is_private":       profile.IsPrivate,
+		"is_following":     isFollowing,
+		"follow_requested": followRequested,
 	}
 
 	if profile.Statistic != nil {
@@ -135,6 +150,49 @@ func (h *ProfileHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusOK, stats)
 }
 
+// GetFollowers handles GET /profile/{id}/followers
+func (h *ProfileHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	h.writeConnections(w, r, h.profileUC.GetFollowers)
+}
+
+// GetFollowing handles GET /profile/{id}/following
+func (h *ProfileHandler) GetFollowing(w http.ResponseWriter, r *http.Request) {
+	h.writeConnections(w, r, h.profileUC.GetFollowing)
+}
+
+// GetMutuals handles GET /profile/{id}/mutuals
+func (h *ProfileHandler) GetMutuals(w http.ResponseWriter, r *http.Request) {
+	h.writeConnections(w, r, h.profileUC.GetMutuals)
+}
+
+// writeConnections writes a paginated follow list of the profile from the path
+func (h *ProfileHandler) writeConnections(
+	w http.ResponseWriter,
+	r *http.Request,
+	list func(ctx context.Context, userID, viewerUserID string, limit, offset int) ([]*domain.UserCard, int, error),
+) {
+	vars := mux.Vars(r)
+	currentUserID := middleware.GetUserID(r.Context())
+	limit, offset := getPagination(r)
+
+	cards, total, err := list(r.Context(), vars["id"], currentUserID, limit, offset)
+	if err != nil {
+		if errors.Is(err, profileUsecase.ErrProfilePrivate) {
+			WriteError(w, http.StatusForbidden, "forbidden", "Аккаунт закрыт", nil)
+			return
+		}
+		WriteError(w, http.StatusNotFound, "not_found", "Профиль не найден", nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  cards,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
 // Follow handles POST /follow/{id}
 func (h *ProfileHandler) Follow(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetUserID(r.Context())
@@ -146,17 +204,30 @@ func (h *ProfileHandler) Follow(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	followingID := vars["id"]
 
-	if err := h.profileUC.Follow(r.Context(), userID, followingID); err != nil {
+	status, err := h.profileUC.Follow(r.Context(), userID, followingID)
+	if err != nil {
 		if err.Error() == "cannot follow yourself" {
 			WriteError(w, http.StatusBadRequest, "validation_error", "Нельзя подписаться на себя", nil)
 			return
 		}
+		if writeIfBlocked(w, err) {
+			return
+		}
 		WriteError(w, http.StatusNotFound, "not_found", "Пользователь не найден", nil)
 		return
 	}
 
+	if status == domain.FollowStatusPending {
+		WriteJSON(w, http.StatusAccepted, map[string]string{
+			"message": "Запрос на подписку отправлен",
+			"status":  string(status),
+		})
+		return
+	}
+
 	WriteJSON(w, http.StatusCreated, map[string]string{
 		"message": "Вы подписались на пользователя",
+		"status":  string(status),
 	})
 }
 
@@ -178,3 +249,74 @@ func (h *ProfileHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetFollowRequests handles GET /follow/requests
+func (h *ProfileHandler) GetFollowRequests(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	requests, total, err := h.profileUC.GetFollowRequests(r.Context(), userID, limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  requests,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ApproveFollowRequest handles POST /follow/requests/{id}/approve
+func (h *ProfileHandler) ApproveFollowRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.profileUC.ApproveFollowRequest(r.Context(), userID, vars["id"]); err != nil {
+		if writeIfBlocked(w, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrFollowRequestNotFound) {
+			WriteError(w, http.StatusNotFound, "not_found", "Запрос на подписку не найден", nil)
+			return
+		}
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Запрос на подписку принят",
+	})
+}
+
+// RejectFollowRequest handles POST /follow/requests/{id}/reject
+func (h *ProfileHandler) RejectFollowRequest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.profileUC.RejectFollowRequest(r.Context(), userID, vars["id"]); err != nil {
+		if errors.Is(err, domain.ErrFollowRequestNotFound) {
+			WriteError(w, http.StatusNotFound, "not_found", "Запрос на подписку не найден", nil)
+			return
+		}
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
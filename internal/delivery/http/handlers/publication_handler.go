@@ -154,6 +154,9 @@ func (h *PublicationHandler) Like(w http.ResponseWriter, r *http.Request) {
 
 	liked, count, err := h.publicationUC.Like(r.Context(), id, userID)
 	if err != nil {
+		if writeIfBlocked(w, err) {
+			return
+		}
 		WriteError(w, http.StatusNotFound, "not_found", "Публикация не найдена", nil)
 		return
 	}
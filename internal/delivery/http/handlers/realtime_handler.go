@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	realtimeUsecase "sense-backend/internal/usecase/realtime"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// realtimeHeartbeat is how often idle streams are pinged so proxies keep them open
+	realtimeHeartbeat = 25 * time.Second
+	// realtimeWriteWait limits writing one WebSocket frame
+	realtimeWriteWait = 10 * time.Second
+	// realtimeRetry is the reconnect delay suggested to EventSource clients, in milliseconds
+	realtimeRetry = 5000
+)
+
+// RealtimeHandler handles the notification stream over Server-Sent Events and WebSocket
+type RealtimeHandler struct {
+	realtimeUC *realtimeUsecase.UseCase
+	upgrader   websocket.Upgrader
+}
+
+// NewRealtimeHandler creates a new realtime handler
+func NewRealtimeHandler(realtimeUC *realtimeUsecase.UseCase) *RealtimeHandler {
+	return &RealtimeHandler{
+		realtimeUC: realtimeUC,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Connections authenticate with a token rather than cookies, so any origin is allowed as with CORS
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+var errUnknownRealtimeAction = errors.New("unknown action")
+
+// realtimeCommand is a message a WebSocket client sends to watch or unwatch a publication
+type realtimeCommand struct {
+	Action        string `json:"action"`
+	PublicationID string `json:"publication_id"`
+}
+
+// Stream handles GET /notifications/stream
+func (h *RealtimeHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	stream, ok := h.connect(w, r)
+	if !ok {
+		return
+	}
+	defer stream.Close()
+
+	rc := http.NewResponseController(w)
+	// The server write timeout would cut the stream; heartbeats detect dead clients instead
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", realtimeRetry); err != nil {
+		return
+	}
+	if err := writeSSE(w, unreadCountEvent(stream.UnreadCount)); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(realtimeHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-stream.Events():
+			if !ok {
+				// The client fell behind; it reconnects and resumes from Last-Event-ID
+				return
+			}
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// WebSocket handles GET /notifications/ws
+func (h *RealtimeHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	stream, ok := h.connect(w, r)
+	if !ok {
+		return
+	}
+	defer stream.Close()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an error
+		return
+	}
+	defer conn.Close()
+
+	// Commands are read in a separate goroutine; all writes happen in this one
+	commands := make(chan realtimeCommand)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn.SetReadLimit(4096)
+		_ = conn.SetReadDeadline(time.Now().Add(2 * realtimeHeartbeat))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * realtimeHeartbeat))
+		})
+		for {
+			var command realtimeCommand
+			if err := conn.ReadJSON(&command); err != nil {
+				return
+			}
+			select {
+			case commands <- command:
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}()
+
+	write := func(v interface{}) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(realtimeWriteWait))
+		return conn.WriteJSON(v)
+	}
+
+	if err := write(unreadCountEvent(stream.UnreadCount)); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(realtimeHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(realtimeWriteWait)); err != nil {
+				return
+			}
+		case command := <-commands:
+			if err := write(h.execute(r, stream, command)); err != nil {
+				return
+			}
+		case event, ok := <-stream.Events():
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "resume from last event id"),
+					time.Now().Add(realtimeWriteWait))
+				return
+			}
+			if err := write(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// connect authenticates the request and opens the user's stream, replying with an error on failure
+func (h *RealtimeHandler) connect(w http.ResponseWriter, r *http.Request) (*realtimeUsecase.Stream, bool) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return nil, false
+	}
+
+	// EventSource resends the last received ID in the Last-Event-ID header; WebSocket clients
+	// pass it as a query parameter
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	var after int64
+	if lastEventID != "" {
+		parsed, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || parsed < 0 {
+			WriteError(w, http.StatusBadRequest, "validation_error", "Неверный идентификатор последнего события", nil)
+			return nil, false
+		}
+		after = parsed
+	}
+
+	var publicationIDs []string
+	for _, value := range r.URL.Query()["publication_id"] {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				publicationIDs = append(publicationIDs, id)
+			}
+		}
+	}
+
+	stream, err := h.realtimeUC.Connect(r.Context(), userID, publicationIDs, after)
+	if err != nil {
+		writeRealtimeError(w, err)
+		return nil, false
+	}
+	return stream, true
+}
+
+// execute applies a WebSocket command and returns the reply to send: an "ack" or an "error" message
+// shaped like the pushed events
+func (h *RealtimeHandler) execute(r *http.Request, stream *realtimeUsecase.Stream, command realtimeCommand) map[string]interface{} {
+	var err error
+	switch command.Action {
+	case "watch":
+		err = stream.Watch(r.Context(), command.PublicationID)
+	case "unwatch":
+		stream.Unwatch(command.PublicationID)
+	default:
+		err = errUnknownRealtimeAction
+	}
+	if err != nil {
+		_, code, message := realtimeErrorDetails(err)
+		return map[string]interface{}{
+			"type": "error",
+			"data": ErrorResponse{Error: code, Message: message},
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "ack",
+		"data": command,
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event *domain.RealtimeEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if event.ID > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+func unreadCountEvent(count int) *domain.RealtimeEvent {
+	return &domain.RealtimeEvent{
+		Type: domain.RealtimeEventUnreadCount,
+		Data: map[string]int{"count": count},
+	}
+}
+
+// writeRealtimeError maps realtime use case errors to responses
+func writeRealtimeError(w http.ResponseWriter, err error) {
+	status, code, message := realtimeErrorDetails(err)
+	WriteError(w, status, code, message, nil)
+}
+
+func realtimeErrorDetails(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, realtimeUsecase.ErrPublicationNotFound):
+		return http.StatusNotFound, "not_found", "Публикация не найдена"
+	case errors.Is(err, realtimeUsecase.ErrTooManyPublications):
+		return http.StatusBadRequest, "validation_error",
+			fmt.Sprintf("Можно следить не более чем за %d публикациями", realtimeUsecase.MaxWatchedPublications)
+	case errors.Is(err, errUnknownRealtimeAction):
+		return http.StatusBadRequest, "validation_error", "Неизвестное действие"
+	default:
+		return http.StatusBadRequest, "validation_error", err.Error()
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	savedSearchUsecase "sense-backend/internal/usecase/savedsearch"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// SavedSearchHandler handles saved search endpoints
+type SavedSearchHandler struct {
+	savedSearchUC *savedSearchUsecase.UseCase
+	validator     *validator.Validate
+}
+
+// NewSavedSearchHandler creates a new saved search handler
+func NewSavedSearchHandler(savedSearchUC *savedSearchUsecase.UseCase, validator *validator.Validate) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchUC: savedSearchUC,
+		validator:     validator,
+	}
+}
+
+// RegisterRoutes registers saved search routes
+func (h *SavedSearchHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("", h.List).Methods("GET")
+	r.HandleFunc("", h.Create).Methods("POST")
+	r.HandleFunc("/{id}", h.Get).Methods("GET")
+	r.HandleFunc("/{id}", h.Update).Methods("PUT")
+	r.HandleFunc("/{id}", h.Delete).Methods("DELETE")
+	r.HandleFunc("/{id}/new", h.GetNew).Methods("GET")
+	r.HandleFunc("/{id}/seen", h.MarkSeen).Methods("POST")
+}
+
+// Create handles POST /search/saved
+func (h *SavedSearchHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req savedSearchUsecase.CreateRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	search, err := h.savedSearchUC.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, search)
+}
+
+// List handles GET /search/saved
+func (h *SavedSearchHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	searches, err := h.savedSearchUC.List(r.Context(), userID)
+	if err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": searches,
+	})
+}
+
+// Get handles GET /search/saved/{id}
+func (h *SavedSearchHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	search, err := h.savedSearchUC.Get(r.Context(), userID, vars["id"])
+	if err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, search)
+}
+
+// Update handles PUT /search/saved/{id}
+func (h *SavedSearchHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req savedSearchUsecase.UpdateRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	vars := mux.Vars(r)
+	search, err := h.savedSearchUC.Update(r.Context(), userID, vars["id"], &req)
+	if err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, search)
+}
+
+// Delete handles DELETE /search/saved/{id}
+func (h *SavedSearchHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.savedSearchUC.Delete(r.Context(), userID, vars["id"]); err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetNew handles GET /search/saved/{id}/new
+func (h *SavedSearchHandler) GetNew(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	vars := mux.Vars(r)
+	publications, total, err := h.savedSearchUC.GetNew(r.Context(), userID, vars["id"], limit, offset)
+	if err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  publications,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// MarkSeen handles POST /search/saved/{id}/seen
+func (h *SavedSearchHandler) MarkSeen(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.savedSearchUC.MarkSeen(r.Context(), userID, vars["id"]); err != nil {
+		writeSavedSearchError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSavedSearchError maps saved search errors to responses; another user's saved search is reported as missing
+func writeSavedSearchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSavedSearchNotFound):
+		WriteError(w, http.StatusNotFound, "not_found", "Сохранённый поиск не найден", nil)
+	case errors.Is(err, savedSearchUsecase.ErrTooManySavedSearches):
+		WriteError(w, http.StatusBadRequest, "validation_error",
+			fmt.Sprintf("Можно сохранить не более %d поисков", savedSearchUsecase.MaxSavedSearchesPerUser), nil)
+	default:
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+	}
+}
@@ -1,14 +1,18 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"sense-backend/internal/delivery/http/middleware"
 	"sense-backend/internal/domain"
 	searchUsecase "sense-backend/internal/usecase/search"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
 )
 
 // SearchHandler handles search endpoints
@@ -43,20 +47,114 @@ func (h *SearchHandler) SearchPublications(w http.ResponseWriter, r *http.Reques
 	limit, offset := getPagination(r)
 	filters := h.parseSearchFilters(r)
 
-	publications, total, err := h.searchUC.SearchPublications(r.Context(), query, viewerUserIDPtr, filters, limit, offset)
+	result, err := h.searchUC.Search(r.Context(), query, viewerUserIDPtr, filters, limit, offset)
 	if err != nil {
 		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
 		return
 	}
 
-	WriteJSON(w, http.StatusOK, map[string]interface{}{
-		"items":  publications,
-		"total":  total,
+	response := map[string]interface{}{
+		"items":  result.Items,
+		"total":  result.Total,
 		"limit":  limit,
 		"offset": offset,
+	}
+	if result.QueryID != "" {
+		response["query_id"] = result.QueryID
+	}
+	if result.DidYouMean != nil {
+		response["did_you_mean"] = *result.DidYouMean
+	}
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// Suggest handles GET /search/suggest
+func (h *SearchHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Параметр 'q' обязателен", nil)
+		return
+	}
+
+	limit := searchUsecase.MaxSuggestLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= searchUsecase.MaxSuggestLimit {
+			limit = parsed
+		}
+	}
+
+	suggestions, err := h.searchUC.Suggest(r.Context(), query, limit)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": suggestions,
 	})
 }
 
+// LogClick handles POST /search/click
+func (h *SearchHandler) LogClick(w http.ResponseWriter, r *http.Request) {
+	var req searchUsecase.ClickRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	viewerUserID := middleware.GetUserID(r.Context())
+	var viewerUserIDPtr *string
+	if viewerUserID != "" {
+		viewerUserIDPtr = &viewerUserID
+	}
+
+	if err := h.searchUC.LogClick(r.Context(), viewerUserIDPtr, &req); err != nil {
+		if errors.Is(err, domain.ErrSearchQueryNotFound) {
+			WriteError(w, http.StatusNotFound, "not_found", "Поисковый запрос или публикация не найдены", nil)
+			return
+		}
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SearchAll handles GET /search/all
+func (h *SearchHandler) SearchAll(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Параметр 'q' обязателен", nil)
+		return
+	}
+
+	var viewerUserIDPtr *string
+	if viewerUserID := middleware.GetUserID(r.Context()); viewerUserID != "" {
+		viewerUserIDPtr = &viewerUserID
+	}
+
+	limit := searchUsecase.DefaultGroupLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= searchUsecase.MaxGroupLimit {
+			limit = parsed
+		}
+	}
+
+	results, err := h.searchUC.SearchAll(r.Context(), query, viewerUserIDPtr, h.parseSearchFilters(r), limit)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, results)
+}
+
 // SearchUsers handles GET /search/users
 func (h *SearchHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
@@ -73,7 +171,12 @@ func (h *SearchHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
 		rolePtr = &role
 	}
 
-	users, total, err := h.searchUC.SearchUsers(r.Context(), query, rolePtr, limit, offset)
+	var viewerUserIDPtr *string
+	if viewerUserID := middleware.GetUserID(r.Context()); viewerUserID != "" {
+		viewerUserIDPtr = &viewerUserID
+	}
+
+	users, total, err := h.searchUC.SearchUsers(r.Context(), query, viewerUserIDPtr, rolePtr, limit, offset)
 	if err != nil {
 		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
 		return
@@ -87,20 +190,83 @@ func (h *SearchHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// AutocompleteUsers handles GET /search/users/autocomplete
+func (h *SearchHandler) AutocompleteUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Параметр 'q' обязателен", nil)
+		return
+	}
+
+	limit, _ := getPagination(r)
+
+	var viewerUserIDPtr *string
+	if viewerUserID := middleware.GetUserID(r.Context()); viewerUserID != "" {
+		viewerUserIDPtr = &viewerUserID
+	}
+
+	users, err := h.searchUC.AutocompleteUsers(r.Context(), query, viewerUserIDPtr, limit)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": users,
+	})
+}
+
 // WarmupIndex handles POST /search/warmup
 func (h *SearchHandler) WarmupIndex(w http.ResponseWriter, r *http.Request) {
-	// For now, this is a placeholder that returns success
-	// In a real implementation, this would trigger search index rebuilding
-	// which could be a background job using the filters provided
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req searchUsecase.WarmupRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
 
-	taskID := "123e4567-e89b-12d3-a456-426614174000" // Placeholder task ID
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
 
-	WriteJSON(w, http.StatusOK, map[string]string{
+	task, err := h.searchUC.StartReindex(r.Context(), userID, &req)
+	if err != nil {
+		writeReindexError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, map[string]interface{}{
 		"message": "Индексация запущена",
-		"task_id": taskID,
+		"task_id": task.ID,
+		"status":  task.Status,
 	})
 }
 
+// GetWarmupTask handles GET /search/warmup/{task_id}
+func (h *SearchHandler) GetWarmupTask(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	task, err := h.searchUC.GetReindexTask(r.Context(), userID, vars["task_id"])
+	if err != nil {
+		writeReindexError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, task)
+}
+
 // GetTags handles GET /tags
 func (h *SearchHandler) GetTags(w http.ResponseWriter, r *http.Request) {
 	limit := 20
@@ -141,6 +307,35 @@ func (h *SearchHandler) parseSearchFilters(r *http.Request) *domain.SearchFilter
 	if authorID := r.URL.Query().Get("author_id"); authorID != "" {
 		filters.AuthorID = &authorID
 	}
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filters.Tags = append(filters.Tags, tag)
+			}
+		}
+	}
+	if dateFrom := r.URL.Query().Get("date_from"); dateFrom != "" {
+		if t, err := time.Parse(time.RFC3339, dateFrom); err == nil {
+			filters.DateFrom = &t
+		}
+	}
+	if dateTo := r.URL.Query().Get("date_to"); dateTo != "" {
+		if t, err := time.Parse(time.RFC3339, dateTo); err == nil {
+			filters.DateTo = &t
+		}
+	}
 
 	return filters
 }
+
+// writeReindexError maps reindex errors to responses
+func writeReindexError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSuperUserRequired):
+		WriteError(w, http.StatusForbidden, "forbidden", "Недостаточно прав", nil)
+	case errors.Is(err, domain.ErrReindexTaskNotFound):
+		WriteError(w, http.StatusNotFound, "not_found", "Задача индексации не найдена", nil)
+	default:
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"sense-backend/internal/delivery/http/middleware"
+	suggestionUsecase "sense-backend/internal/usecase/suggestion"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// SuggestionHandler handles follow suggestion endpoints
+type SuggestionHandler struct {
+	suggestionUC *suggestionUsecase.UseCase
+	validator    *validator.Validate
+}
+
+// NewSuggestionHandler creates a new follow suggestion handler
+func NewSuggestionHandler(suggestionUC *suggestionUsecase.UseCase, validator *validator.Validate) *SuggestionHandler {
+	return &SuggestionHandler{
+		suggestionUC: suggestionUC,
+		validator:    validator,
+	}
+}
+
+// RegisterRoutes registers suggestion routes under /follow; they must be registered before /follow/{id}
+func (h *SuggestionHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/suggestions", h.GetSuggestions).Methods("GET")
+	r.HandleFunc("/suggestions/{id}", h.Dismiss).Methods("DELETE")
+}
+
+// GetSuggestions handles GET /follow/suggestions
+func (h *SuggestionHandler) GetSuggestions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	suggestions, err := h.suggestionUC.GetSuggestions(r.Context(), userID, limit)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": suggestions,
+		"total": len(suggestions),
+		"limit": limit,
+	})
+}
+
+// Dismiss handles DELETE /follow/suggestions/{id}
+func (h *SuggestionHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.suggestionUC.Dismiss(r.Context(), userID, vars["id"]); err != nil {
+		if errors.Is(err, suggestionUsecase.ErrSelfDismiss) {
+			WriteError(w, http.StatusBadRequest, "validation_error", "Нельзя скрыть себя из рекомендаций", nil)
+			return
+		}
+		WriteError(w, http.StatusNotFound, "not_found", "Пользователь не найден", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
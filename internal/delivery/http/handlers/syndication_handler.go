@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	syndicationUsecase "sense-backend/internal/usecase/syndication"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+)
+
+// SyndicationHandler handles RSS, Atom and JSON Feed endpoints
+type SyndicationHandler struct {
+	syndicationUC *syndicationUsecase.UseCase
+	publicURL     string
+}
+
+// NewSyndicationHandler creates a new syndication handler
+func NewSyndicationHandler(syndicationUC *syndicationUsecase.UseCase, publicURL string) *SyndicationHandler {
+	return &SyndicationHandler{
+		syndicationUC: syndicationUC,
+		publicURL:     strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// RegisterRoutes registers syndication routes; they must be registered before /feed/user/{id}
+func (h *SyndicationHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/user/{id}.{format:rss|atom|json}", h.GetUserFeed).Methods("GET", "HEAD")
+	r.HandleFunc("/tag/{name}.{format:rss|atom|json}", h.GetTagFeed).Methods("GET", "HEAD")
+}
+
+// GetUserFeed handles GET /feed/user/{id}.{rss|atom|json}
+func (h *SyndicationHandler) GetUserFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	channel, err := h.syndicationUC.GetUserFeed(r.Context(), vars["id"])
+	if err != nil {
+		WriteError(w, http.StatusNotFound, "not_found", "Пользователь не найден", nil)
+		return
+	}
+
+	h.serveChannel(w, r, channel, vars["format"])
+}
+
+// GetTagFeed handles GET /feed/tag/{name}.{rss|atom|json}
+func (h *SyndicationHandler) GetTagFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	channel, err := h.syndicationUC.GetTagFeed(r.Context(), vars["name"])
+	if err != nil {
+		WriteError(w, http.StatusNotFound, "not_found", "Тег не найден", nil)
+		return
+	}
+
+	h.serveChannel(w, r, channel, vars["format"])
+}
+
+// serveChannel renders the channel and answers conditional requests via ETag
+func (h *SyndicationHandler) serveChannel(w http.ResponseWriter, r *http.Request, channel *syndicationUsecase.Channel, format string) {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+	switch format {
+	case feedFormatRSS:
+		body, err = h.renderRSS(channel)
+		contentType = "application/rss+xml; charset=utf-8"
+	case feedFormatAtom:
+		body, err = h.renderAtom(channel)
+		contentType = "application/atom+xml; charset=utf-8"
+	default:
+		body, err = h.renderJSONFeed(channel)
+		contentType = "application/feed+json; charset=utf-8"
+	}
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", channel.ETag(format))
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	// No Last-Modified: channel.Updated stays put when an item is deleted, hidden or its author is blocked,
+	// so If-Modified-Since would answer 304 for a changed feed. ServeContent checks If-None-Match only
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(body))
+}
+
+func (h *SyndicationHandler) feedURL(channel *syndicationUsecase.Channel, format string) string {
+	return h.publicURL + "/feed/" + string(channel.Kind) + "/" + url.PathEscape(channel.Subject) + "." + format
+}
+
+func (h *SyndicationHandler) homeURL(channel *syndicationUsecase.Channel) string {
+	if channel.Kind == syndicationUsecase.KindUser {
+		return h.publicURL + "/feed/user/" + url.PathEscape(channel.Subject)
+	}
+	return h.publicURL + "/"
+}
+
+func (h *SyndicationHandler) itemURL(item *syndicationUsecase.Item) string {
+	return h.publicURL + "/publication/" + item.Publication.ID
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Creator     string  `xml:"dc:creator"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+func (h *SyndicationHandler) renderRSS(channel *syndicationUsecase.Channel) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       channel.Title,
+			Link:        h.homeURL(channel),
+			Description: channel.Description,
+			AtomLink: rssLink{
+				Href: h.feedURL(channel, feedFormatRSS),
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			LastBuildDate: channel.Updated.UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, item := range channel.Items {
+		link := h.itemURL(item)
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       item.Publication.Title,
+			Link:        link,
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			Creator:     item.AuthorName,
+			PubDate:     item.Publication.PublicationDate.UTC().Format(time.RFC1123Z),
+			Description: item.ContentHTML,
+		})
+	}
+
+	return marshalXML(doc)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    atomAuthor  `xml:"author"`
+	Content   atomContent `xml:"content"`
+}
+
+func (h *SyndicationHandler) renderAtom(channel *syndicationUsecase.Channel) ([]byte, error) {
+	feedURL := h.feedURL(channel, feedFormatAtom)
+	feed := atomFeed{
+		ID:      feedURL,
+		Title:   channel.Title,
+		Updated: channel.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: h.homeURL(channel), Rel: "alternate"},
+		},
+	}
+	for _, item := range channel.Items {
+		link := h.itemURL(item)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        "urn:uuid:" + item.Publication.ID,
+			Title:     item.Publication.Title,
+			Link:      atomLink{Href: link, Rel: "alternate"},
+			Published: item.Publication.PublicationDate.UTC().Format(time.RFC3339),
+			Updated:   item.Publication.UpdatedAt.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: item.AuthorName},
+			Content:   atomContent{Type: "html", Value: item.ContentHTML},
+		})
+	}
+
+	return marshalXML(feed)
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+}
+
+func (h *SyndicationHandler) renderJSONFeed(channel *syndicationUsecase.Channel) ([]byte, error) {
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       channel.Title,
+		HomePageURL: h.homeURL(channel),
+		FeedURL:     h.feedURL(channel, feedFormatJSON),
+		Description: channel.Description,
+		Items:       []jsonFeedItem{},
+	}
+	for _, item := range channel.Items {
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            item.Publication.ID,
+			URL:           h.itemURL(item),
+			Title:         item.Publication.Title,
+			ContentHTML:   item.ContentHTML,
+			DatePublished: item.Publication.PublicationDate.UTC().Format(time.RFC3339),
+			DateModified:  item.Publication.UpdatedAt.UTC().Format(time.RFC3339),
+			Authors:       []jsonFeedAuthor{{Name: item.AuthorName}},
+		})
+	}
+
+	return json.Marshal(feed)
+}
+
+func marshalXML(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	trendingUsecase "sense-backend/internal/usecase/trending"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// TrendingHandler handles trending endpoints
+type TrendingHandler struct {
+	trendingUC *trendingUsecase.UseCase
+	validator  *validator.Validate
+}
+
+// NewTrendingHandler creates a new trending handler
+func NewTrendingHandler(trendingUC *trendingUsecase.UseCase, validator *validator.Validate) *TrendingHandler {
+	return &TrendingHandler{
+		trendingUC: trendingUC,
+		validator:  validator,
+	}
+}
+
+// RegisterRoutes registers trending routes
+func (h *TrendingHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/publications", h.GetPublications).Methods("GET")
+	r.HandleFunc("/tags", h.GetTags).Methods("GET")
+}
+
+// GetPublications handles GET /trending/publications
+func (h *TrendingHandler) GetPublications(w http.ResponseWriter, r *http.Request) {
+	// Get viewer user ID for like status (may be empty if not authenticated)
+	viewerUserID := middleware.GetUserID(r.Context())
+	var viewerUserIDPtr *string
+	if viewerUserID != "" {
+		viewerUserIDPtr = &viewerUserID
+	}
+
+	limit, offset := getPagination(r)
+	filters := h.parseTrendingFilters(r)
+
+	publications, total, err := h.trendingUC.GetPublications(r.Context(), viewerUserIDPtr, filters, limit, offset)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  publications,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetTags handles GET /trending/tags
+func (h *TrendingHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	filters := h.parseTrendingFilters(r)
+
+	tags, err := h.trendingUC.GetTags(r.Context(), filters, limit)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": tags,
+		"total": len(tags),
+	})
+}
+
+func (h *TrendingHandler) parseTrendingFilters(r *http.Request) *domain.TrendingFilters {
+	filters := &domain.TrendingFilters{}
+
+	if typeStr := r.URL.Query().Get("type"); typeStr != "" {
+		t := domain.PublicationType(typeStr)
+		filters.Type = &t
+	}
+	if language := r.URL.Query().Get("language"); language != "" {
+		filters.Language = &language
+	}
+
+	return filters
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	_ "image/gif"
@@ -14,6 +15,8 @@ import (
 	"net/http"
 	"strings"
 
+	"sense-backend/internal/domain"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -39,6 +42,16 @@ func WriteError(w http.ResponseWriter, statusCode int, errorCode, message string
 	})
 }
 
+// writeIfBlocked writes a forbidden response for interactions rejected because of a block
+// and reports whether it did
+func writeIfBlocked(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, domain.ErrBlocked) {
+		return false
+	}
+	WriteError(w, http.StatusForbidden, "forbidden", "Действие недоступно: пользователь заблокирован", nil)
+	return true
+}
+
 // WriteJSON writes JSON response
 func WriteJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
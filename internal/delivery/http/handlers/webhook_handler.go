@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"sense-backend/internal/delivery/http/middleware"
+	"sense-backend/internal/domain"
+	webhookUsecase "sense-backend/internal/usecase/webhook"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// WebhookHandler handles outgoing webhook endpoints
+type WebhookHandler struct {
+	webhookUC *webhookUsecase.UseCase
+	validator *validator.Validate
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookUC *webhookUsecase.UseCase, validator *validator.Validate) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUC: webhookUC,
+		validator: validator,
+	}
+}
+
+// RegisterRoutes registers webhook routes
+func (h *WebhookHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("", h.List).Methods("GET")
+	r.HandleFunc("", h.Create).Methods("POST")
+	r.HandleFunc("/{id}", h.Get).Methods("GET")
+	r.HandleFunc("/{id}", h.Update).Methods("PUT")
+	r.HandleFunc("/{id}", h.Delete).Methods("DELETE")
+	r.HandleFunc("/{id}/deliveries", h.GetDeliveries).Methods("GET")
+	r.HandleFunc("/{id}/deliveries/{delivery_id}/replay", h.Replay).Methods("POST")
+}
+
+// Create handles POST /webhooks
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req webhookUsecase.CreateRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	webhook, err := h.webhookUC.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, webhook)
+}
+
+// List handles GET /webhooks
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	webhooks, err := h.webhookUC.List(r.Context(), userID)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items": webhooks,
+	})
+}
+
+// Get handles GET /webhooks/{id}
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	webhook, err := h.webhookUC.Get(r.Context(), userID, vars["id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, webhook)
+}
+
+// Update handles PUT /webhooks/{id}
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	var req webhookUsecase.UpdateRequest
+	if err := ParseJSON(r, &req); err != nil {
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", nil)
+		return
+	}
+
+	if err := ValidateRequest(h.validator, &req); err != nil {
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неверные данные в запросе", &errMsg)
+		return
+	}
+
+	vars := mux.Vars(r)
+	webhook, err := h.webhookUC.Update(r.Context(), userID, vars["id"], &req)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, webhook)
+}
+
+// Delete handles DELETE /webhooks/{id}
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.webhookUC.Delete(r.Context(), userID, vars["id"]); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries handles GET /webhooks/{id}/deliveries
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	limit, offset := getPagination(r)
+
+	vars := mux.Vars(r)
+	deliveries, total, err := h.webhookUC.GetDeliveries(r.Context(), userID, vars["id"], limit, offset)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"items":  deliveries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Replay handles POST /webhooks/{id}/deliveries/{delivery_id}/replay
+func (h *WebhookHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "unauthorized", "Требуется аутентификация", nil)
+		return
+	}
+
+	vars := mux.Vars(r)
+	delivery, err := h.webhookUC.Replay(r.Context(), userID, vars["id"], vars["delivery_id"])
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, delivery)
+}
+
+// writeWebhookError maps webhook errors to responses; another user's webhook is reported as missing
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		WriteError(w, http.StatusNotFound, "not_found", "Вебхук не найден", nil)
+	case errors.Is(err, webhookUsecase.ErrInvalidURL):
+		WriteError(w, http.StatusBadRequest, "validation_error", "Адрес вебхука должен быть абсолютным URL http или https", nil)
+	case errors.Is(err, webhookUsecase.ErrUnknownEventType):
+		errMsg := err.Error()
+		WriteError(w, http.StatusBadRequest, "validation_error", "Неизвестный тип события", &errMsg)
+	case errors.Is(err, webhookUsecase.ErrTooManyWebhooks):
+		WriteError(w, http.StatusBadRequest, "validation_error",
+			fmt.Sprintf("Можно создать не более %d вебхуков", webhookUsecase.MaxWebhooksPerUser), nil)
+	default:
+		WriteError(w, http.StatusBadRequest, "validation_error", err.Error(), nil)
+	}
+}
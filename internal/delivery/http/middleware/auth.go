@@ -30,21 +30,62 @@ func AuthMiddleware(tokenSvc *jwt.TokenService) func(http.Handler) http.Handler
 				return
 			}
 
-			claims, err := tokenSvc.ValidateToken(parts[1])
-			if err != nil {
-				http.Error(w, `{"error":"unauthorized","message":"Недействительный токен"}`, http.StatusUnauthorized)
+			authenticate(tokenSvc, parts[1], next, w, r)
+		})
+	}
+}
+
+// StreamAuthMiddleware validates JWT token of SSE and WebSocket connections; browsers cannot set
+// headers on EventSource and WebSocket, so the token may also be passed as access_token query parameter
+func StreamAuthMiddleware(tokenSvc *jwt.TokenService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Query().Get("access_token")
+			if token == "" {
+				AuthMiddleware(tokenSvc)(next).ServeHTTP(w, r)
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), userIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, usernameKey, claims.Username)
-			ctx = context.WithValue(ctx, roleKey, claims.Role)
+			authenticate(tokenSvc, token, next, w, r)
+		})
+	}
+}
 
-			next.ServeHTTP(w, r.WithContext(ctx))
+// OptionalAuthMiddleware identifies the viewer of public routes: a valid Bearer token sets the
+// user's claims as AuthMiddleware does, while a missing or invalid one leaves the request anonymous
+func OptionalAuthMiddleware(tokenSvc *jwt.TokenService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			parts := strings.Split(r.Header.Get("Authorization"), " ")
+			if len(parts) == 2 && parts[0] == "Bearer" {
+				if claims, err := tokenSvc.ValidateToken(parts[1]); err == nil {
+					r = r.WithContext(withClaims(r.Context(), claims))
+				}
+			}
+
+			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// authenticate validates token and passes the request with the user's claims to next
+func authenticate(tokenSvc *jwt.TokenService, token string, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	claims, err := tokenSvc.ValidateToken(token)
+	if err != nil {
+		http.Error(w, `{"error":"unauthorized","message":"Недействительный токен"}`, http.StatusUnauthorized)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
+}
+
+// withClaims stores the user's claims in ctx
+func withClaims(ctx context.Context, claims *jwt.Claims) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, usernameKey, claims.Username)
+	return context.WithValue(ctx, roleKey, claims.Role)
+}
+
 // GetUserID retrieves user ID from context
 func GetUserID(ctx context.Context) string {
 	if id, ok := ctx.Value(userIDKey).(string); ok {
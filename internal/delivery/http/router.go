@@ -28,6 +28,17 @@ type Router struct {
 	aiHandler           *authHandler.AIHandler
 	searchHandler       *authHandler.SearchHandler
 	notificationHandler *authHandler.NotificationHandler
+	trendingHandler     *authHandler.TrendingHandler
+	syndicationHandler  *authHandler.SyndicationHandler
+	blockHandler        *authHandler.BlockHandler
+	suggestionHandler   *authHandler.SuggestionHandler
+	communityHandler    *authHandler.CommunityHandler
+	messageHandler      *authHandler.MessageHandler
+	realtimeHandler     *authHandler.RealtimeHandler
+	digestHandler       *authHandler.DigestHandler
+	webhookHandler      *authHandler.WebhookHandler
+	jobHandler          *authHandler.JobHandler
+	savedSearchHandler  *authHandler.SavedSearchHandler
 }
 
 // NewRouter creates a new router
@@ -44,6 +55,17 @@ func NewRouter(
 	aiHandler *authHandler.AIHandler,
 	searchHandler *authHandler.SearchHandler,
 	notificationHandler *authHandler.NotificationHandler,
+	trendingHandler *authHandler.TrendingHandler,
+	syndicationHandler *authHandler.SyndicationHandler,
+	blockHandler *authHandler.BlockHandler,
+	suggestionHandler *authHandler.SuggestionHandler,
+	communityHandler *authHandler.CommunityHandler,
+	messageHandler *authHandler.MessageHandler,
+	realtimeHandler *authHandler.RealtimeHandler,
+	digestHandler *authHandler.DigestHandler,
+	webhookHandler *authHandler.WebhookHandler,
+	jobHandler *authHandler.JobHandler,
+	savedSearchHandler *authHandler.SavedSearchHandler,
 ) *Router {
 	return &Router{
 		router:              mux.NewRouter(),
@@ -59,6 +81,17 @@ func NewRouter(
 		aiHandler:           aiHandler,
 		searchHandler:       searchHandler,
 		notificationHandler: notificationHandler,
+		trendingHandler:     trendingHandler,
+		syndicationHandler:  syndicationHandler,
+		blockHandler:        blockHandler,
+		suggestionHandler:   suggestionHandler,
+		communityHandler:    communityHandler,
+		messageHandler:      messageHandler,
+		realtimeHandler:     realtimeHandler,
+		digestHandler:       digestHandler,
+		webhookHandler:      webhookHandler,
+		jobHandler:          jobHandler,
+		savedSearchHandler:  savedSearchHandler,
 	}
 }
 
@@ -91,15 +124,18 @@ func (r *Router) SetupRoutes() *mux.Router {
 	// Profile routes (protected)
 	profileRouter := r.router.PathPrefix("/profile").Subrouter()
 	profileRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.notificationHandler.RegisterSettingsRoutes(profileRouter)
 	r.profileHandler.RegisterRoutes(profileRouter)
 
 	// Feed routes (some protected, some not)
 	feedRouter := r.router.PathPrefix("/feed").Subrouter()
-	// Public routes
-	feedRouter.HandleFunc("", r.feedHandler.GetFeed).Methods("GET")
-	feedRouter.HandleFunc("/user/{id}", r.feedHandler.GetUser).Methods("GET")
+	// Public routes, personalised when a token is sent; syndication formats go first so /user/{id} does not swallow the extension
+	r.syndicationHandler.RegisterRoutes(feedRouter)
+	feedRouter.Handle("", middleware.OptionalAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.feedHandler.GetFeed))).Methods("GET")
+	feedRouter.Handle("/user/{id}", middleware.OptionalAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.feedHandler.GetUser))).Methods("GET")
 	// Protected routes
 	feedRouter.Handle("/me", middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.feedHandler.GetMe))).Methods("GET")
+	feedRouter.Handle("/for-you", middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.feedHandler.GetForYou))).Methods("GET")
 	feedRouter.Handle("/me/saved", middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.feedHandler.GetSaved))).Methods("GET")
 
 	// Media routes (protected)
@@ -118,22 +154,83 @@ func (r *Router) SetupRoutes() *mux.Router {
 		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.aiHandler.PurifyText))).Methods("POST")
 
 	// Search routes (mixed auth - some optional, some required)
-	r.router.HandleFunc("/search", r.searchHandler.SearchPublications).Methods("GET")
+	r.router.Handle("/search",
+		middleware.OptionalAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.SearchPublications))).Methods("GET")
+	r.router.Handle("/search/suggest",
+		middleware.OptionalAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.Suggest))).Methods("GET")
+	r.router.Handle("/search/click",
+		middleware.OptionalAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.LogClick))).Methods("POST")
+	r.router.Handle("/search/all",
+		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.SearchAll))).Methods("GET")
 	r.router.Handle("/search/users",
 		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.SearchUsers))).Methods("GET")
+	r.router.Handle("/search/users/autocomplete",
+		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.AutocompleteUsers))).Methods("GET")
 	r.router.Handle("/search/warmup",
 		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.WarmupIndex))).Methods("POST")
+	r.router.Handle("/search/warmup/{task_id}",
+		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.GetWarmupTask))).Methods("GET")
 	r.router.Handle("/tags",
 		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.searchHandler.GetTags))).Methods("GET")
 
+	// Trending routes (public)
+	trendingRouter := r.router.PathPrefix("/trending").Subrouter()
+	trendingRouter.Use(middleware.OptionalAuthMiddleware(r.tokenSvc))
+	r.trendingHandler.RegisterRoutes(trendingRouter)
+
 	// Follow routes (protected)
 	followRouter := r.router.PathPrefix("/follow").Subrouter()
 	followRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.suggestionHandler.RegisterRoutes(followRouter)
 	r.profileHandler.RegisterFollowRoutes(followRouter)
 
+	// Block and mute routes (protected)
+	blockRouter := r.router.PathPrefix("/block").Subrouter()
+	blockRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.blockHandler.RegisterBlockRoutes(blockRouter)
+
+	muteRouter := r.router.PathPrefix("/mute").Subrouter()
+	muteRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.blockHandler.RegisterMuteRoutes(muteRouter)
+
+	// Community routes (protected)
+	communityRouter := r.router.PathPrefix("/communities").Subrouter()
+	communityRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.communityHandler.RegisterRoutes(communityRouter)
+
+	// Conversation routes (protected)
+	conversationRouter := r.router.PathPrefix("/conversations").Subrouter()
+	conversationRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.messageHandler.RegisterRoutes(conversationRouter)
+
+	// Realtime notification streams (protected; the token may be passed as access_token)
+	r.router.Handle("/notifications/stream",
+		middleware.StreamAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.realtimeHandler.Stream))).Methods("GET")
+	r.router.Handle("/notifications/ws",
+		middleware.StreamAuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.realtimeHandler.WebSocket))).Methods("GET")
+
 	// Notification routes (protected)
-	r.router.Handle("/notifications",
-		middleware.AuthMiddleware(r.tokenSvc)(http.HandlerFunc(r.notificationHandler.GetNotifications))).Methods("GET")
+	notificationRouter := r.router.PathPrefix("/notifications").Subrouter()
+	notificationRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.notificationHandler.RegisterRoutes(notificationRouter)
+
+	// Saved search routes (protected)
+	savedSearchRouter := r.router.PathPrefix("/search/saved").Subrouter()
+	savedSearchRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.savedSearchHandler.RegisterRoutes(savedSearchRouter)
+
+	// Webhook routes (protected)
+	webhookRouter := r.router.PathPrefix("/webhooks").Subrouter()
+	webhookRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.webhookHandler.RegisterRoutes(webhookRouter)
+
+	// Background job administration (protected; super users only)
+	jobRouter := r.router.PathPrefix("/admin/jobs").Subrouter()
+	jobRouter.Use(middleware.AuthMiddleware(r.tokenSvc))
+	r.jobHandler.RegisterRoutes(jobRouter)
+
+	// Digest unsubscribe links (public; the token identifies the user)
+	r.router.HandleFunc("/unsubscribe", r.digestHandler.Unsubscribe).Methods("GET", "POST")
 
 	return r.router
 }
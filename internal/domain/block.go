@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBlocked is returned when an interaction between two users is rejected because one blocked the other
+var ErrBlocked = errors.New("blocked: interaction between users is not allowed")
+
+// UserBlock represents a block: neither side sees the other's content or can interact with them
+type UserBlock struct {
+	BlockerID string    `json:"blocker_id"`
+	BlockedID string    `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserMute represents a mute: the muted user's content is hidden from the muter's feeds and notifications
+type UserMute struct {
+	MuterID   string    `json:"muter_id"`
+	MutedID   string    `json:"muted_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
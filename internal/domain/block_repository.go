@@ -0,0 +1,30 @@
+package domain
+
+import "context"
+
+// BlockRepository defines interface for block and mute data operations
+type BlockRepository interface {
+	// Block blocks a user and removes follows and follow requests in both directions
+	Block(ctx context.Context, blockerID, blockedID string) error
+
+	// Unblock removes a block
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+
+	// IsBlockedEither checks if either user blocked the other
+	IsBlockedEither(ctx context.Context, userID, otherUserID string) (bool, error)
+
+	// GetBlocked retrieves users blocked by user
+	GetBlocked(ctx context.Context, userID string, limit, offset int) ([]*User, int, error)
+
+	// Mute mutes a user
+	Mute(ctx context.Context, muterID, mutedID string) error
+
+	// Unmute removes a mute
+	Unmute(ctx context.Context, muterID, mutedID string) error
+	
+	// IsMuted checks if muter muted the other user
+	IsMuted(ctx context.Context, muterID, mutedID string) (bool, error)
+
+	// GetMuted retrieves users muted by user
+	GetMuted(ctx context.Context, userID string, limit, offset int) ([]*User, int, error)
+}
@@ -10,8 +10,8 @@ type CommentRepository interface {
 	// GetByID retrieves comment by ID
 	GetByID(ctx context.Context, id string) (*Comment, error)
 	
-	// GetByPublication retrieves comments for publication
-	GetByPublication(ctx context.Context, publicationID string, limit, offset int) ([]*Comment, int, error)
+	// GetByPublication retrieves comments for publication visible to viewer
+	GetByPublication(ctx context.Context, publicationID string, viewerUserID *string, limit, offset int) ([]*Comment, int, error)
 	
 	// Update updates comment
 	Update(ctx context.Context, comment *Comment) error
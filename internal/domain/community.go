@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// CommunityJoinPolicy represents how users become community members
+type CommunityJoinPolicy string
+
+const (
+	CommunityJoinPolicyOpen    CommunityJoinPolicy = "open"    // anyone joins immediately
+	CommunityJoinPolicyRequest CommunityJoinPolicy = "request" // moderators approve join requests
+	CommunityJoinPolicyInvite  CommunityJoinPolicy = "invite"  // only invited users may join
+)
+
+// CommunityRole represents a member's role in a community
+type CommunityRole string
+
+const (
+	CommunityRoleOwner     CommunityRole = "owner"
+	CommunityRoleModerator CommunityRole = "moderator"
+	CommunityRoleMember    CommunityRole = "member"
+)
+
+// CanModerate reports whether the role may manage members and community publications
+func (r CommunityRole) CanModerate() bool {
+	return r == CommunityRoleOwner || r == CommunityRoleModerator
+}
+
+// Community represents a group of users with its own publications
+type Community struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Description  *string             `json:"description,omitempty"`
+	IconURL      *string             `json:"icon_url,omitempty"`
+	JoinPolicy   CommunityJoinPolicy `json:"join_policy"`
+	OwnerID      string              `json:"owner_id"`
+	CreatedAt    time.Time           `json:"created_at"`
+	MembersCount int                 `json:"members_count"`
+	ViewerRole   *CommunityRole      `json:"viewer_role,omitempty"` // nil when the viewer is not a member
+}
+
+// CommunityMember represents a user's membership in a community
+type CommunityMember struct {
+	CommunityID string        `json:"community_id"`
+	User        *User         `json:"user"`
+	Role        CommunityRole `json:"role"`
+	JoinedAt    time.Time     `json:"joined_at"`
+}
+
+// CommunityJoinRequest represents a pending request to join a community
+type CommunityJoinRequest struct {
+	CommunityID string    `json:"community_id"`
+	User        *User     `json:"user"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "context"
+
+// CommunityRepository defines interface for community data operations
+type CommunityRepository interface {
+	// Create creates a new community with its owner as the first member
+	Create(ctx context.Context, community *Community) error
+
+	// GetByID retrieves community by ID with members count and the viewer's role
+	GetByID(ctx context.Context, id string, viewerUserID *string) (*Community, error)
+
+	// List retrieves communities matching query (empty for all), largest first
+	List(ctx context.Context, query string, viewerUserID *string, limit, offset int) ([]*Community, int, error)
+
+	// Update updates community information
+	Update(ctx context.Context, community *Community) error
+
+	// Delete deletes community with its publications
+	Delete(ctx context.Context, id string) error
+
+	// GetRole retrieves the user's role in the community, nil if the user is not a member
+	GetRole(ctx context.Context, communityID, userID string) (*CommunityRole, error)
+
+	// AddMember adds a member and removes their pending join request and invite
+	AddMember(ctx context.Context, communityID, userID string, role CommunityRole) error
+
+	// RemoveMember removes a member
+	RemoveMember(ctx context.Context, communityID, userID string) error
+
+	// SetRole changes a member's role
+	SetRole(ctx context.Context, communityID, userID string, role CommunityRole) error
+
+	// GetMembers retrieves members, owner and moderators first
+	GetMembers(ctx context.Context, communityID string, limit, offset int) ([]*CommunityMember, int, error)
+
+	// CreateJoinRequest creates a pending join request
+	CreateJoinRequest(ctx context.Context, communityID, userID string) error
+
+	// DeleteJoinRequest removes a pending join request, reporting whether one existed
+	DeleteJoinRequest(ctx context.Context, communityID, userID string) (bool, error)
+
+	// GetJoinRequests retrieves pending join requests, oldest first
+	GetJoinRequests(ctx context.Context, communityID string, limit, offset int) ([]*CommunityJoinRequest, int, error)
+
+	// CreateInvite invites a user to the community
+	CreateInvite(ctx context.Context, communityID, userID, invitedBy string) error
+
+	// HasInvite checks if the user is invited to the community
+	HasInvite(ctx context.Context, communityID, userID string) (bool, error)
+}
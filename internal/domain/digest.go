@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// DigestRecipient is a user whose email digest is due
+type DigestRecipient struct {
+	User      *User
+	Frequency DigestFrequency
+	// LastSentAt is when the previous digest was sent, nil if never
+	LastSentAt *time.Time
+}
+
+// Digest is the activity of a period collected for one email
+type Digest struct {
+	Recipient     *DigestRecipient
+	Since         time.Time
+	Notifications []*Notification
+	Publications  []*PublicationWithLikeStatus
+	NewFollowers  []*User
+	// NewFollowersTotal may exceed len(NewFollowers), which lists only the latest
+	NewFollowersTotal int
+}
+
+// IsEmpty reports whether the digest has nothing to tell
+func (d *Digest) IsEmpty() bool {
+	return len(d.Notifications) == 0 && len(d.Publications) == 0 && d.NewFollowersTotal == 0
+}
+
+// Email is a message with HTML and plain text bodies
+type Email struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	// Headers are extra headers such as List-Unsubscribe
+	Headers map[string]string
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DigestRepository defines interface for email digest data operations
+type DigestRepository interface {
+	// ClaimDue marks up to limit users with an email whose digest is due at now as sent at now and
+	// returns them; concurrent callers never claim the same user
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*DigestRecipient, error)
+	
+	// Release restores the previous send time of a claimed digest that could not be sent
+	Release(ctx context.Context, userID string, lastSentAt *time.Time) error
+	
+	// GetNewFollowers retrieves users who started following userID since the time, latest first
+	GetNewFollowers(ctx context.Context, userID string, since time.Time, limit int) ([]*User, int, error)
+}
+
+// Mailer sends emails
+type Mailer interface {
+	// Send delivers the email
+	Send(ctx context.Context, email *Email) error
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EventType represents type of a domain event
+type EventType string
+
+const (
+	EventPublicationCreated    EventType = "publication.created"
+	EventPublicationUpdated    EventType = "publication.updated"
+	EventPublicationDeleted    EventType = "publication.deleted"
+	EventPublicationLiked      EventType = "publication.liked"
+	EventPublicationUnliked    EventType = "publication.unliked"
+	EventCommentCreated        EventType = "comment.created"
+	EventCommentLiked          EventType = "comment.liked"
+	EventCommentUnliked        EventType = "comment.unliked"
+	EventUserFollowed          EventType = "user.followed"
+	EventUserUnfollowed        EventType = "user.unfollowed"
+	EventFollowRequested       EventType = "user.follow_requested"
+	EventFollowApproved        EventType = "user.follow_approved"
+	EventCommunityInvited      EventType = "community.invited"
+	EventCommunityJoinApproved EventType = "community.join_approved"
+	EventSavedSearchMatched    EventType = "search.saved_matched"
+)
+
+// Event is emitted by a use case after a state change
+type Event struct {
+	// ID is the outbox message the event was dispatched from, the same on every retry;
+	// empty for events published directly
+	ID         string    `json:"id,omitempty"`
+	Type       EventType `json:"type"`
+	ActorID    string    `json:"actor_id"`          // user who performed the action
+	TargetID   string    `json:"target_id"`         // publication, comment, community, saved search or user the action is about
+	UserID     string    `json:"user_id,omitempty"` // user the action concerns when the target is not a user
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NewEvent creates an event that occurred now
+func NewEvent(eventType EventType, actorID, targetID string) *Event {
+	return &Event{
+		Type:       eventType,
+		ActorID:    actorID,
+		TargetID:   targetID,
+		OccurredAt: time.Now(),
+	}
+}
+
+// EventHandler handles a published event
+type EventHandler func(ctx context.Context, event *Event) error
+
+// EventPublisher publishes domain events to their subscribers
+type EventPublisher interface {
+	// Publish delivers event to subscribers; subscriber failures never fail the publisher
+	Publish(ctx context.Context, event *Event)
+}
+
+// EventDispatcher delivers events to their subscribers and reports failures to the caller
+type EventDispatcher interface {
+	// Dispatch calls every subscriber of the event type and returns their joined errors
+	Dispatch(ctx context.Context, event *Event) error
+}
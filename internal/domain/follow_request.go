@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrFollowRequestNotFound is returned when there is no pending follow request to act on
+var ErrFollowRequestNotFound = errors.New("follow request not found")
+
+// FollowStatus represents the outcome of a follow attempt
+type FollowStatus string
+
+const (
+	FollowStatusFollowing FollowStatus = "following"
+	FollowStatusPending   FollowStatus = "pending"
+)
+
+// FollowRequest represents a pending follow of a private account
+type FollowRequest struct {
+	RequesterID string    `json:"requester_id"`
+	TargetID    string    `json:"target_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Requester   *User     `json:"requester,omitempty"`
+}
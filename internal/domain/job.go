@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrJobNotFound is returned when a job does not exist
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobDuplicate is returned when a job with the same unique key was already enqueued
+	ErrJobDuplicate = errors.New("job already enqueued")
+	// ErrJobNotFailed is returned when retrying a job that has not failed
+	ErrJobNotFailed = errors.New("job has not failed")
+)
+
+// JobStatus represents state of a background job
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// Job is a unit of background work stored in the jobs table
+type Job struct {
+	ID    string `json:"id"`
+	Queue string `json:"queue"`
+	// Type selects the handler
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+	Status  JobStatus       `json:"status"`
+	// Attempts counts started runs, including the current one
+	Attempts    int `json:"attempts"`
+	MaxAttempts int `json:"max_attempts"`
+	// TimeoutSeconds is the visibility timeout: a run not finished in time is cancelled and the
+	// job is given to another worker
+	TimeoutSeconds int        `json:"timeout_seconds"`
+	RunAt          time.Time  `json:"run_at"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	// UniqueKey deduplicates enqueues, such as one run of a cron schedule on several replicas
+	UniqueKey  *string    `json:"unique_key,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// JobFilters represents job list filters
+type JobFilters struct {
+	Queue  *string
+	Type   *string
+	Status *JobStatus
+}
+
+// JobQueueStats counts jobs of a queue by status
+type JobQueueStats struct {
+	Queue     string `json:"queue"`
+	Queued    int    `json:"queued"`
+	Running   int    `json:"running"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// RetryBackoff returns the delay before the next try after the given number of failed attempts:
+// base after the first one, twice as long after every next one, never more than maxDelay
+func RetryBackoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// JobRepository defines interface for background job data operations
+type JobRepository interface {
+	// Enqueue stores a queued job and sets its ID and timestamps; returns ErrJobDuplicate when the unique key is taken
+	Enqueue(ctx context.Context, job *Job) error
+	
+	// Claim marks up to limit due jobs of the queue and types as running and returns them; jobs
+	// whose visibility timeout expired are claimed again while they have attempts left
+	Claim(ctx context.Context, queue string, types []string, now time.Time, limit int) ([]*Job, error)
+	
+	// Complete marks the run of job as succeeded unless the job was claimed again since
+	Complete(ctx context.Context, job *Job) error
+	
+	// Fail records the error of the run of job; the job is queued again at retryAt, or failed when retryAt is nil
+	Fail(ctx context.Context, job *Job, message string, retryAt *time.Time) error
+	
+	// FailExpired fails running jobs whose visibility timeout expired on their last attempt
+	FailExpired(ctx context.Context, now time.Time) (int64, error)
+	
+	// GetByID retrieves job by ID
+	GetByID(ctx context.Context, id string) (*Job, error)
+	
+	// List retrieves jobs matching filters, newest first
+	List(ctx context.Context, filters *JobFilters, limit, offset int) ([]*Job, int, error)
+	
+	// Stats counts jobs by queue and status
+	Stats(ctx context.Context) ([]*JobQueueStats, error)
+	
+	// Retry queues a failed job again with its attempts reset; returns ErrJobNotFailed for other jobs
+	Retry(ctx context.Context, id string) (*Job, error)
+	
+	// DeleteFinished deletes succeeded and failed jobs finished before the given time
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// JobQueue enqueues background jobs for registered handlers
+type JobQueue interface {
+	// Enqueue schedules a job of a registered type with a JSON-encoded payload at runAt, or now when runAt is zero
+	Enqueue(ctx context.Context, jobType string, payload interface{}, runAt time.Time) (*Job, error)
+}
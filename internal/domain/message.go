@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrMessagingNotAllowed is returned when the recipient accepts messages only from people they follow
+var ErrMessagingNotAllowed = errors.New("recipient does not accept messages from this user")
+
+// Conversation represents a one-to-one or group conversation
+type Conversation struct {
+	ID          string    `json:"id"`
+	IsGroup     bool      `json:"is_group"`
+	Title       *string   `json:"title,omitempty"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"` // time of the last message
+	Members     []*User   `json:"members"`
+	LastMessage *Message  `json:"last_message,omitempty"`
+	UnreadCount int       `json:"unread_count"` // messages of others the viewer has not read yet
+}
+
+// Message represents a message in a conversation
+type Message struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	SenderID       string    `json:"sender_id"`
+	Content        *string   `json:"content,omitempty"`
+	MediaIDs       []string  `json:"media_ids"`
+	CreatedAt      time.Time `json:"created_at"`
+	ReadBy         []string  `json:"read_by"` // members other than the sender who have read the message
+}
+
+// MessageSettings represents user's direct message preferences
+type MessageSettings struct {
+	UserID            string `json:"-"`
+	FromFollowingOnly bool   `json:"from_following_only"` // only people the user follows can start or continue a conversation
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MessageRepository defines interface for conversation and message data operations
+type MessageRepository interface {
+	// CreateConversation creates a conversation with its members; for an existing
+	// one-to-one conversation between the same users it sets conversation.ID to it instead
+	CreateConversation(ctx context.Context, conversation *Conversation, memberIDs []string) error
+
+	// FindDirect retrieves the one-to-one conversation between two users, nil if there is none
+	FindDirect(ctx context.Context, userID, otherUserID string) (*Conversation, error)
+
+	// GetConversation retrieves conversation with members, last message and unread count for userID
+	GetConversation(ctx context.Context, id, userID string) (*Conversation, error)
+
+	// GetConversations retrieves user's conversations, most recently active first
+	GetConversations(ctx context.Context, userID string, limit, offset int) ([]*Conversation, int, error)
+
+	// IsMember checks if user is a member of the conversation
+	IsMember(ctx context.Context, conversationID, userID string) (bool, error)
+
+	// RemoveMember removes a member from the conversation
+	RemoveMember(ctx context.Context, conversationID, userID string) error
+
+	// CreateMessage creates a message with attachments, bumps the conversation and marks it read for the sender
+	CreateMessage(ctx context.Context, message *Message) error
+
+	// GetMessages retrieves conversation messages with read receipts, newest first
+	GetMessages(ctx context.Context, conversationID string, limit, offset int) ([]*Message, int, error)
+
+	// MarkRead marks conversation messages created up to readAt as read by the user
+	MarkRead(ctx context.Context, conversationID, userID string, readAt time.Time) error
+
+	// GetSettings retrieves user's message settings, defaults if none were saved
+	GetSettings(ctx context.Context, userID string) (*MessageSettings, error)
+
+	// UpdateSettings saves user's message settings
+	UpdateSettings(ctx context.Context, settings *MessageSettings) error
+}
@@ -1,6 +1,13 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNotificationNotFound is returned when the notification does not exist or belongs to another user
+var ErrNotificationNotFound = errors.New("notification not found")
 
 // NotificationType represents type of notification
 type NotificationType string
@@ -10,6 +17,13 @@ const (
 	NotificationTypeComment NotificationType = "comment"
 	NotificationTypeFollow   NotificationType = "follow"
 	NotificationTypeMention  NotificationType = "mention"
+	NotificationTypeReply       NotificationType = "reply"
+	NotificationTypeCommentLike NotificationType = "comment_like"
+	NotificationTypeFollowRequest  NotificationType = "follow_request"
+	NotificationTypeFollowApproved NotificationType = "follow_approved"
+	NotificationTypeCommunityInvite       NotificationType = "community_invite"
+	NotificationTypeCommunityJoinApproved NotificationType = "community_join_approved"
+	NotificationTypeSavedSearch           NotificationType = "saved_search"
 )
 
 // Notification represents a user notification
@@ -24,3 +38,122 @@ type Notification struct {
 	CreatedAt time.Time              `json:"created_at"`
 }
 
+// MaxNotificationActors is the number of latest actors an aggregated notification keeps with their names
+const MaxNotificationActors = 3
+
+// NotificationActor is a user who caused a notification
+type NotificationActor struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// Aggregated notifications keep in Data:
+//   - actor_id: the latest actor, used to hide notifications of blocked and muted users
+//   - actor_ids: every actor, latest first
+//   - actors: the latest MaxNotificationActors actors with their names
+//   - actors_count: number of distinct actors
+//   - target_id: the publication, comment or user the notifications are about
+
+// TargetID returns the object the notification is about
+func (n *Notification) TargetID() string {
+	id, _ := n.Data["target_id"].(string)
+	return id
+}
+
+// Actors returns the latest actors of the notification, latest first
+func (n *Notification) Actors() []NotificationActor {
+	var actors []NotificationActor
+	decodeData(n.Data["actors"], &actors)
+	return actors
+}
+
+// ActorsCount returns the number of distinct actors of the notification
+func (n *Notification) ActorsCount() int {
+	return len(n.actorIDs())
+}
+
+// AddActor makes actor the latest actor, reporting whether they were not counted yet
+func (n *Notification) AddActor(actor NotificationActor) bool {
+	ids := n.actorIDs()
+	added := true
+	for i, id := range ids {
+		if id == actor.ID {
+			ids = append(ids[:i], ids[i+1:]...)
+			added = false
+			break
+		}
+	}
+	ids = append([]string{actor.ID}, ids...)
+
+	actors := []NotificationActor{actor}
+	for _, a := range n.Actors() {
+		if a.ID != actor.ID && len(actors) < MaxNotificationActors {
+			actors = append(actors, a)
+		}
+	}
+
+	n.setActors(ids, actors)
+	return added
+}
+
+// RemoveActor removes actor from the notification, reporting whether they were counted
+func (n *Notification) RemoveActor(actorID string) bool {
+	ids := n.actorIDs()
+	removed := false
+	for i, id := range ids {
+		if id == actorID {
+			ids = append(ids[:i], ids[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	if !removed {
+		return false
+	}
+
+	var actors []NotificationActor
+	for _, a := range n.Actors() {
+		if a.ID != actorID {
+			actors = append(actors, a)
+		}
+	}
+
+	n.setActors(ids, actors)
+	return true
+}
+
+func (n *Notification) actorIDs() []string {
+	var ids []string
+	decodeData(n.Data["actor_ids"], &ids)
+	if len(ids) == 0 {
+		// Notifications created before aggregation name a single actor
+		if id, ok := n.Data["actor_id"].(string); ok && id != "" {
+			ids = []string{id}
+		}
+	}
+	return ids
+}
+
+func (n *Notification) setActors(ids []string, actors []NotificationActor) {
+	if n.Data == nil {
+		n.Data = make(map[string]interface{})
+	}
+	n.Data["actor_ids"] = ids
+	n.Data["actors"] = actors
+	n.Data["actors_count"] = len(ids)
+	if len(ids) > 0 {
+		n.Data["actor_id"] = ids[0]
+	}
+}
+
+// decodeData converts a Data value, typed or decoded from JSON, into out
+func decodeData(value interface{}, out interface{}) {
+	if value == nil {
+		return
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(raw, out)
+}
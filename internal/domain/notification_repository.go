@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // NotificationRepository defines interface for notification data operations
 type NotificationRepository interface {
@@ -10,10 +13,25 @@ type NotificationRepository interface {
 	// GetByUser retrieves notifications for user
 	GetByUser(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]*Notification, int, error)
 	
-	// MarkAsRead marks notification as read
-	MarkAsRead(ctx context.Context, notificationID string) error
+	// MarkAsRead marks the user's notification as read, returning ErrNotificationNotFound for others' notifications
+	MarkAsRead(ctx context.Context, userID, notificationID string) error
 	
 	// MarkAllAsRead marks all user notifications as read
 	MarkAllAsRead(ctx context.Context, userID string) error
+	
+	// Delete deletes the user's notification, returning ErrNotificationNotFound for others' notifications
+	Delete(ctx context.Context, userID, notificationID string) error
+	
+	// CountUnread counts unread notifications shown to the user
+	CountUnread(ctx context.Context, userID string) (int, error)
+	
+	// Aggregate merges notification into the user's unread notification of the same type about
+	// the same target created within window, or inserts it when there is none; render sets
+	// title and message from the merged actors before saving
+	Aggregate(ctx context.Context, notification *Notification, window time.Duration, render func(*Notification)) error
+	
+	// RemoveActor removes actorID from unread notifications of the type about targetID,
+	// deleting those left without actors; render updates title and message of the rest
+	RemoveActor(ctx context.Context, userID string, notificationType NotificationType, targetID, actorID string, render func(*Notification)) error
 }
 
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationChannel is a way notifications reach the user
+type NotificationChannel string
+
+const (
+	// NotificationChannelInApp stores the notification in the user's list; the other channels
+	// deliver stored notifications, so turning it off turns off the type entirely
+	NotificationChannelInApp NotificationChannel = "in_app"
+	// NotificationChannelEmail includes the notification in the email digest
+	NotificationChannelEmail NotificationChannel = "email"
+	// NotificationChannelPush delivers the notification live over SSE and WebSocket
+	NotificationChannelPush NotificationChannel = "push"
+)
+
+// ConfigurableNotificationTypes are the notification types users can turn off
+var ConfigurableNotificationTypes = []NotificationType{
+	NotificationTypeLike,
+	NotificationTypeComment,
+	NotificationTypeReply,
+	NotificationTypeCommentLike,
+	NotificationTypeFollow,
+	NotificationTypeFollowRequest,
+	NotificationTypeFollowApproved,
+	NotificationTypeCommunityInvite,
+	NotificationTypeCommunityJoinApproved,
+	NotificationTypeSavedSearch,
+}
+
+// DigestFrequency is how often the user receives the email digest
+type DigestFrequency string
+
+const (
+	DigestFrequencyOff    DigestFrequency = "off"
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// Period returns the time covered by one digest
+func (f DigestFrequency) Period() time.Duration {
+	if f == DigestFrequencyDaily {
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// NotificationChannels holds channel toggles of one notification type
+type NotificationChannels struct {
+	InApp bool `json:"in_app"`
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+}
+
+// QuietHours is a daily period in the user's time zone when notifications are not pushed;
+// the period may span midnight
+type QuietHours struct {
+	Start string `json:"start"` // HH:MM
+	End   string `json:"end"`   // HH:MM
+}
+
+// NotificationSettings represents user's notification preferences
+type NotificationSettings struct {
+	UserID            string                                    `json:"-"`
+	Types             map[NotificationType]NotificationChannels `json:"types"`
+	TimeZone          string                                    `json:"time_zone"` // IANA name such as Europe/Minsk
+	QuietHours        *QuietHours                               `json:"quiet_hours"`
+	FromFollowingOnly bool                                      `json:"from_following_only"` // only actions of people the user follows notify
+	Digest            DigestFrequency                           `json:"digest"`
+}
+
+// DefaultNotificationSettings returns the settings of a user who has not changed them:
+// every type on every channel, no quiet hours, a weekly digest
+func DefaultNotificationSettings(userID string) *NotificationSettings {
+	settings := &NotificationSettings{
+		UserID:   userID,
+		Types:    make(map[NotificationType]NotificationChannels),
+		TimeZone: "UTC",
+		Digest:   DigestFrequencyWeekly,
+	}
+	for _, notificationType := range ConfigurableNotificationTypes {
+		settings.Types[notificationType] = NotificationChannels{InApp: true, Email: true, Push: true}
+	}
+	return settings
+}
+
+// IsConfigurableNotificationType reports whether users can turn the type off
+func IsConfigurableNotificationType(notificationType NotificationType) bool {
+	for _, t := range ConfigurableNotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled reports whether notifications of the type reach the user through channel
+func (s *NotificationSettings) Enabled(notificationType NotificationType, channel NotificationChannel) bool {
+	channels, ok := s.Types[notificationType]
+	if !ok || !IsConfigurableNotificationType(notificationType) {
+		return true
+	}
+
+	switch channel {
+	case NotificationChannelInApp:
+		return channels.InApp
+	case NotificationChannelEmail:
+		return channels.InApp && channels.Email
+	case NotificationChannelPush:
+		return channels.InApp && channels.Push
+	default:
+		return false
+	}
+}
+
+// Location returns the user's time zone, UTC if it is unknown
+func (s *NotificationSettings) Location() *time.Location {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// InQuietHours reports whether now falls within the user's quiet hours
+func (s *NotificationSettings) InQuietHours(now time.Time) bool {
+	if s.QuietHours == nil {
+		return false
+	}
+	start, err := parseClock(s.QuietHours.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(s.QuietHours.End)
+	if err != nil || start == end {
+		return false
+	}
+
+	local := now.In(s.Location())
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	// The period spans midnight, e.g. 22:00-07:00
+	return minute >= start || minute < end
+}
+
+// parseClock converts HH:MM into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: %w", value, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// NotificationSettingsRepository defines interface for notification settings data operations
+type NotificationSettingsRepository interface {
+	// Get retrieves user's notification settings, defaults for types and fields never saved
+	Get(ctx context.Context, userID string) (*NotificationSettings, error)
+
+	// Update saves user's notification settings
+	Update(ctx context.Context, settings *NotificationSettings) error
+}
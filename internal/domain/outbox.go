@@ -0,0 +1,11 @@
+package domain
+
+// OutboxMessage is a domain event recorded in the transaction of the change it describes and
+// published by the outbox dispatcher after commit
+type OutboxMessage struct {
+	ID    int64
+	Event *Event
+	// Attempts counts failed dispatches so far
+	Attempts  int
+	LastError *string
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxRepository defines interface for transactional outbox operations; messages are written by
+// other repositories inside their own transactions
+type OutboxRepository interface {
+	// Process locks up to limit due messages, skipping ones locked by other dispatchers, and calls
+	// handle for each; handled messages are marked processed, failed ones are retried with backoff
+	// until they have failed maxAttempts times and are marked failed. Returns the number of
+	// messages handled
+	Process(ctx context.Context, limit, maxAttempts int, handle func(ctx context.Context, message *OutboxMessage) error) (int, error)
+	
+	// DeleteProcessed deletes messages processed before the given time
+	DeleteProcessed(ctx context.Context, before time.Time) (int64, error)
+}
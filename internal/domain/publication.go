@@ -29,7 +29,9 @@ type Publication struct {
 	Content         *string         `json:"content,omitempty"`
 	Source          *string         `json:"source,omitempty"`
 	PublicationDate time.Time       `json:"publication_date"`
+	UpdatedAt       time.Time       `json:"updated_at"`
 	Visibility      VisibilityType  `json:"visibility"`
+	CommunityID     *string         `json:"community_id,omitempty"` // set for publications posted into a community
 	LikesCount      int             `json:"likes_count"`
 	CommentsCount   int             `json:"comments_count"`
 	SavedCount      int             `json:"saved_count"`
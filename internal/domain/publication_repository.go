@@ -52,11 +52,47 @@ type PublicationRepository interface {
 	// GetSaved retrieves saved publications for user with like status
 	GetSaved(ctx context.Context, userID string, filters *PublicationFilters, limit, offset int) ([]*SavedPublicationWithLikeStatus, int, error)
 
-	// Search searches publications by query with like status for viewer
-	Search(ctx context.Context, query string, viewerUserID *string, filters *SearchFilters, limit, offset int) ([]*PublicationWithLikeStatus, int, error)
-
+	// SearchFacets counts publications matching a full-text query by type, tag, author and date bucket
+	SearchFacets(ctx context.Context, query string, viewerUserID *string, filters *SearchFilters, buckets []DateBucket, limit int) (*SearchFacets, error)
+	
+	// SearchSources retrieves publication sources containing query, most cited first, counting
+	// visible publications only
+	SearchSources(ctx context.Context, query string, viewerUserID *string, limit int) ([]*SourceHit, int, error)
+	
+	// CountForReindex counts publications matching filters regardless of visibility
+	CountForReindex(ctx context.Context, filters *SearchFilters) (int, error)
+	
+	// GetSearchDocuments retrieves the searchable form of publications by IDs regardless of
+	// visibility; missing publications are skipped and the order is not preserved
+	GetSearchDocuments(ctx context.Context, ids []string) ([]*SearchDocument, error)
+	
+	// ListSearchDocuments retrieves the searchable form of up to limit publications matching
+	// filters with IDs greater than afterID, in ID order, regardless of visibility
+	ListSearchDocuments(ctx context.Context, filters *SearchFilters, afterID string, limit int) ([]*SearchDocument, error)
+	
 	// GetMediaIDs retrieves media IDs for publication
 	GetMediaIDs(ctx context.Context, publicationID string) ([]string, error)
+
+	// GetByIDs retrieves visible publications by IDs with like status for viewer (order is not preserved)
+	GetByIDs(ctx context.Context, ids []string, viewerUserID *string) ([]*PublicationWithLikeStatus, error)
+
+	// GetFeedByIDs retrieves publications by IDs like GetByIDs for user's feeds, also leaving out authors muted by user
+	GetFeedByIDs(ctx context.Context, userID string, ids []string) ([]*PublicationWithLikeStatus, error)
+
+	// GetByTag retrieves visible publications with tag with like status for viewer
+	GetByTag(ctx context.Context, tagID string, viewerUserID *string, limit, offset int) ([]*PublicationWithLikeStatus, int, error)
+	
+	// GetByCommunity retrieves visible publications posted into community with like status for viewer
+	GetByCommunity(ctx context.Context, communityID string, viewerUserID *string, limit, offset int) ([]*PublicationWithLikeStatus, int, error)
+
+	// GetByFollowing retrieves recent publications of authors followed by user
+	GetByFollowing(ctx context.Context, userID string, since time.Time, limit int) ([]*PublicationWithLikeStatus, error)
+
+	// GetPopularInUserTags retrieves popular recent publications tagged with tags the user engaged with
+	GetPopularInUserTags(ctx context.Context, userID string, since time.Time, limit int) ([]*PublicationWithLikeStatus, error)
+
+	// GetAuthorAffinity returns number of likes given by user to each author
+	GetAuthorAffinity(ctx context.Context, userID string) (map[string]int, error)
 }
 
 // FeedFilters represents filters for feed
@@ -76,9 +112,14 @@ type PublicationFilters struct {
 
 // SearchFilters represents filters for search
 type SearchFilters struct {
-	Type       *PublicationType
-	Visibility *VisibilityType
-	AuthorID   *string
+	Type       *PublicationType `json:"type,omitempty"`
+	Visibility *VisibilityType  `json:"visibility,omitempty"`
+	AuthorID   *string          `json:"author_id,omitempty"`
+	// Tags keeps publications tagged with every one of the tag names
+	Tags []string `json:"tags,omitempty"`
+	// DateFrom and DateTo bound the publication date; DateTo is exclusive
+	DateFrom *time.Time `json:"date_from,omitempty"`
+	DateTo   *time.Time `json:"date_to,omitempty"`
 }
 
 // SavedPublication represents publication with saved metadata
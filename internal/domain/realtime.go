@@ -0,0 +1,64 @@
+package domain
+
+import "context"
+
+// RealtimeEventType represents type of an event pushed to connected clients
+type RealtimeEventType string
+
+const (
+	RealtimeEventMessage      RealtimeEventType = "message"
+	RealtimeEventMessageRead  RealtimeEventType = "message_read"
+	RealtimeEventNotification RealtimeEventType = "notification"
+	RealtimeEventUnreadCount  RealtimeEventType = "unread_count"
+	RealtimeEventComment      RealtimeEventType = "comment"
+)
+
+// RealtimeEvent is an event pushed to a user's live connections
+type RealtimeEvent struct {
+	// ID orders delivered events; clients resume after it with Last-Event-ID. Zero for events sent only on connect
+	ID   int64             `json:"id,omitempty"`
+	Type RealtimeEventType `json:"type"`
+	Data interface{}       `json:"data"`
+}
+
+// UserChannel returns the realtime channel of a user's own events
+func UserChannel(userID string) string {
+	return "user:" + userID
+}
+
+// PublicationChannel returns the realtime channel of events about a publication
+func PublicationChannel(publicationID string) string {
+	return "publication:" + publicationID
+}
+
+// RealtimePublisher delivers events to users over a realtime channel
+type RealtimePublisher interface {
+	// Publish delivers event to every live connection of the user
+	Publish(ctx context.Context, userID string, event *RealtimeEvent) error
+
+	// PublishToPublication delivers event to every live connection watching the publication
+	PublishToPublication(ctx context.Context, publicationID string, event *RealtimeEvent) error
+}
+
+// RealtimeSubscription is a live connection's feed of events from a set of channels
+type RealtimeSubscription interface {
+	// Events returns the events in delivery order; the channel is closed when the subscription ends,
+	// including when the client falls too far behind
+	Events() <-chan *RealtimeEvent
+
+	// Join starts delivering events of channel
+	Join(channel string)
+
+	// Leave stops delivering events of channel
+	Leave(channel string)
+
+	// Close ends the subscription
+	Close()
+}
+
+// RealtimeSubscriber opens subscriptions to realtime channels
+type RealtimeSubscriber interface {
+	// Subscribe opens a subscription to channels; with a positive lastEventID it first replays
+	// the retained events of those channels published after it
+	Subscribe(ctx context.Context, channels []string, lastEventID int64) (RealtimeSubscription, error)
+}
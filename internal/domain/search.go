@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// PublicationSearchResult is a publication matching a search query
+type PublicationSearchResult struct {
+	PublicationWithLikeStatus
+	// Rank is the relevance of the publication to the query; higher is better
+	Rank       float64          `json:"rank"`
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+// SearchHighlights are HTML-escaped fragments of a result with the matched words wrapped in <mark>
+type SearchHighlights struct {
+	Title   string  `json:"title"`
+	Content *string `json:"content,omitempty"`
+}
+
+// SearchDocument is the searchable form of a publication
+type SearchDocument struct {
+	ID              string
+	AuthorID        string
+	Type            PublicationType
+	Visibility      VisibilityType
+	CommunityID     *string
+	Title           string
+	Content         *string
+	Source          *string
+	Tags            []string
+	PublicationDate time.Time
+}
+
+// SearchHit is a publication found by a search index
+type SearchHit struct {
+	ID string
+	// Rank is the relevance of the publication to the query; higher is better. Ranks of
+	// different index backends are not comparable
+	Rank       float64
+	Highlights SearchHighlights
+}
+
+// SearchFacets are counts of matching publications per value of a field; selecting a value
+// narrows the search with the corresponding SearchFilters field
+type SearchFacets struct {
+	Types   []*FacetCount `json:"types"`
+	Tags    []*FacetCount `json:"tags"`
+	Authors []*FacetCount `json:"authors"`
+	Dates   []*FacetCount `json:"dates"`
+}
+
+// FacetCount is the number of matching publications with one value of a facet
+type FacetCount struct {
+	// Value is the type, tag name, author ID or date bucket name
+	Value string `json:"value"`
+	// Label is the author's username
+	Label *string `json:"label,omitempty"`
+	// DateFrom and DateTo are the bounds of a date bucket, to be passed as filters
+	DateFrom *time.Time `json:"date_from,omitempty"`
+	DateTo   *time.Time `json:"date_to,omitempty"`
+	Count    int        `json:"count"`
+}
+
+// DateBucket is a publication date range counted as a facet; nil bounds are open
+type DateBucket struct {
+	Name     string
+	DateFrom *time.Time
+	DateTo   *time.Time
+}
+
+// SourceHit is a publication source matching a search query
+type SourceHit struct {
+	Source            string `json:"source"`
+	PublicationsCount int    `json:"publications_count"`
+}
+
+// UserMatch tells how a username matched a search query
+type UserMatch string
+
+const (
+	UserMatchExact       UserMatch = "exact"
+	UserMatchPrefix      UserMatch = "prefix"
+	UserMatchFuzzy       UserMatch = "fuzzy"
+	UserMatchDescription UserMatch = "description"
+)
+
+// UserSearchResult is a user matching a search query
+type UserSearchResult struct {
+	UserCard
+	FollowersCount int       `json:"followers_count"`
+	Match          UserMatch `json:"match"`
+	// Rank orders users with the same match: username similarity boosted by followers
+	// and by the viewer following the user
+	Rank float64 `json:"rank"`
+}
+
+// UserSuggestion is a user offered by autocomplete, e.g. in a mention picker
+type UserSuggestion struct {
+	ID             string  `json:"id"`
+	Username       string  `json:"username"`
+	IconURL        *string `json:"icon_url,omitempty"`
+	IsFollowedByMe bool    `json:"is_followed_by_me"`
+}
+
+// ErrReindexTaskNotFound is returned when a reindex task does not exist
+var ErrReindexTaskNotFound = errors.New("reindex task not found")
+
+// ReindexStatus represents state of a reindex task
+type ReindexStatus string
+
+const (
+	ReindexQueued    ReindexStatus = "queued"
+	ReindexRunning   ReindexStatus = "running"
+	ReindexSucceeded ReindexStatus = "succeeded"
+	ReindexFailed    ReindexStatus = "failed"
+)
+
+// ReindexTask tracks a rebuild of the search data of publications matching Filters
+type ReindexTask struct {
+	ID          string        `json:"task_id"`
+	RequestedBy string        `json:"requested_by"`
+	Filters     SearchFilters `json:"filters"`
+	Status      ReindexStatus `json:"status"`
+	// Total is the number of matching publications when the task started
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	// Cursor is the ID of the last processed publication; a retried run resumes after it
+	Cursor *string `json:"-"`
+	// Errors are the failures of the runs so far, oldest first
+	Errors     []string   `json:"errors"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// ErrSavedSearchNotFound is returned when the saved search does not exist or belongs to another user
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// SavedSearch is a query with filters whose new matches the user is told about
+type SavedSearch struct {
+	ID      string        `json:"id"`
+	UserID  string        `json:"user_id"`
+	Name    string        `json:"name"`
+	Query   string        `json:"query"`
+	Filters SearchFilters `json:"filters"`
+	// Notify turns notifications about new matches on; matches are collected either way
+	Notify bool `json:"notify"`
+	// NewCount is the number of matches found since SeenAt
+	NewCount int `json:"new_count"`
+	// LastRunAt is when the search last looked for new matches; the next run looks for
+	// publications newer than that
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	SeenAt    time.Time  `json:"seen_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// SuggestionSource tells where a query suggestion comes from
+type SuggestionSource string
+
+const (
+	SuggestionSourceQuery SuggestionSource = "query"
+	SuggestionSourceTag   SuggestionSource = "tag"
+	SuggestionSourceTitle SuggestionSource = "title"
+)
+
+// SearchSuggestion is a completion of a search query being typed
+type SearchSuggestion struct {
+	Text   string           `json:"text"`
+	Source SuggestionSource `json:"source"`
+}
+
+// MinCorrectedWordLength is the shortest word SearchIndex.Correct looks for a correction of;
+// shorter words have too many close neighbours
+const MinCorrectedWordLength = 3
+
+// ErrSearchQueryNotFound is returned when a click refers to a search query that was not logged
+var ErrSearchQueryNotFound = errors.New("search query not found")
+
+// SearchQueryLog is a logged publication search; popular queries are suggested to others
+type SearchQueryLog struct {
+	ID     string
+	UserID *string
+	Query  string
+	// Normalized is the query lowercased with collapsed whitespace; queries are grouped by it
+	Normalized   string
+	ResultsCount int
+	CreatedAt    time.Time
+}
+
+// SearchClick is an opening of a search result
+type SearchClick struct {
+	QueryID       string
+	PublicationID string
+	// UserID is the clicking user, nil for anonymous clicks; it must match the user who searched
+	UserID *string
+	// Position is the zero-based position of the result in the search results
+	Position  int
+	CreatedAt time.Time
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReindexTaskRepository defines interface for reindex task data operations
+type ReindexTaskRepository interface {
+	// Create creates a new reindex task
+	Create(ctx context.Context, task *ReindexTask) error
+	
+	// GetByID retrieves reindex task by ID
+	GetByID(ctx context.Context, id string) (*ReindexTask, error)
+	
+	// Update saves status, progress, cursor and errors of task
+	Update(ctx context.Context, task *ReindexTask) error
+}
+
+// SearchIndex is the full-text index of publications behind search
+type SearchIndex interface {
+	// Index adds documents, replacing their previous versions
+	Index(ctx context.Context, docs ...*SearchDocument) error
+	
+	// Delete removes documents by publication ID, ignoring unknown IDs
+	Delete(ctx context.Context, ids ...string) error
+	
+	// Query finds publications visible to viewer matching query and filters, most relevant first,
+	// with highlights
+	Query(ctx context.Context, query string, viewerUserID *string, filters *SearchFilters, limit, offset int) ([]*SearchHit, int, error)
+	
+	// Suggest retrieves words of public publications starting with prefix, most common first
+	Suggest(ctx context.Context, prefix string, limit int) ([]string, error)
+	
+	// Correct maps each of words that no public publication contains to the closest word of
+	// public publications; words without a close match and words shorter than
+	// MinCorrectedWordLength are left out
+	Correct(ctx context.Context, words []string) (map[string]string, error)
+}
+
+// SavedSearchRepository defines interface for saved search data operations
+type SavedSearchRepository interface {
+	// Create creates a new saved search
+	Create(ctx context.Context, search *SavedSearch) error
+	
+	// GetByID retrieves the user's saved search, returning ErrSavedSearchNotFound for others' searches
+	GetByID(ctx context.Context, userID, searchID string) (*SavedSearch, error)
+	
+	// GetByUser retrieves saved searches of user, newest first
+	GetByUser(ctx context.Context, userID string) ([]*SavedSearch, error)
+	
+	// CountByUser counts saved searches of user
+	CountByUser(ctx context.Context, userID string) (int, error)
+	
+	// Update updates name, query, filters and notify of the saved search
+	Update(ctx context.Context, search *SavedSearch) error
+	
+	// Delete deletes the user's saved search with its matches, returning ErrSavedSearchNotFound for others' searches
+	Delete(ctx context.Context, userID, searchID string) error
+	
+	// List retrieves up to limit saved searches of all users with IDs greater than afterID, in ID order
+	List(ctx context.Context, afterID string, limit int) ([]*SavedSearch, error)
+	
+	// AddMatches records publications found by the saved search at runAt, skipping those found
+	// before, and sets its last run; returns the number of new matches and of unseen ones
+	AddMatches(ctx context.Context, searchID string, publicationIDs []string, runAt time.Time) (added, unseen int, err error)
+	
+	// GetUnseen retrieves IDs of publications found by the saved search since it was last seen, latest found first
+	GetUnseen(ctx context.Context, searchID string, limit, offset int) ([]string, int, error)
+	
+	// MarkSeen marks the matches of the user's saved search found up to at as seen
+	MarkSeen(ctx context.Context, userID, searchID string, at time.Time) error
+}
+
+// SearchLogRepository defines interface for search query and click log operations
+type SearchLogRepository interface {
+	// LogQuery stores a search query
+	LogQuery(ctx context.Context, entry *SearchQueryLog) error
+	
+	// LogClick stores a click on a search result once, returning ErrSearchQueryNotFound for unknown queries and
+	// publications and for queries searched by another user
+	LogClick(ctx context.Context, click *SearchClick) error
+	
+	// PopularQueries retrieves normalized queries starting with prefix that found publications
+	// since the given time and were searched by at least minSearchers users, most searched and
+	// clicked first
+	PopularQueries(ctx context.Context, prefix string, since time.Time, minSearchers, limit int) ([]string, error)
+	
+	// DeleteBefore deletes queries logged before the given time with their clicks
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
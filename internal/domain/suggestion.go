@@ -0,0 +1,21 @@
+package domain
+
+// SuggestionCandidate is a user who may be worth following, with the social graph signals behind it
+type SuggestionCandidate struct {
+	User          *User
+	MutualFollows int      // users followed by the viewer who follow the candidate
+	MutualNames   []string // usernames of a few of those users
+	CoLikes       int      // publications liked by both the viewer and the candidate
+	SharedTags    int      // tags of the viewer's interests the candidate publishes in
+	TopTag        *string  // the most relevant of those tags
+}
+
+// FollowSuggestion is a ranked suggestion with a human-readable reason
+type FollowSuggestion struct {
+	User          *User   `json:"user"`
+	Score         float64 `json:"score"`
+	Reason        string  `json:"reason"`
+	MutualFollows int     `json:"mutual_follows"`
+	CoLikes       int     `json:"co_likes"`
+	SharedTags    int     `json:"shared_tags"`
+}
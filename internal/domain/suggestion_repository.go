@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// SuggestionRepository defines interface for follow suggestion data operations
+type SuggestionRepository interface {
+	// GetCandidates retrieves up to limit suggestion candidates for userID, excluding
+	// the user, followed and requested users, dismissed suggestions and blocked or muted users
+	GetCandidates(ctx context.Context, userID string, limit int) ([]*SuggestionCandidate, error)
+
+	// Dismiss hides a user from userID's suggestions
+	Dismiss(ctx context.Context, userID, dismissedID string) error
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// TrendingPublication represents a publication with its time-decayed engagement score
+type TrendingPublication struct {
+	PublicationWithLikeStatus
+	TrendingScore float64 `json:"trending_score"`
+}
+
+// TrendingTag represents a tag with its time-decayed engagement score
+type TrendingTag struct {
+	Tag
+	TrendingScore             float64 `json:"trending_score"`
+	TrendingPublicationsCount int     `json:"trending_publications_count"`
+}
+
+// TrendingFilters represents filters for trending views
+type TrendingFilters struct {
+	Type     *PublicationType
+	Language *string
+}
+
+// TrendingParams configures trending score computation.
+// Every like, save, comment and view inside Window contributes its weight,
+// halved for each HalfLife elapsed since the event.
+type TrendingParams struct {
+	Window        time.Duration
+	HalfLife      time.Duration
+	LikeWeight    float64
+	SaveWeight    float64
+	CommentWeight float64
+	ViewWeight    float64
+}
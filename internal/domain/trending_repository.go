@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// TrendingRepository defines interface for trending data operations
+type TrendingRepository interface {
+	// Refresh recomputes trending publications and tags
+	Refresh(ctx context.Context, params *TrendingParams) error
+
+	// GetPublications retrieves trending publications with like status for viewer
+	GetPublications(ctx context.Context, viewerUserID *string, filters *TrendingFilters, limit, offset int) ([]*TrendingPublication, int, error)
+
+	// GetTags retrieves trending tags
+	GetTags(ctx context.Context, filters *TrendingFilters, limit int) ([]*TrendingTag, error)
+}
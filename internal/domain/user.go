@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrSuperUserRequired is returned when a user without the super role performs an administrative action
+var ErrSuperUserRequired = errors.New("super user role required")
 
 // UserRole represents user role in the system
 type UserRole string
@@ -23,10 +29,18 @@ type User struct {
 	Description *string   `json:"description,omitempty"`
 	Role         UserRole  `json:"role"`
 	RegisteredAt time.Time `json:"registered_at"`
+	IsPrivate    bool      `json:"is_private"` // follows require approval
 	PasswordHash string    `json:"-"` // Not exposed in JSON
 	Statistic    *UserStatistic `json:"statistic,omitempty"`
 }
 
+// UserCard is a user in a follow list with relationship flags relative to the viewer
+type UserCard struct {
+	User
+	IsFollowedByMe bool `json:"is_followed_by_me"`
+	FollowsMe      bool `json:"follows_me"`
+}
+
 // UserStatistic represents user statistics
 type UserStatistic struct {
 	PublicationsCount int `json:"publications_count"`
@@ -36,4 +50,3 @@ type UserStatistic struct {
 	CommentsReceived  int `json:"comments_received"`
 	SavedCount        int `json:"saved_count"`
 }
-
@@ -40,7 +40,42 @@ type UserRepository interface {
 	// Unfollow removes a follow relationship
 	Unfollow(ctx context.Context, followerID, followingID string) error
 	
-	// Search searches users by query
-	Search(ctx context.Context, query string, role *UserRole, limit, offset int) ([]*User, int, error)
+	// GetFollowers retrieves users following userID as cards relative to viewer,
+	// hiding users blocked by or blocking viewer
+	GetFollowers(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*UserCard, int, error)
+	
+	// GetFollowing retrieves users followed by userID as cards relative to viewer,
+	// hiding users blocked by or blocking viewer
+	GetFollowing(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*UserCard, int, error)
+	
+	// GetMutuals retrieves users who follow userID and are followed back, as cards relative to viewer,
+	// hiding users blocked by or blocking viewer
+	GetMutuals(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*UserCard, int, error)
+	
+	// CreateFollowRequest creates a pending follow request to a private account
+	CreateFollowRequest(ctx context.Context, requesterID, targetID string) error
+	
+	// HasFollowRequest checks if requesterID has a pending follow request to targetID
+	HasFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error)
+	
+	// DeleteFollowRequest removes a pending follow request, reporting whether one existed
+	DeleteFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error)
+	
+	// GetFollowRequests retrieves pending follow requests to targetID, newest first
+	GetFollowRequests(ctx context.Context, targetID string, limit, offset int) ([]*FollowRequest, int, error)
+	
+	// ApproveFollowRequest turns a pending follow request into a follow
+	ApproveFollowRequest(ctx context.Context, requesterID, targetID string) error
+	
+	// ApproveAllFollowRequests turns every pending follow request to targetID into a follow
+	ApproveAllFollowRequests(ctx context.Context, targetID string) error
+	
+	// Search searches users by username similarity and description, exact matches first, then
+	// prefix and fuzzy ones, hiding users blocked by or blocking viewer
+	Search(ctx context.Context, query string, viewerUserID *string, role *UserRole, limit, offset int) ([]*UserSearchResult, int, error)
+	
+	// Autocomplete retrieves users whose username starts with or resembles prefix, hiding users
+	// blocked by or blocking viewer
+	Autocomplete(ctx context.Context, prefix string, viewerUserID *string, limit int) ([]*UserSuggestion, error)
 }
 
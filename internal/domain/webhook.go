@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrWebhookNotFound is returned when a webhook or delivery does not exist or belongs to another user
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// WebhookEventTypes are the domain events webhooks can subscribe to
+var WebhookEventTypes = []EventType{
+	EventPublicationCreated,
+	EventPublicationLiked,
+	EventCommentCreated,
+	EventCommentLiked,
+	EventUserFollowed,
+	EventUserUnfollowed,
+}
+
+// IsWebhookEventType reports whether webhooks can subscribe to the event type
+func IsWebhookEventType(eventType EventType) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is a user-registered URL receiving signed POSTs about events the user performed or
+// that concern the user's publications, comments and profile
+type Webhook struct {
+	ID          string      `json:"id"`
+	UserID      string      `json:"user_id"`
+	URL         string      `json:"url"`
+	Description *string     `json:"description,omitempty"`
+	EventTypes  []EventType `json:"event_types"`
+	// Secret signs the deliveries; it is returned only when the webhook is created
+	Secret   string `json:"secret,omitempty"`
+	IsActive bool   `json:"is_active"`
+	// FailureCount is the number of consecutive failed attempts; the webhook is disabled when it
+	// reaches the configured limit
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook receives the event type
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus represents the state of a delivery
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is waiting for its first attempt or a retry
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliverySucceeded got a 2xx response
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed ran out of attempts
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to a webhook, with the outcome of its latest attempt
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhook_id"`
+	EventID        string                `json:"event_id"` // Payload ID; one delivery per event and webhook besides replays
+	EventType      EventType             `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	ResponseBody   *string               `json:"response_body,omitempty"`
+	Error          *string               `json:"error,omitempty"`
+	DurationMs     *int                  `json:"duration_ms,omitempty"`
+	ReplayOf       *string               `json:"replay_of,omitempty"` // delivery this one replays
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// WebhookResponse is the receiver's answer to a delivery attempt
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookRepository defines interface for webhook data operations
+type WebhookRepository interface {
+	// Create creates a new webhook
+	Create(ctx context.Context, webhook *Webhook) error
+	
+	// GetByID retrieves the user's webhook, returning ErrWebhookNotFound for others' webhooks
+	GetByID(ctx context.Context, userID, webhookID string) (*Webhook, error)
+	
+	// GetByUser retrieves webhooks of user
+	GetByUser(ctx context.Context, userID string) ([]*Webhook, error)
+	
+	// CountByUser counts webhooks of user
+	CountByUser(ctx context.Context, userID string) (int, error)
+	
+	// Update updates URL, description, event types and state of the webhook
+	Update(ctx context.Context, webhook *Webhook) error
+	
+	// Delete deletes the user's webhook with its deliveries, returning ErrWebhookNotFound for others' webhooks
+	Delete(ctx context.Context, userID, webhookID string) error
+	
+	// GetActiveForEvent retrieves active webhooks of the users subscribed to the event type
+	GetActiveForEvent(ctx context.Context, userIDs []string, eventType EventType) ([]*Webhook, error)
+	
+	// CreateDelivery creates a pending delivery due at its NextAttemptAt; a delivery of an event
+	// already queued for the webhook is skipped unless it is a replay
+	CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	
+	// GetDelivery retrieves a delivery of the user's webhook
+	GetDelivery(ctx context.Context, userID, webhookID, deliveryID string) (*WebhookDelivery, error)
+	
+	// GetDeliveries retrieves deliveries of a webhook, latest first
+	GetDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]*WebhookDelivery, int, error)
+	
+	// ClaimDueDeliveries locks up to limit pending deliveries of active webhooks due at now and
+	// postpones them by lease, so a crashed sender's deliveries are retried after the lease;
+	// concurrent callers never claim the same delivery
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+	
+	// GetWebhook retrieves a webhook by ID regardless of its owner, for delivering
+	GetWebhook(ctx context.Context, webhookID string) (*Webhook, error)
+	
+	// RecordAttempt saves the outcome of a delivery attempt
+	RecordAttempt(ctx context.Context, delivery *WebhookDelivery) error
+	
+	// RecordSuccess resets the consecutive failure count of the webhook
+	RecordSuccess(ctx context.Context, webhookID string) error
+	
+	// RecordFailure increments the consecutive failure count of the webhook and disables it when
+	// the count reaches disableAfter; it reports whether the webhook was disabled
+	RecordFailure(ctx context.Context, webhookID string, disableAfter int) (bool, error)
+}
+
+// WebhookSender sends webhook requests
+type WebhookSender interface {
+	// Send POSTs body with headers to url; a response with any status is not an error
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (*WebhookResponse, error)
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"sense-backend/internal/domain"
+)
+
+// Bus is an in-process domain event bus; handlers run synchronously in the publisher's goroutine
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[domain.EventType][]domain.EventHandler
+	onError  func(error)
+}
+
+// NewBus creates an event bus; onError receives handler failures and may be nil
+func NewBus(onError func(error)) *Bus {
+	return &Bus{
+		handlers: make(map[domain.EventType][]domain.EventHandler),
+		onError:  onError,
+	}
+}
+
+// Subscribe registers handler for the given event types
+func (b *Bus) Subscribe(handler domain.EventHandler, types ...domain.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, eventType := range types {
+		b.handlers[eventType] = append(b.handlers[eventType], handler)
+	}
+}
+
+// Publish delivers event to every handler subscribed to its type
+func (b *Bus) Publish(ctx context.Context, event *domain.Event) {
+	for _, err := range b.dispatch(ctx, event) {
+		if b.onError != nil {
+			b.onError(err)
+		}
+	}
+}
+
+// Dispatch delivers event to every handler subscribed to its type and returns their failures;
+// a failing handler does not stop the others
+func (b *Bus) Dispatch(ctx context.Context, event *domain.Event) error {
+	return errors.Join(b.dispatch(ctx, event)...)
+}
+
+func (b *Bus) dispatch(ctx context.Context, event *domain.Event) []error {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("handle %s event: %w", event.Type, err))
+		}
+	}
+	return errs
+}
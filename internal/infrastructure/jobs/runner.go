@@ -0,0 +1,420 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// DefaultQueue is the queue of jobs registered without one
+const DefaultQueue = "default"
+
+// Handler runs a job; a returned error retries it with backoff until MaxAttempts is reached
+type Handler func(ctx context.Context, job *domain.Job) error
+
+// Options configures a job type
+type Options struct {
+	// Queue is the queue jobs of the type run in; it limits their concurrency
+	Queue string
+	// MaxAttempts is the number of runs after which a failing job is given up
+	MaxAttempts int
+	// Timeout is the visibility timeout: a run taking longer is cancelled and the job is given to
+	// another worker
+	Timeout time.Duration
+}
+
+// DefaultOptions run a job in the default queue up to 5 times, 5 minutes each
+var DefaultOptions = Options{
+	Queue:       DefaultQueue,
+	MaxAttempts: 5,
+	Timeout:     5 * time.Minute,
+}
+
+// Params configures the runner
+type Params struct {
+	// Queues maps the queues this process works on to the number of their jobs run in parallel;
+	// jobs of other queues are only enqueued
+	Queues map[string]int
+	// PollInterval is how often due jobs are looked for when none were enqueued by this process
+	PollInterval time.Duration
+	// BackoffBase is the delay before the first retry; every next retry waits twice as long
+	BackoffBase time.Duration
+	// BackoffMax caps the delay between retries
+	BackoffMax time.Duration
+	// Retention is how long succeeded and failed jobs are kept
+	Retention time.Duration
+	// CleanupInterval is how often finished jobs past retention are deleted
+	CleanupInterval time.Duration
+}
+
+// DefaultParams run four jobs of the default queue at a time and keep finished jobs for a week
+var DefaultParams = Params{
+	Queues:          map[string]int{DefaultQueue: 4},
+	PollInterval:    time.Second,
+	BackoffBase:     10 * time.Second,
+	BackoffMax:      time.Hour,
+	Retention:       7 * 24 * time.Hour,
+	CleanupInterval: time.Hour,
+}
+
+type registration struct {
+	handler Handler
+	options Options
+}
+
+type schedule struct {
+	name    string
+	cron    *cronSchedule
+	jobType string
+	payload interface{}
+}
+
+// Runner enqueues jobs and runs them with their registered handlers. Jobs are stored in
+// Postgres, so any replica may run a job enqueued by another one
+type Runner struct {
+	jobRepo domain.JobRepository
+	params  Params
+	onError func(error)
+
+	mu        sync.RWMutex
+	handlers  map[string]registration
+	schedules []*schedule
+	// wake makes a queue look for jobs before its next poll
+	wake map[string]chan struct{}
+}
+
+// NewRunner creates a job runner; onError receives job and polling failures and may be nil
+func NewRunner(jobRepo domain.JobRepository, params Params, onError func(error)) *Runner {
+	wake := make(map[string]chan struct{}, len(params.Queues))
+	for queue := range params.Queues {
+		wake[queue] = make(chan struct{}, 1)
+	}
+	return &Runner{
+		jobRepo:  jobRepo,
+		params:   params,
+		onError:  onError,
+		handlers: make(map[string]registration),
+		wake:     wake,
+	}
+}
+
+// Register sets the handler of a job type; zero options fall back to DefaultOptions
+func (r *Runner) Register(jobType string, handler Handler, options Options) {
+	if options.Queue == "" {
+		options.Queue = DefaultOptions.Queue
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultOptions.MaxAttempts
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultOptions.Timeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[jobType] = registration{handler: handler, options: options}
+}
+
+// Handle registers a handler receiving the job payload decoded into T; a payload that does not
+// decode fails the job without retries
+func Handle[T any](r *Runner, jobType string, handler func(ctx context.Context, payload T) error, options Options) {
+	r.Register(jobType, func(ctx context.Context, job *domain.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("decode %s payload: %w", jobType, err))
+		}
+		return handler(ctx, payload)
+	}, options)
+}
+
+// Schedule enqueues a job of a registered type on a cron schedule evaluated in UTC, such as
+// "*/15 * * * *" or "@daily". Every replica runs the scheduler; each run is enqueued once
+func (r *Runner) Schedule(name, spec, jobType string, payload interface{}) error {
+	cron, err := parseCron(spec)
+	if err != nil {
+		return err
+	}
+	if cron.Next(time.Now().UTC()).IsZero() {
+		return fmt.Errorf("schedule %s: cron %q never matches", name, spec)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.handlers[jobType]; !ok {
+		return fmt.Errorf("schedule %s: job type %s is not registered", name, jobType)
+	}
+	r.schedules = append(r.schedules, &schedule{name: name, cron: cron, jobType: jobType, payload: payload})
+	return nil
+}
+
+// Enqueue schedules a job of a registered type at runAt, or now when runAt is zero
+func (r *Runner) Enqueue(ctx context.Context, jobType string, payload interface{}, runAt time.Time) (*domain.Job, error) {
+	return r.enqueue(ctx, jobType, payload, runAt, nil)
+}
+
+func (r *Runner) enqueue(ctx context.Context, jobType string, payload interface{}, runAt time.Time, uniqueKey *string) (*domain.Job, error) {
+	r.mu.RLock()
+	reg, ok := r.handlers[jobType]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("job type %s is not registered", jobType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", jobType, err)
+	}
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+
+	job := &domain.Job{
+		Queue:          reg.options.Queue,
+		Type:           jobType,
+		Payload:        data,
+		MaxAttempts:    reg.options.MaxAttempts,
+		TimeoutSeconds: int(reg.options.Timeout.Seconds()),
+		RunAt:          runAt,
+		UniqueKey:      uniqueKey,
+	}
+	if err := r.jobRepo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if !runAt.After(time.Now()) {
+		r.notify(job.Queue)
+	}
+	return job, nil
+}
+
+// Run works on the configured queues, enqueues scheduled jobs and deletes old finished jobs until
+// ctx is cancelled, then waits for running jobs to return
+func (r *Runner) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for queue, concurrency := range r.params.Queues {
+		wg.Add(1)
+		go func(queue string, concurrency int) {
+			defer wg.Done()
+			r.runQueue(ctx, queue, max(concurrency, 1))
+		}(queue, concurrency)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.runScheduler(ctx)
+	}()
+
+	r.runCleanup(ctx)
+	wg.Wait()
+}
+
+// runQueue claims as many due jobs as there are free slots and runs each in its own goroutine
+func (r *Runner) runQueue(ctx context.Context, queue string, concurrency int) {
+	ticker := time.NewTicker(r.params.PollInterval)
+	defer ticker.Stop()
+
+	var (
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+		// done wakes the loop when a slot frees up
+		done = make(chan struct{}, 1)
+	)
+	defer wg.Wait()
+
+	for {
+		// Only jobs with a handler in this process are claimed
+		if types, free := r.typesOf(queue), concurrency-len(slots); len(types) > 0 && free > 0 {
+			jobs, err := r.jobRepo.Claim(ctx, queue, types, time.Now(), free)
+			if err != nil && ctx.Err() == nil {
+				r.reportError(fmt.Errorf("claim %s jobs: %w", queue, err))
+			}
+			for _, job := range jobs {
+				slots <- struct{}{}
+				wg.Add(1)
+				go func(job *domain.Job) {
+					defer wg.Done()
+					r.run(ctx, job)
+					<-slots
+					select {
+					case done <- struct{}{}:
+					default:
+					}
+				}(job)
+			}
+			// A full batch means more jobs may be due
+			if len(jobs) == free {
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-done:
+		case <-r.wake[queue]:
+		}
+	}
+}
+
+// run runs a claimed job within its visibility timeout and records the outcome
+func (r *Runner) run(ctx context.Context, job *domain.Job) {
+	r.mu.RLock()
+	reg := r.handlers[job.Type]
+	r.mu.RUnlock()
+
+	runCtx, cancel := context.WithTimeout(ctx, time.Duration(job.TimeoutSeconds)*time.Second)
+	err := r.call(runCtx, reg.handler, job)
+	cancel()
+
+	if err == nil {
+		if err := r.jobRepo.Complete(ctx, job); err != nil && ctx.Err() == nil {
+			r.reportError(fmt.Errorf("complete job %s: %w", job.ID, err))
+		}
+		return
+	}
+	if ctx.Err() != nil {
+		// Shutting down; the visibility timeout expires and the job runs again
+		return
+	}
+
+	r.reportError(fmt.Errorf("job %s (%s) attempt %d: %w", job.ID, job.Type, job.Attempts, err))
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts && !isPermanent(err) {
+		at := time.Now().Add(domain.RetryBackoff(r.params.BackoffBase, r.params.BackoffMax, job.Attempts))
+		retryAt = &at
+	}
+	if err := r.jobRepo.Fail(ctx, job, err.Error(), retryAt); err != nil {
+		r.reportError(fmt.Errorf("fail job %s: %w", job.ID, err))
+	}
+}
+
+// call runs handler, turning a panic into an error
+func (r *Runner) call(ctx context.Context, handler Handler, job *domain.Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v\n%s", p, debug.Stack())
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// runScheduler enqueues the runs of cron schedules as they come due. The unique key of a run is
+// its schedule name and time, so replicas enqueue it once; runs missed while no replica was up
+// are skipped
+func (r *Runner) runScheduler(ctx context.Context) {
+	r.mu.RLock()
+	schedules := append([]*schedule(nil), r.schedules...)
+	r.mu.RUnlock()
+	if len(schedules) == 0 {
+		return
+	}
+
+	next := make([]time.Time, len(schedules))
+	now := time.Now().UTC()
+	for i, s := range schedules {
+		next[i] = s.cron.Next(now)
+	}
+
+	ticker := time.NewTicker(min(r.params.PollInterval, 10*time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now().UTC()
+		for i, s := range schedules {
+			if next[i].IsZero() || now.Before(next[i]) {
+				continue
+			}
+			key := fmt.Sprintf("cron:%s:%d", s.name, next[i].Unix())
+			if _, err := r.enqueue(ctx, s.jobType, s.payload, next[i], &key); err != nil &&
+				!errors.Is(err, domain.ErrJobDuplicate) && ctx.Err() == nil {
+				r.reportError(fmt.Errorf("schedule %s: %w", s.name, err))
+				continue
+			}
+			next[i] = s.cron.Next(now)
+		}
+	}
+}
+
+// runCleanup fails jobs that timed out on their last attempt and deletes old finished jobs
+func (r *Runner) runCleanup(ctx context.Context) {
+	ticker := time.NewTicker(r.params.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if _, err := r.jobRepo.FailExpired(ctx, now); err != nil && ctx.Err() == nil {
+			r.reportError(fmt.Errorf("fail expired jobs: %w", err))
+		}
+		if _, err := r.jobRepo.DeleteFinished(ctx, now.Add(-r.params.Retention)); err != nil && ctx.Err() == nil {
+			r.reportError(fmt.Errorf("delete finished jobs: %w", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// typesOf returns the registered job types of queue
+func (r *Runner) typesOf(queue string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var types []string
+	for jobType, reg := range r.handlers {
+		if reg.options.Queue == queue {
+			types = append(types, jobType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// notify wakes the queue without blocking when this process works on it
+func (r *Runner) notify(queue string) {
+	select {
+	case r.wake[queue] <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Runner) reportError(err error) {
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// permanentError fails a job without retries
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that the job fails at once instead of being retried
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
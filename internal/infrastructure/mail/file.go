@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates a mailer writing every email to an .eml file in dir instead of sending it,
+// for local runs; the files open in any mail client
+func NewFileMailer(dir, from string) (domain.Mailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create mail directory: %w", err)
+	}
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, email *domain.Email) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	message, err := buildMessage(m.from, email, now)
+	if err != nil {
+		return err
+	}
+
+	suffix, err := randomHex()
+	if err != nil {
+		return err
+	}
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(email.To)
+	name := fmt.Sprintf("%s-%s-%s.eml", now.UTC().Format("20060102T150405"), recipient, suffix[:8])
+
+	return os.WriteFile(filepath.Join(m.dir, name), message, 0o600)
+}
@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"fmt"
+
+	"sense-backend/internal/domain"
+	"sense-backend/pkg/config"
+)
+
+// NewMailer creates the mailer selected by the configured driver: smtp or file
+func NewMailer(cfg *config.MailConfig) (domain.Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(SMTPParams{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.From,
+		})
+	case "file":
+		return NewFileMailer(cfg.FileDir, cfg.From)
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
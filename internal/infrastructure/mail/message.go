@@ -0,0 +1,80 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"sort"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// buildMessage renders the email as a multipart/alternative MIME message with the plain text
+// part first, so clients without HTML support show it
+func buildMessage(from string, email *domain.Email, now time.Time) ([]byte, error) {
+	if _, err := mail.ParseAddress(email.To); err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %w", email.To, err)
+	}
+
+	boundary, err := randomHex()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+	writeHeader("From", from)
+	writeHeader("To", email.To)
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+
+	names := make([]string, 0, len(email.Headers))
+	for name := range email.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHeader(name, email.Headers[name])
+	}
+	writeHeader("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", boundary))
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", email.Text},
+		{"text/html", email.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		fmt.Fprintf(&buf, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&buf)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+func randomHex() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
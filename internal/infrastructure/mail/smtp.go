@@ -0,0 +1,64 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// SMTPParams configures the SMTP mailer
+type SMTPParams struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender, such as "Sense <noreply@example.com>"
+	From string
+}
+
+type smtpMailer struct {
+	params SMTPParams
+	sender string
+}
+
+// NewSMTPMailer creates a mailer sending through an SMTP server; STARTTLS is used when the
+// server offers it and credentials are sent only over TLS
+func NewSMTPMailer(params SMTPParams) (domain.Mailer, error) {
+	from, err := mail.ParseAddress(params.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", params.From, err)
+	}
+	return &smtpMailer{params: params, sender: from.Address}, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, email *domain.Email) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	message, err := buildMessage(m.params.From, email, time.Now())
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.params.Username != "" {
+		auth = smtp.PlainAuth("", m.params.Username, m.params.Password, m.params.Host)
+	}
+
+	addr := net.JoinHostPort(m.params.Host, strconv.Itoa(m.params.Port))
+	if err := smtp.SendMail(addr, auth, m.sender, []string{to.Address}, message); err != nil {
+		return fmt.Errorf("send mail to %s: %w", to.Address, err)
+	}
+	return nil
+}
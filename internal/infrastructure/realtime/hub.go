@@ -0,0 +1,443 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// notifyChannel is the Postgres LISTEN/NOTIFY channel announcing new realtime events
+const notifyChannel = "realtime_events"
+
+// Params configures event retention and per-connection buffering
+type Params struct {
+	// Retention is how long events are kept for clients resuming with Last-Event-ID
+	Retention time.Duration
+	// Buffer is the number of undelivered live events a connection may fall behind by
+	// before its subscription is closed
+	Buffer int
+	// ReplayLimit caps the number of events replayed on resume
+	ReplayLimit int
+}
+
+// DefaultParams are used unless configured otherwise
+var DefaultParams = Params{
+	Retention:   time.Hour,
+	Buffer:      64,
+	ReplayLimit: 500,
+}
+
+// Hub publishes realtime events through Postgres and fans them out to the subscriptions of this
+// replica: every event is stored in realtime_events and announced with NOTIFY, and each replica's
+// hub LISTENs and delivers announced events to its local subscribers
+type Hub struct {
+	pool    *pgxpool.Pool
+	params  Params
+	onError func(error)
+
+	mu       sync.RWMutex
+	channels map[string]map[*subscription]struct{}
+}
+
+var (
+	_ domain.RealtimePublisher  = (*Hub)(nil)
+	_ domain.RealtimeSubscriber = (*Hub)(nil)
+)
+
+// NewHub creates a realtime hub; onError receives listener failures and may be nil
+func NewHub(pool *pgxpool.Pool, params Params, onError func(error)) *Hub {
+	return &Hub{
+		pool:     pool,
+		params:   params,
+		onError:  onError,
+		channels: make(map[string]map[*subscription]struct{}),
+	}
+}
+
+// Publish delivers event to every live connection of the user
+func (h *Hub) Publish(ctx context.Context, userID string, event *domain.RealtimeEvent) error {
+	return h.publish(ctx, domain.UserChannel(userID), event)
+}
+
+// PublishToPublication delivers event to every live connection watching the publication
+func (h *Hub) PublishToPublication(ctx context.Context, publicationID string, event *domain.RealtimeEvent) error {
+	return h.publish(ctx, domain.PublicationChannel(publicationID), event)
+}
+
+func (h *Hub) publish(ctx context.Context, channel string, event *domain.RealtimeEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	// The payload carries only the ID and channel: NOTIFY payloads are limited to 8000 bytes,
+	// and replicas without subscribers to the channel skip the event without loading it
+	_, err = h.pool.Exec(ctx, `
+		WITH e AS (
+			INSERT INTO realtime_events (channel, type, data)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		)
+		SELECT pg_notify('`+notifyChannel+`', e.id::text || ':' || $1::text) FROM e
+	`, channel, event.Type, data)
+	return err
+}
+
+// Subscribe opens a subscription to channels; with a positive lastEventID it first replays
+// the retained events of those channels published after it
+func (h *Hub) Subscribe(ctx context.Context, channels []string, lastEventID int64) (domain.RealtimeSubscription, error) {
+	s := &subscription{
+		hub:      h,
+		events:   make(chan *domain.RealtimeEvent, h.params.Buffer+h.params.ReplayLimit),
+		channels: make(map[string]struct{}),
+		lastID:   lastEventID,
+	}
+
+	// Live events wait for the replay under the subscription lock, so they are not delivered
+	// ahead of older replayed events
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, channel := range channels {
+		s.channels[channel] = struct{}{}
+		h.add(s, channel)
+	}
+
+	if lastEventID > 0 {
+		if err := h.replay(ctx, s); err != nil {
+			s.closeLocked()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// replay sends the retained events of the subscription's channels after its last delivered event;
+// the caller holds s.mu
+func (h *Hub) replay(ctx context.Context, s *subscription) error {
+	channels := make([]string, 0, len(s.channels))
+	for channel := range s.channels {
+		channels = append(channels, channel)
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	rows, err := h.pool.Query(ctx, `
+		SELECT id, type, data
+		FROM realtime_events
+		WHERE channel = ANY($1) AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`, channels, s.lastID, h.params.ReplayLimit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return err
+		}
+		if event.ID <= s.replayedUpTo {
+			continue
+		}
+		s.replayedUpTo = event.ID
+		if !s.sendLocked(event) {
+			return nil
+		}
+	}
+
+	return rows.Err()
+}
+
+// Run listens for events announced by every replica and delivers them to local subscribers until
+// ctx is cancelled, reconnecting with backoff; it also deletes events older than the retention.
+// When it returns every subscription is closed, so clients reconnect to another replica
+func (h *Hub) Run(ctx context.Context) {
+	go h.cleanup(ctx)
+	defer h.closeAll()
+
+	backoff := time.Second
+	for {
+		err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		h.report(fmt.Errorf("listen for realtime events: %w", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (h *Hub) listen(ctx context.Context) error {
+	conn, err := h.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays subscribed to the channel, so it is not returned to the pool
+	pgConn := conn.Hijack()
+	defer func() {
+		_ = pgConn.Close(context.Background())
+	}()
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	// Events published while this replica was not listening are picked up from the table
+	h.catchUp(ctx)
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		h.dispatch(ctx, notification.Payload)
+	}
+}
+
+// dispatch loads an announced event and delivers it to the subscribers of its channel
+func (h *Hub) dispatch(ctx context.Context, payload string) {
+	idText, channel, ok := strings.Cut(payload, ":")
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if err != nil {
+		return
+	}
+
+	subscribers := h.subscribers(channel)
+	if len(subscribers) == 0 {
+		return
+	}
+
+	event, err := scanEvent(h.pool.QueryRow(ctx, `
+		SELECT id, type, data FROM realtime_events WHERE id = $1
+	`, id))
+	if err != nil {
+		h.report(fmt.Errorf("load realtime event %d: %w", id, err))
+		return
+	}
+
+	for _, s := range subscribers {
+		s.deliver(event)
+	}
+}
+
+// catchUp replays missed events to every local subscription after the listener reconnects
+func (h *Hub) catchUp(ctx context.Context) {
+	for _, s := range h.all() {
+		s.mu.Lock()
+		if !s.closed && s.lastID > 0 {
+			if err := h.replay(ctx, s); err != nil {
+				h.report(fmt.Errorf("replay realtime events: %w", err))
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (h *Hub) closeAll() {
+	for _, s := range h.all() {
+		s.Close()
+	}
+}
+
+func (h *Hub) cleanup(ctx context.Context) {
+	ticker := time.NewTicker(h.params.Retention / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := h.pool.Exec(ctx, `
+				DELETE FROM realtime_events WHERE created_at < now() - make_interval(secs => $1)
+			`, h.params.Retention.Seconds())
+			if err != nil && ctx.Err() == nil {
+				h.report(fmt.Errorf("delete old realtime events: %w", err))
+			}
+		}
+	}
+}
+
+// all returns every local subscription
+func (h *Hub) all() []*subscription {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[*subscription]struct{})
+	var subscriptions []*subscription
+	for _, subscribers := range h.channels {
+		for s := range subscribers {
+			if _, ok := seen[s]; !ok {
+				seen[s] = struct{}{}
+				subscriptions = append(subscriptions, s)
+			}
+		}
+	}
+	return subscriptions
+}
+
+func (h *Hub) subscribers(channel string) []*subscription {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	subscribers := make([]*subscription, 0, len(h.channels[channel]))
+	for s := range h.channels[channel] {
+		subscribers = append(subscribers, s)
+	}
+	return subscribers
+}
+
+func (h *Hub) add(s *subscription, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.channels[channel] == nil {
+		h.channels[channel] = make(map[*subscription]struct{})
+	}
+	h.channels[channel][s] = struct{}{}
+}
+
+func (h *Hub) remove(s *subscription, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.channels[channel], s)
+	if len(h.channels[channel]) == 0 {
+		delete(h.channels, channel)
+	}
+}
+
+func (h *Hub) report(err error) {
+	if h.onError != nil {
+		h.onError(err)
+	}
+}
+
+type eventRow interface {
+	Scan(dest ...any) error
+}
+
+func scanEvent(row eventRow) (*domain.RealtimeEvent, error) {
+	var (
+		event domain.RealtimeEvent
+		data  []byte
+	)
+	if err := row.Scan(&event.ID, &event.Type, &data); err != nil {
+		return nil, err
+	}
+	event.Data = json.RawMessage(data)
+	return &event, nil
+}
+
+// subscription delivers events of its channels to one live connection
+type subscription struct {
+	hub    *Hub
+	events chan *domain.RealtimeEvent
+
+	mu       sync.Mutex
+	channels map[string]struct{}
+	// lastID is the latest event ID delivered, used to replay events missed while reconnecting
+	lastID int64
+	// replayedUpTo is the latest replayed event ID; live announcements of events up to it were
+	// replayed already and are not delivered twice
+	replayedUpTo int64
+	closed       bool
+}
+
+func (s *subscription) Events() <-chan *domain.RealtimeEvent {
+	return s.events
+}
+
+func (s *subscription) Join(channel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	if _, ok := s.channels[channel]; ok {
+		return
+	}
+	s.channels[channel] = struct{}{}
+	s.hub.add(s, channel)
+}
+
+func (s *subscription) Leave(channel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.channels[channel]; !ok {
+		return
+	}
+	delete(s.channels, channel)
+	s.hub.remove(s, channel)
+}
+
+func (s *subscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closeLocked()
+}
+
+func (s *subscription) deliver(event *domain.RealtimeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	if event.ID <= s.replayedUpTo {
+		return
+	}
+	s.sendLocked(event)
+}
+
+// sendLocked queues event without blocking; a connection that fell too far behind is closed
+// and resumes with Last-Event-ID. It reports whether the subscription is still open
+func (s *subscription) sendLocked(event *domain.RealtimeEvent) bool {
+	select {
+	case s.events <- event:
+		if event.ID > s.lastID {
+			s.lastID = event.ID
+		}
+		return true
+	default:
+		s.closeLocked()
+		return false
+	}
+}
+
+func (s *subscription) closeLocked() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	for channel := range s.channels {
+		s.hub.remove(s, channel)
+	}
+	close(s.events)
+}
@@ -0,0 +1,118 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// notificationRepository pushes notification changes to the recipient's live connections;
+// every use case writing notifications goes through it, so none of them has to know about realtime
+type notificationRepository struct {
+	domain.NotificationRepository
+	settingsRepo domain.NotificationSettingsRepository
+	publisher    domain.RealtimePublisher
+	onError      func(error)
+}
+
+// NewNotificationRepository wraps repo so that new and updated notifications and unread count
+// changes are published to the user; notifications are pushed only when the user's push channel
+// is on for the type and outside their quiet hours. onError receives publishing failures and may be nil
+func NewNotificationRepository(
+	repo domain.NotificationRepository,
+	settingsRepo domain.NotificationSettingsRepository,
+	publisher domain.RealtimePublisher,
+	onError func(error),
+) domain.NotificationRepository {
+	return &notificationRepository{
+		NotificationRepository: repo,
+		settingsRepo:           settingsRepo,
+		publisher:              publisher,
+		onError:                onError,
+	}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	if err := r.NotificationRepository.Create(ctx, notification); err != nil {
+		return err
+	}
+	r.pushNotification(ctx, notification)
+	return nil
+}
+
+func (r *notificationRepository) MarkAsRead(ctx context.Context, userID, notificationID string) error {
+	if err := r.NotificationRepository.MarkAsRead(ctx, userID, notificationID); err != nil {
+		return err
+	}
+	r.pushUnreadCount(ctx, userID)
+	return nil
+}
+
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID string) error {
+	if err := r.NotificationRepository.MarkAllAsRead(ctx, userID); err != nil {
+		return err
+	}
+	r.pushUnreadCount(ctx, userID)
+	return nil
+}
+
+func (r *notificationRepository) Delete(ctx context.Context, userID, notificationID string) error {
+	if err := r.NotificationRepository.Delete(ctx, userID, notificationID); err != nil {
+		return err
+	}
+	r.pushUnreadCount(ctx, userID)
+	return nil
+}
+
+func (r *notificationRepository) Aggregate(ctx context.Context, notification *domain.Notification, window time.Duration, render func(*domain.Notification)) error {
+	if err := r.NotificationRepository.Aggregate(ctx, notification, window, render); err != nil {
+		return err
+	}
+	r.pushNotification(ctx, notification)
+	return nil
+}
+
+func (r *notificationRepository) RemoveActor(ctx context.Context, userID string, notificationType domain.NotificationType, targetID, actorID string, render func(*domain.Notification)) error {
+	if err := r.NotificationRepository.RemoveActor(ctx, userID, notificationType, targetID, actorID, render); err != nil {
+		return err
+	}
+	r.pushUnreadCount(ctx, userID)
+	return nil
+}
+
+// pushNotification publishes the created or merged notification followed by the new unread count;
+// the count is updated silently even when the notification itself is not pushed
+func (r *notificationRepository) pushNotification(ctx context.Context, notification *domain.Notification) {
+	settings, err := r.settingsRepo.Get(ctx, notification.UserID)
+	if err == nil && settings.Enabled(notification.Type, domain.NotificationChannelPush) && !settings.InQuietHours(time.Now()) {
+		err = r.publisher.Publish(ctx, notification.UserID, &domain.RealtimeEvent{
+			Type: domain.RealtimeEventNotification,
+			Data: notification,
+		})
+	}
+	if err != nil {
+		r.report(fmt.Errorf("publish notification: %w", err))
+	}
+	r.pushUnreadCount(ctx, notification.UserID)
+}
+
+func (r *notificationRepository) pushUnreadCount(ctx context.Context, userID string) {
+	count, err := r.NotificationRepository.CountUnread(ctx, userID)
+	if err == nil {
+		err = r.publisher.Publish(ctx, userID, &domain.RealtimeEvent{
+			Type: domain.RealtimeEventUnreadCount,
+			Data: map[string]int{"count": count},
+		})
+	}
+	if err != nil {
+		r.report(fmt.Errorf("publish unread count: %w", err))
+	}
+}
+
+func (r *notificationRepository) report(err error) {
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
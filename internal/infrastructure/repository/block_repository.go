@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type blockRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBlockRepository creates a new block repository
+func NewBlockRepository(pool *pgxpool.Pool) domain.BlockRepository {
+	return &blockRepository{pool: pool}
+}
+
+// notBlockedCondition excludes rows whose user column blocked the viewer or was blocked by them.
+// viewer is a placeholder such as $1; a NULL viewer matches no block.
+func notBlockedCondition(userColumn, viewer string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM user_blocks ub
+		WHERE (ub.blocker_id = %[2]s AND ub.blocked_id = %[1]s)
+		   OR (ub.blocker_id = %[1]s AND ub.blocked_id = %[2]s))`, userColumn, viewer)
+}
+
+// notMutedCondition excludes rows whose user column is muted by the viewer.
+// viewer is a placeholder such as $1; a NULL viewer matches no mute.
+func notMutedCondition(userColumn, viewer string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM user_mutes um
+		WHERE um.muter_id = %[2]s AND um.muted_id = %[1]s)`, userColumn, viewer)
+}
+
+// rowQuerier is implemented by both the pool and transactions
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// isBlockedEither checks if either user blocked the other
+func isBlockedEither(ctx context.Context, q rowQuerier, userID, otherUserID string) (bool, error) {
+	var exists bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2)
+			   OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`, userID, otherUserID).Scan(&exists)
+	return exists, err
+}
+
+// ensureNotBlocked returns domain.ErrBlocked if either user blocked the other
+func ensureNotBlocked(ctx context.Context, q rowQuerier, userID, otherUserID string) error {
+	blocked, err := isBlockedEither(ctx, q, userID, otherUserID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return domain.ErrBlocked
+	}
+	return nil
+}
+
+func (r *blockRepository) Block(ctx context.Context, blockerID, blockedID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM user_follows
+		WHERE (follower_id = $1 AND following_id = $2)
+		   OR (follower_id = $2 AND following_id = $1)
+	`, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM follow_requests
+		WHERE (requester_id = $1 AND target_id = $2)
+		   OR (requester_id = $2 AND target_id = $1)
+	`, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *blockRepository) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2
+	`, blockerID, blockedID)
+	return err
+}
+
+func (r *blockRepository) IsBlockedEither(ctx context.Context, userID, otherUserID string) (bool, error) {
+	return isBlockedEither(ctx, r.pool, userID, otherUserID)
+}
+
+func (r *blockRepository) GetBlocked(ctx context.Context, userID string, limit, offset int) ([]*domain.User, int, error) {
+	return r.listUsers(ctx, "user_blocks", "blocker_id", "blocked_id", userID, limit, offset)
+}
+
+func (r *blockRepository) Mute(ctx context.Context, muterID, mutedID string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO user_mutes (muter_id, muted_id)
+		VALUES ($1, $2)
+		ON CONFLICT (muter_id, muted_id) DO NOTHING
+	`, muterID, mutedID)
+	return err
+}
+
+func (r *blockRepository) Unmute(ctx context.Context, muterID, mutedID string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM user_mutes WHERE muter_id = $1 AND muted_id = $2
+	`, muterID, mutedID)
+	return err
+}
+
+func (r *blockRepository) IsMuted(ctx context.Context, muterID, mutedID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = $2)
+	`, muterID, mutedID).Scan(&exists)
+	return exists, err
+}
+
+func (r *blockRepository) GetMuted(ctx context.Context, userID string, limit, offset int) ([]*domain.User, int, error) {
+	return r.listUsers(ctx, "user_mutes", "muter_id", "muted_id", userID, limit, offset)
+}
+
+// listUsers lists users referenced by targetColumn in table for rows owned by ownerColumn
+func (r *blockRepository) listUsers(ctx context.Context, table, ownerColumn, targetColumn, userID string, limit, offset int) ([]*domain.User, int, error) {
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = $1", table, ownerColumn)
+	if err := r.pool.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+publicUserColumns+`
+		FROM %[1]s t
+		INNER JOIN users u ON u.id = t.%[3]s
+		WHERE t.%[2]s = $1
+		ORDER BY t.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, table, ownerColumn, targetColumn)
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+		); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, total, rows.Err()
+}
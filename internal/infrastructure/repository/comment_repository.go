@@ -20,11 +20,32 @@ func NewCommentRepository(pool *pgxpool.Pool) domain.CommentRepository {
 }
 
 func (r *commentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	// Commenting is rejected if the publication author or the parent comment author
+	// and the commenter blocked each other
+	var publicationAuthorID string
+	err := r.pool.QueryRow(ctx, `SELECT author_id FROM publications WHERE id = $1`, comment.PublicationID).Scan(&publicationAuthorID)
+	if err != nil {
+		return fmt.Errorf("publication not found: %w", err)
+	}
+	if err := ensureNotBlocked(ctx, r.pool, comment.AuthorID, publicationAuthorID); err != nil {
+		return err
+	}
+	if comment.ParentID != nil {
+		var parentAuthorID string
+		err := r.pool.QueryRow(ctx, `SELECT author_id FROM comments WHERE id = $1`, *comment.ParentID).Scan(&parentAuthorID)
+		if err != nil {
+			return fmt.Errorf("parent comment not found: %w", err)
+		}
+		if err := ensureNotBlocked(ctx, r.pool, comment.AuthorID, parentAuthorID); err != nil {
+			return err
+		}
+	}
+
 	query := `
 		INSERT INTO comments (id, publication_id, parent_id, author_id, text, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6)
 	`
-	_, err := r.pool.Exec(ctx, query,
+	_, err = r.pool.Exec(ctx, query,
 		comment.ID, comment.PublicationID, comment.ParentID, comment.AuthorID,
 		comment.Text, comment.CreatedAt,
 	)
@@ -52,12 +73,15 @@ func (r *commentRepository) GetByID(ctx context.Context, id string) (*domain.Com
 	return &comment, err
 }
 
-func (r *commentRepository) GetByPublication(ctx context.Context, publicationID string, limit, offset int) ([]*domain.Comment, int, error) {
+func (r *commentRepository) GetByPublication(ctx context.Context, publicationID string, viewerUserID *string, limit, offset int) ([]*domain.Comment, int, error) {
+	// Comments of users blocked by or blocking the viewer are hidden
+	notBlocked := notBlockedCondition("c.author_id", "$2::uuid")
+
 	// Get total
 	var total int
 	err := r.pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM comments WHERE publication_id = $1
-	`, publicationID).Scan(&total)
+		SELECT COUNT(*) FROM comments c WHERE c.publication_id = $1 AND `+notBlocked,
+		publicationID, viewerUserID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -69,10 +93,10 @@ func (r *commentRepository) GetByPublication(ctx context.Context, publicationID
 		FROM comments c
 		LEFT JOIN (SELECT comment_id, COUNT(*) as count FROM comment_likes GROUP BY comment_id) likes 
 		  ON c.id = likes.comment_id
-		WHERE c.publication_id = $1
+		WHERE c.publication_id = $1 AND `+notBlocked+`
 		ORDER BY c.created_at ASC
-		LIMIT $2 OFFSET $3
-	`, publicationID, limit, offset)
+		LIMIT $3 OFFSET $4
+	`, publicationID, viewerUserID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -138,7 +162,16 @@ func (r *commentRepository) Like(ctx context.Context, userID, commentID string)
 		}
 		return false, tx.Commit(ctx)
 	} else {
-		// Like
+		// Like, unless the comment author and the user blocked each other
+		var authorID string
+		err = tx.QueryRow(ctx, `SELECT author_id FROM comments WHERE id = $1`, commentID).Scan(&authorID)
+		if err != nil {
+			return false, err
+		}
+		if err := ensureNotBlocked(ctx, tx, userID, authorID); err != nil {
+			return false, err
+		}
+
 		_, err = tx.Exec(ctx, `
 			INSERT INTO comment_likes (user_id, comment_id) VALUES ($1, $2)
 		`, userID, commentID)
@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type communityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommunityRepository creates a new community repository
+func NewCommunityRepository(pool *pgxpool.Pool) domain.CommunityRepository {
+	return &communityRepository{pool: pool}
+}
+
+// communitySelect selects community columns with members count and the role of viewer ($1, may be NULL)
+const communitySelect = `
+	SELECT c.id, c.name, c.description, c.icon_url, c.join_policy, c.owner_id, c.created_at,
+	       (SELECT COUNT(*) FROM community_members cm WHERE cm.community_id = c.id) AS members_count,
+	       (SELECT vm.role FROM community_members vm WHERE vm.community_id = c.id AND vm.user_id = $1) AS viewer_role
+	FROM communities c`
+
+func scanCommunity(row pgx.Row) (*domain.Community, error) {
+	var community domain.Community
+	err := row.Scan(
+		&community.ID, &community.Name, &community.Description, &community.IconURL,
+		&community.JoinPolicy, &community.OwnerID, &community.CreatedAt,
+		&community.MembersCount, &community.ViewerRole,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &community, nil
+}
+
+func (r *communityRepository) Create(ctx context.Context, community *domain.Community) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO communities (id, name, description, icon_url, join_policy, owner_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, community.ID, community.Name, community.Description, community.IconURL,
+		community.JoinPolicy, community.OwnerID, community.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO community_members (community_id, user_id, role, joined_at)
+		VALUES ($1, $2, 'owner', $3)
+	`, community.ID, community.OwnerID, community.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *communityRepository) GetByID(ctx context.Context, id string, viewerUserID *string) (*domain.Community, error) {
+	community, err := scanCommunity(r.pool.QueryRow(ctx, communitySelect+`
+		WHERE c.id = $2
+	`, viewerUserID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("community not found")
+	}
+	return community, err
+}
+
+func (r *communityRepository) List(ctx context.Context, query string, viewerUserID *string, limit, offset int) ([]*domain.Community, int, error) {
+	// An empty query matches every community
+	where := func(q string) string {
+		return fmt.Sprintf("(%[1]s::text = '' OR c.name ILIKE '%%' || %[1]s || '%%' OR c.description ILIKE '%%' || %[1]s || '%%')", q)
+	}
+
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM communities c WHERE `+where("$1"), query).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, communitySelect+`
+		WHERE `+where("$2")+`
+		ORDER BY members_count DESC, c.created_at DESC
+		LIMIT $3 OFFSET $4
+	`, viewerUserID, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var communities []*domain.Community
+	for rows.Next() {
+		community, err := scanCommunity(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		communities = append(communities, community)
+	}
+
+	return communities, total, rows.Err()
+}
+
+func (r *communityRepository) Update(ctx context.Context, community *domain.Community) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE communities
+		SET name = $2, description = $3, icon_url = $4, join_policy = $5
+		WHERE id = $1
+	`, community.ID, community.Name, community.Description, community.IconURL, community.JoinPolicy)
+	return err
+}
+
+func (r *communityRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM communities WHERE id = $1`, id)
+	return err
+}
+
+func (r *communityRepository) GetRole(ctx context.Context, communityID, userID string) (*domain.CommunityRole, error) {
+	var role domain.CommunityRole
+	err := r.pool.QueryRow(ctx, `
+		SELECT role FROM community_members WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *communityRepository) AddMember(ctx context.Context, communityID, userID string, role domain.CommunityRole) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO community_members (community_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (community_id, user_id) DO NOTHING
+	`, communityID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM community_join_requests WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM community_invites WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *communityRepository) RemoveMember(ctx context.Context, communityID, userID string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM community_members WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID)
+	return err
+}
+
+func (r *communityRepository) SetRole(ctx context.Context, communityID, userID string, role domain.CommunityRole) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE community_members SET role = $3 WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("member not found")
+	}
+	return nil
+}
+
+func (r *communityRepository) GetMembers(ctx context.Context, communityID string, limit, offset int) ([]*domain.CommunityMember, int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM community_members WHERE community_id = $1
+	`, communityID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Enum order puts the owner first, then moderators
+	rows, err := r.pool.Query(ctx, `
+		SELECT cm.community_id, cm.role, cm.joined_at,
+		       `+publicUserColumns+`
+		FROM community_members cm
+		INNER JOIN users u ON u.id = cm.user_id
+		WHERE cm.community_id = $1
+		ORDER BY cm.role, cm.joined_at
+		LIMIT $2 OFFSET $3
+	`, communityID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var members []*domain.CommunityMember
+	for rows.Next() {
+		var member domain.CommunityMember
+		var user domain.User
+		if err := rows.Scan(
+			&member.CommunityID, &member.Role, &member.JoinedAt,
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+		); err != nil {
+			return nil, 0, err
+		}
+		member.User = &user
+		members = append(members, &member)
+	}
+
+	return members, total, rows.Err()
+}
+
+func (r *communityRepository) CreateJoinRequest(ctx context.Context, communityID, userID string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO community_join_requests (community_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (community_id, user_id) DO NOTHING
+	`, communityID, userID)
+	return err
+}
+
+func (r *communityRepository) DeleteJoinRequest(ctx context.Context, communityID, userID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM community_join_requests WHERE community_id = $1 AND user_id = $2
+	`, communityID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *communityRepository) GetJoinRequests(ctx context.Context, communityID string, limit, offset int) ([]*domain.CommunityJoinRequest, int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM community_join_requests WHERE community_id = $1
+	`, communityID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT jr.community_id, jr.created_at,
+		       `+publicUserColumns+`
+		FROM community_join_requests jr
+		INNER JOIN users u ON u.id = jr.user_id
+		WHERE jr.community_id = $1
+		ORDER BY jr.created_at
+		LIMIT $2 OFFSET $3
+	`, communityID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var requests []*domain.CommunityJoinRequest
+	for rows.Next() {
+		var request domain.CommunityJoinRequest
+		var user domain.User
+		if err := rows.Scan(
+			&request.CommunityID, &request.CreatedAt,
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+		); err != nil {
+			return nil, 0, err
+		}
+		request.User = &user
+		requests = append(requests, &request)
+	}
+
+	return requests, total, rows.Err()
+}
+
+func (r *communityRepository) CreateInvite(ctx context.Context, communityID, userID, invitedBy string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO community_invites (community_id, user_id, invited_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (community_id, user_id) DO NOTHING
+	`, communityID, userID, invitedBy)
+	return err
+}
+
+func (r *communityRepository) HasInvite(ctx context.Context, communityID, userID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM community_invites WHERE community_id = $1 AND user_id = $2)
+	`, communityID, userID).Scan(&exists)
+	return exists, err
+}
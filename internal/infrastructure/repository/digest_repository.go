@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type digestRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDigestRepository creates a new email digest repository
+func NewDigestRepository(pool *pgxpool.Pool) domain.DigestRepository {
+	return &digestRepository{pool: pool}
+}
+
+func (r *digestRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*domain.DigestRecipient, error) {
+	// Users without a settings row get the default weekly digest. Locking the user rows with
+	// SKIP LOCKED and moving digest_sent_at in the same statement keeps other instances from
+	// claiming the same users
+	query := `
+		WITH due AS (
+			SELECT u.id,
+			       COALESCE(s.digest_frequency, 'weekly') AS frequency,
+			       s.digest_sent_at AS last_sent_at
+			FROM users u
+			LEFT JOIN notification_settings s ON s.user_id = u.id
+			WHERE u.email IS NOT NULL AND u.email <> ''
+			  AND COALESCE(s.digest_frequency, 'weekly') <> 'off'
+			  AND (s.digest_sent_at IS NULL OR s.digest_sent_at <= $1 - CASE COALESCE(s.digest_frequency, 'weekly')
+			           WHEN 'daily' THEN interval '1 day'
+			           ELSE interval '7 days'
+			       END)
+			ORDER BY u.id
+			LIMIT $2
+			FOR UPDATE OF u SKIP LOCKED
+		), claimed AS (
+			INSERT INTO notification_settings (user_id, digest_sent_at)
+			SELECT id, $1 FROM due
+			ON CONFLICT (user_id) DO UPDATE SET digest_sent_at = EXCLUDED.digest_sent_at
+		)
+		SELECT u.id, u.username, u.email, u.registered_at, d.frequency, d.last_sent_at
+		FROM due d
+		INNER JOIN users u ON u.id = d.id
+		ORDER BY u.id
+	`
+	rows, err := r.pool.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []*domain.DigestRecipient
+	for rows.Next() {
+		recipient := &domain.DigestRecipient{User: &domain.User{}}
+		if err := rows.Scan(
+			&recipient.User.ID, &recipient.User.Username, &recipient.User.Email, &recipient.User.RegisteredAt,
+			&recipient.Frequency, &recipient.LastSentAt,
+		); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+func (r *digestRepository) Release(ctx context.Context, userID string, lastSentAt *time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE notification_settings SET digest_sent_at = $2 WHERE user_id = $1
+	`, userID, lastSentAt)
+	return err
+}
+
+func (r *digestRepository) GetNewFollowers(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.User, int, error) {
+	where := "f.following_id = $1 AND f.created_at > $2 AND " + notBlockedCondition("f.follower_id", "$1")
+
+	var total int
+	if err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM user_follows f WHERE "+where, userID, since).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT u.id, u.username, u.icon_url, u.description
+		FROM user_follows f
+		INNER JOIN users u ON u.id = f.follower_id
+		WHERE `+where+`
+		ORDER BY f.created_at DESC
+		LIMIT $3
+	`, userID, since, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Username, &user.IconURL, &user.Description); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, total, rows.Err()
+}
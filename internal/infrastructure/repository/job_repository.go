@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type jobRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(pool *pgxpool.Pool) domain.JobRepository {
+	return &jobRepository{pool: pool}
+}
+
+const jobColumns = `id, queue, type, payload, status, attempts, max_attempts, timeout_seconds, run_at,
+	locked_until, last_error, unique_key, created_at, updated_at, finished_at`
+
+func (r *jobRepository) Enqueue(ctx context.Context, job *domain.Job) error {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO jobs (queue, type, payload, max_attempts, timeout_seconds, run_at, unique_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (unique_key) WHERE unique_key IS NOT NULL DO NOTHING
+		RETURNING id, status, created_at, updated_at
+	`, job.Queue, job.Type, job.Payload, job.MaxAttempts, job.TimeoutSeconds, job.RunAt, job.UniqueKey,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrJobDuplicate
+	}
+	return err
+}
+
+func (r *jobRepository) Claim(ctx context.Context, queue string, types []string, now time.Time, limit int) ([]*domain.Job, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE jobs j
+		SET status = 'running', attempts = j.attempts + 1,
+		    locked_until = $3 + make_interval(secs => j.timeout_seconds), updated_at = $3
+		WHERE j.id IN (
+			SELECT due.id
+			FROM jobs due
+			WHERE due.queue = $1 AND due.type = ANY($2)
+			  AND ((due.status = 'queued' AND due.run_at <= $3)
+			    OR (due.status = 'running' AND due.locked_until <= $3 AND due.attempts < due.max_attempts))
+			ORDER BY due.run_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns+`
+	`, queue, types, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+func (r *jobRepository) Complete(ctx context.Context, job *domain.Job) error {
+	// The attempts check fences off a run whose job was claimed again after its timeout
+	_, err := r.pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = 'succeeded', locked_until = NULL, last_error = NULL, finished_at = now(), updated_at = now()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, job.ID, job.Attempts)
+	return err
+}
+
+func (r *jobRepository) Fail(ctx context.Context, job *domain.Job, message string, retryAt *time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = CASE WHEN $4::timestamptz IS NULL THEN 'failed' ELSE 'queued' END,
+		    run_at = COALESCE($4, run_at),
+		    finished_at = CASE WHEN $4::timestamptz IS NULL THEN now() END,
+		    locked_until = NULL, last_error = $3, updated_at = now()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, job.ID, job.Attempts, message, retryAt)
+	return err
+}
+
+func (r *jobRepository) FailExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = 'failed', locked_until = NULL, last_error = 'visibility timeout exceeded',
+		    finished_at = $1, updated_at = $1
+		WHERE status = 'running' AND locked_until <= $1 AND attempts >= max_attempts
+	`, now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *jobRepository) GetByID(ctx context.Context, id string) (*domain.Job, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id)
+	job, err := scanJob(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrJobNotFound
+	}
+	return job, err
+}
+
+func (r *jobRepository) List(ctx context.Context, filters *domain.JobFilters, limit, offset int) ([]*domain.Job, int, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	if filters != nil {
+		if filters.Queue != nil {
+			args = append(args, *filters.Queue)
+			conditions = append(conditions, fmt.Sprintf("queue = $%d", len(args)))
+		}
+		if filters.Type != nil {
+			args = append(args, *filters.Type)
+			conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+		}
+		if filters.Status != nil {
+			args = append(args, string(*filters.Status))
+			conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+		}
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	jobs, err := scanJobs(rows)
+	return jobs, total, err
+}
+
+func (r *jobRepository) Stats(ctx context.Context) ([]*domain.JobQueueStats, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT queue,
+		       COUNT(*) FILTER (WHERE status = 'queued'),
+		       COUNT(*) FILTER (WHERE status = 'running'),
+		       COUNT(*) FILTER (WHERE status = 'succeeded'),
+		       COUNT(*) FILTER (WHERE status = 'failed')
+		FROM jobs
+		GROUP BY queue
+		ORDER BY queue
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*domain.JobQueueStats
+	for rows.Next() {
+		var s domain.JobQueueStats
+		if err := rows.Scan(&s.Queue, &s.Queued, &s.Running, &s.Succeeded, &s.Failed); err != nil {
+			return nil, err
+		}
+		stats = append(stats, &s)
+	}
+	return stats, rows.Err()
+}
+
+func (r *jobRepository) Retry(ctx context.Context, id string) (*domain.Job, error) {
+	row := r.pool.QueryRow(ctx, `
+		UPDATE jobs
+		SET status = 'queued', attempts = 0, run_at = now(), finished_at = NULL, updated_at = now()
+		WHERE id = $1 AND status = 'failed'
+		RETURNING `+jobColumns, id)
+	job, err := scanJob(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, domain.ErrJobNotFailed
+	}
+	return job, err
+}
+
+func (r *jobRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM jobs WHERE status IN ('succeeded', 'failed') AND finished_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanJob(row pgx.Row) (*domain.Job, error) {
+	var job domain.Job
+	err := row.Scan(
+		&job.ID, &job.Queue, &job.Type, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts,
+		&job.TimeoutSeconds, &job.RunAt, &job.LockedUntil, &job.LastError, &job.UniqueKey,
+		&job.CreatedAt, &job.UpdatedAt, &job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func scanJobs(rows pgx.Rows) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type messageRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewMessageRepository creates a new message repository
+func NewMessageRepository(pool *pgxpool.Pool) domain.MessageRepository {
+	return &messageRepository{pool: pool}
+}
+
+// messageColumns selects message columns of m with attachments and read receipts
+const messageColumns = `
+	m.id, m.conversation_id, m.sender_id, m.content, m.created_at,
+	COALESCE((SELECT array_agg(mm.media_id::text ORDER BY mm.ord) FROM message_media mm WHERE mm.message_id = m.id), '{}') AS media_ids,
+	COALESCE((SELECT array_agg(rm.user_id::text) FROM conversation_members rm
+	          WHERE rm.conversation_id = m.conversation_id AND rm.user_id <> m.sender_id
+	            AND rm.last_read_at >= m.created_at), '{}') AS read_by`
+
+// conversationSelect selects conversations of member $1 with their unread count
+const conversationSelect = `
+	SELECT c.id, c.is_group, c.title, c.created_by, c.created_at, c.updated_at,
+	       (SELECT COUNT(*) FROM messages um
+	        WHERE um.conversation_id = c.id AND um.sender_id <> $1 AND um.created_at > me.last_read_at) AS unread_count
+	FROM conversations c
+	INNER JOIN conversation_members me ON me.conversation_id = c.id AND me.user_id = $1`
+
+func scanMessage(row pgx.Row) (*domain.Message, error) {
+	var message domain.Message
+	err := row.Scan(
+		&message.ID, &message.ConversationID, &message.SenderID, &message.Content, &message.CreatedAt,
+		&message.MediaIDs, &message.ReadBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func scanConversation(row pgx.Row) (*domain.Conversation, error) {
+	var conversation domain.Conversation
+	err := row.Scan(
+		&conversation.ID, &conversation.IsGroup, &conversation.Title, &conversation.CreatedBy,
+		&conversation.CreatedAt, &conversation.UpdatedAt, &conversation.UnreadCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// directKey identifies the one-to-one conversation of two users regardless of their order
+func directKey(memberIDs []string) string {
+	ids := append([]string(nil), memberIDs...)
+	sort.Strings(ids)
+	return strings.Join(ids, ":")
+}
+
+func (r *messageRepository) CreateConversation(ctx context.Context, conversation *domain.Conversation, memberIDs []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var key *string
+	if !conversation.IsGroup {
+		k := directKey(memberIDs)
+		key = &k
+	}
+
+	// A concurrent request may have created the same one-to-one conversation
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO conversations (id, is_group, title, created_by, direct_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (direct_key) DO NOTHING
+	`, conversation.ID, conversation.IsGroup, conversation.Title, conversation.CreatedBy, key, conversation.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return tx.QueryRow(ctx, `SELECT id FROM conversations WHERE direct_key = $1`, key).Scan(&conversation.ID)
+	}
+
+	for _, memberID := range memberIDs {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO conversation_members (conversation_id, user_id, joined_at, last_read_at)
+			VALUES ($1, $2, $3, $3)
+		`, conversation.ID, memberID, conversation.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *messageRepository) FindDirect(ctx context.Context, userID, otherUserID string) (*domain.Conversation, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id FROM conversations WHERE direct_key = $1
+	`, directKey([]string{userID, otherUserID})).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetConversation(ctx, id, userID)
+}
+
+func (r *messageRepository) GetConversation(ctx context.Context, id, userID string) (*domain.Conversation, error) {
+	conversation, err := scanConversation(r.pool.QueryRow(ctx, conversationSelect+`
+		WHERE c.id = $2
+	`, userID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("conversation not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.fillConversations(ctx, []*domain.Conversation{conversation}); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *messageRepository) GetConversations(ctx context.Context, userID string, limit, offset int) ([]*domain.Conversation, int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM conversation_members WHERE user_id = $1
+	`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, conversationSelect+`
+		ORDER BY c.updated_at DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var conversations []*domain.Conversation
+	for rows.Next() {
+		conversation, err := scanConversation(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		conversations = append(conversations, conversation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.fillConversations(ctx, conversations); err != nil {
+		return nil, 0, err
+	}
+	return conversations, total, nil
+}
+
+// fillConversations loads members and the last message of conversations in two batch queries
+func (r *messageRepository) fillConversations(ctx context.Context, conversations []*domain.Conversation) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Conversation, len(conversations))
+	ids := make([]string, 0, len(conversations))
+	for _, conversation := range conversations {
+		conversation.Members = []*domain.User{}
+		byID[conversation.ID] = conversation
+		ids = append(ids, conversation.ID)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT cm.conversation_id,
+		       `+publicUserColumns+`
+		FROM conversation_members cm
+		INNER JOIN users u ON u.id = cm.user_id
+		WHERE cm.conversation_id = ANY($1)
+		ORDER BY cm.joined_at, u.username
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var conversationID string
+		var user domain.User
+		if err := rows.Scan(
+			&conversationID,
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+		); err != nil {
+			return err
+		}
+		byID[conversationID].Members = append(byID[conversationID].Members, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	messageRows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (m.conversation_id) `+messageColumns+`
+		FROM messages m
+		WHERE m.conversation_id = ANY($1)
+		ORDER BY m.conversation_id, m.created_at DESC
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer messageRows.Close()
+
+	for messageRows.Next() {
+		message, err := scanMessage(messageRows)
+		if err != nil {
+			return err
+		}
+		byID[message.ConversationID].LastMessage = message
+	}
+
+	return messageRows.Err()
+}
+
+func (r *messageRepository) IsMember(ctx context.Context, conversationID, userID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM conversation_members WHERE conversation_id = $1 AND user_id = $2)
+	`, conversationID, userID).Scan(&exists)
+	return exists, err
+}
+
+func (r *messageRepository) RemoveMember(ctx context.Context, conversationID, userID string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM conversation_members WHERE conversation_id = $1 AND user_id = $2
+	`, conversationID, userID)
+	return err
+}
+
+func (r *messageRepository) CreateMessage(ctx context.Context, message *domain.Message) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO messages (id, conversation_id, sender_id, content, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, message.ID, message.ConversationID, message.SenderID, message.Content, message.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	for i, mediaID := range message.MediaIDs {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO message_media (message_id, media_id, ord)
+			VALUES ($1, $2, $3)
+		`, message.ID, mediaID, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE conversations SET updated_at = $2 WHERE id = $1
+	`, message.ConversationID, message.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE conversation_members SET last_read_at = GREATEST(last_read_at, $3)
+		WHERE conversation_id = $1 AND user_id = $2
+	`, message.ConversationID, message.SenderID, message.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *messageRepository) GetMessages(ctx context.Context, conversationID string, limit, offset int) ([]*domain.Message, int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM messages WHERE conversation_id = $1
+	`, conversationID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+messageColumns+`
+		FROM messages m
+		WHERE m.conversation_id = $1
+		ORDER BY m.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, conversationID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, total, rows.Err()
+}
+
+func (r *messageRepository) MarkRead(ctx context.Context, conversationID, userID string, readAt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE conversation_members SET last_read_at = GREATEST(last_read_at, $3)
+		WHERE conversation_id = $1 AND user_id = $2
+	`, conversationID, userID, readAt)
+	return err
+}
+
+func (r *messageRepository) GetSettings(ctx context.Context, userID string) (*domain.MessageSettings, error) {
+	settings := &domain.MessageSettings{UserID: userID}
+	err := r.pool.QueryRow(ctx, `
+		SELECT from_following_only FROM message_settings WHERE user_id = $1
+	`, userID).Scan(&settings.FromFollowingOnly)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *messageRepository) UpdateSettings(ctx context.Context, settings *domain.MessageSettings) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO message_settings (user_id, from_following_only, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (user_id) DO UPDATE
+		SET from_following_only = EXCLUDED.from_following_only, updated_at = now()
+	`, settings.UserID, settings.FromFollowingOnly)
+	return err
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sense-backend/internal/domain"
 )
@@ -36,8 +39,30 @@ func (r *notificationRepository) Create(ctx context.Context, notification *domai
 	return err
 }
 
+// scanNotification scans notification columns; data is decoded from JSONB by pgx
+func scanNotification(row pgx.Row) (*domain.Notification, error) {
+	var notif domain.Notification
+	err := row.Scan(
+		&notif.ID, &notif.UserID, &notif.Type, &notif.Title,
+		&notif.Message, &notif.Data, &notif.IsRead, &notif.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &notif, nil
+}
+
+// visibleNotificationsCondition selects notifications of the user $1; those caused by a single
+// actor who is muted or blocked are hidden. Aggregated notifications are kept: the producer never
+// adds muted or blocked actors to them, and one actor must not hide the others
+func visibleNotificationsCondition() string {
+	actor := "(data->>'actor_id')::uuid"
+	return "user_id = $1 AND (COALESCE((data->>'actors_count')::int, 1) > 1 OR (" +
+		notBlockedCondition(actor, "$1") + " AND " + notMutedCondition(actor, "$1") + "))"
+}
+
 func (r *notificationRepository) GetByUser(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]*domain.Notification, int, error) {
-	where := "user_id = $1"
+	where := visibleNotificationsCondition()
 	args := []interface{}{userID}
 	argIndex := 2
 
@@ -71,37 +96,181 @@ func (r *notificationRepository) GetByUser(ctx context.Context, userID string, u
 
 	var notifications []*domain.Notification
 	for rows.Next() {
-		var notif domain.Notification
-		var dataJSON interface{}
-		err := rows.Scan(
-			&notif.ID, &notif.UserID, &notif.Type, &notif.Title,
-			&notif.Message, &dataJSON, &notif.IsRead, &notif.CreatedAt,
-		)
+		notif, err := scanNotification(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-		if dataJSON != nil {
-			// Data will be parsed as map[string]interface{} by pgx
-			notif.Data = make(map[string]interface{})
-			// Full JSON parsing would require proper unmarshaling
-		}
-		notifications = append(notifications, &notif)
+		notifications = append(notifications, notif)
 	}
 
 	return notifications, total, rows.Err()
 }
 
-func (r *notificationRepository) MarkAsRead(ctx context.Context, notificationID string) error {
-	_, err := r.pool.Exec(ctx, `
-		UPDATE notifications SET is_read = true WHERE id = $1
-	`, notificationID)
-	return err
+func (r *notificationRepository) MarkAsRead(ctx context.Context, userID, notificationID string) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE notifications SET is_read = true WHERE id = $1 AND user_id = $2
+	`, notificationID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
 }
 
 func (r *notificationRepository) MarkAllAsRead(ctx context.Context, userID string) error {
 	_, err := r.pool.Exec(ctx, `
-		UPDATE notifications SET is_read = true WHERE user_id = $1
+		UPDATE notifications SET is_read = true WHERE user_id = $1 AND is_read = false
 	`, userID)
 	return err
 }
 
+func (r *notificationRepository) Delete(ctx context.Context, userID, notificationID string) error {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM notifications WHERE id = $1 AND user_id = $2
+	`, notificationID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	// Served by the partial index on unread notifications
+	var count int
+	err := r.pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM notifications WHERE "+visibleNotificationsCondition()+" AND is_read = false",
+		userID,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *notificationRepository) Aggregate(ctx context.Context, notification *domain.Notification, window time.Duration, render func(*domain.Notification)) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Serialize aggregation per group so that concurrent actions do not create two aggregates
+	_, err = tx.Exec(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2 || ':' || $3))
+	`, notification.UserID, string(notification.Type), notification.TargetID())
+	if err != nil {
+		return err
+	}
+
+	existing, err := scanNotification(tx.QueryRow(ctx, `
+		SELECT id, user_id, type, title, message, data, is_read, created_at
+		FROM notifications
+		WHERE user_id = $1 AND type = $2 AND is_read = false
+		  AND data->>'target_id' = $3 AND created_at >= $4
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, notification.UserID, notification.Type, notification.TargetID(), notification.CreatedAt.Add(-window)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		render(notification)
+		_, err = tx.Exec(ctx, `
+			INSERT INTO notifications (id, user_id, type, title, message, data, is_read, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, notification.ID, notification.UserID, notification.Type, notification.Title,
+			notification.Message, notification.Data, notification.IsRead, notification.CreatedAt)
+		if err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The latest action wins for keys such as comment_id and moves the aggregate to the top
+	for key, value := range notification.Data {
+		if key != "actor_id" && key != "actor_ids" && key != "actors" && key != "actors_count" {
+			existing.Data[key] = value
+		}
+	}
+	actors := notification.Actors()
+	for i := len(actors) - 1; i >= 0; i-- {
+		existing.AddActor(actors[i])
+	}
+	existing.CreatedAt = notification.CreatedAt
+	render(existing)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE notifications SET title = $2, message = $3, data = $4, created_at = $5
+		WHERE id = $1
+	`, existing.ID, existing.Title, existing.Message, existing.Data, existing.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	*notification = *existing
+	return tx.Commit(ctx)
+}
+
+func (r *notificationRepository) RemoveActor(ctx context.Context, userID string, notificationType domain.NotificationType, targetID, actorID string, render func(*domain.Notification)) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2 || ':' || $3))
+	`, userID, string(notificationType), targetID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, user_id, type, title, message, data, is_read, created_at
+		FROM notifications
+		WHERE user_id = $1 AND type = $2 AND is_read = false
+		  AND data->>'target_id' = $3
+		  AND (data->'actor_ids' ? $4 OR data->>'actor_id' = $4)
+		FOR UPDATE
+	`, userID, notificationType, targetID, actorID)
+	if err != nil {
+		return err
+	}
+	var notifications []*domain.Notification
+	for rows.Next() {
+		notif, err := scanNotification(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		notifications = append(notifications, notif)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, notif := range notifications {
+		notif.RemoveActor(actorID)
+		if notif.ActorsCount() == 0 {
+			_, err = tx.Exec(ctx, `DELETE FROM notifications WHERE id = $1`, notif.ID)
+		} else {
+			render(notif)
+			_, err = tx.Exec(ctx, `
+				UPDATE notifications SET title = $2, message = $3, data = $4 WHERE id = $1
+			`, notif.ID, notif.Title, notif.Message, notif.Data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
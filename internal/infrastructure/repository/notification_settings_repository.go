@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type notificationSettingsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewNotificationSettingsRepository creates a new notification settings repository
+func NewNotificationSettingsRepository(pool *pgxpool.Pool) domain.NotificationSettingsRepository {
+	return &notificationSettingsRepository{pool: pool}
+}
+
+func (r *notificationSettingsRepository) Get(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	settings := domain.DefaultNotificationSettings(userID)
+
+	var (
+		channels   []byte
+		quietStart *string
+		quietEnd   *string
+	)
+	err := r.pool.QueryRow(ctx, `
+		SELECT channels, time_zone,
+		       to_char(quiet_hours_start, 'HH24:MI'), to_char(quiet_hours_end, 'HH24:MI'),
+		       from_following_only, digest_frequency
+		FROM notification_settings
+		WHERE user_id = $1
+	`, userID).Scan(&channels, &settings.TimeZone, &quietStart, &quietEnd, &settings.FromFollowingOnly, &settings.Digest)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Saved toggles override the defaults; types added later keep theirs
+	var saved map[domain.NotificationType]domain.NotificationChannels
+	if err := json.Unmarshal(channels, &saved); err != nil {
+		return nil, err
+	}
+	for notificationType, toggles := range saved {
+		if domain.IsConfigurableNotificationType(notificationType) {
+			settings.Types[notificationType] = toggles
+		}
+	}
+
+	if quietStart != nil && quietEnd != nil {
+		settings.QuietHours = &domain.QuietHours{Start: *quietStart, End: *quietEnd}
+	}
+
+	return settings, nil
+}
+
+func (r *notificationSettingsRepository) Update(ctx context.Context, settings *domain.NotificationSettings) error {
+	channels, err := json.Marshal(settings.Types)
+	if err != nil {
+		return err
+	}
+
+	var quietStart, quietEnd *string
+	if settings.QuietHours != nil {
+		quietStart, quietEnd = &settings.QuietHours.Start, &settings.QuietHours.End
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO notification_settings
+			(user_id, channels, time_zone, quiet_hours_start, quiet_hours_end, from_following_only, digest_frequency, updated_at)
+		VALUES ($1, $2, $3, $4::time, $5::time, $6, $7, now())
+		ON CONFLICT (user_id) DO UPDATE
+		SET channels = EXCLUDED.channels,
+		    time_zone = EXCLUDED.time_zone,
+		    quiet_hours_start = EXCLUDED.quiet_hours_start,
+		    quiet_hours_end = EXCLUDED.quiet_hours_end,
+		    from_following_only = EXCLUDED.from_following_only,
+		    digest_frequency = EXCLUDED.digest_frequency,
+		    updated_at = now()
+	`, settings.UserID, channels, settings.TimeZone, quietStart, quietEnd, settings.FromFollowingOnly, settings.Digest)
+	return err
+}
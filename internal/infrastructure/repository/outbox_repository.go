@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxOutboxBackoff caps the delay between retries of a failing message
+const maxOutboxBackoff = time.Hour
+
+type outboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(pool *pgxpool.Pool) domain.OutboxRepository {
+	return &outboxRepository{pool: pool}
+}
+
+// writeOutbox records event in the transaction of the change it describes, so the event is
+// published if and only if the change is committed
+func writeOutbox(ctx context.Context, tx pgx.Tx, event *domain.Event) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO outbox (event_type, actor_id, target_id, occurred_at)
+		VALUES ($1, $2, $3, $4)
+	`, event.Type, event.ActorID, event.TargetID, event.OccurredAt)
+	return err
+}
+
+func (r *outboxRepository) Process(ctx context.Context, limit, maxAttempts int, handle func(ctx context.Context, message *domain.OutboxMessage) error) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// The row locks are held until commit, so a dispatcher that dies mid-batch leaves its
+	// messages to the others
+	rows, err := tx.Query(ctx, `
+		SELECT id, event_type, actor_id, target_id, occurred_at, attempts, last_error
+		FROM outbox
+		WHERE processed_at IS NULL AND failed_at IS NULL AND next_attempt_at <= now()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		message := &domain.OutboxMessage{Event: &domain.Event{}}
+		if err := rows.Scan(
+			&message.ID, &message.Event.Type, &message.Event.ActorID, &message.Event.TargetID,
+			&message.Event.OccurredAt, &message.Attempts, &message.LastError,
+		); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		message.Event.ID = strconv.FormatInt(message.ID, 10)
+		messages = append(messages, message)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		if handleErr := handle(ctx, message); handleErr != nil {
+			// Every handler runs again on retry; delivery is at least once. A message failing
+			// maxAttempts times is left failed for inspection rather than retried forever
+			_, err = tx.Exec(ctx, `
+				UPDATE outbox
+				SET attempts = attempts + 1, last_error = $2,
+				    next_attempt_at = clock_timestamp() + LEAST(make_interval(secs => power(2, attempts)), make_interval(secs => $3)),
+				    failed_at = CASE WHEN attempts + 1 >= $4 THEN clock_timestamp() END
+				WHERE id = $1
+			`, message.ID, handleErr.Error(), maxOutboxBackoff.Seconds(), maxAttempts)
+		} else {
+			_, err = tx.Exec(ctx, `UPDATE outbox SET processed_at = clock_timestamp() WHERE id = $1`, message.ID)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(messages), nil
+}
+
+func (r *outboxRepository) DeleteProcessed(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM outbox WHERE processed_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"sense-backend/internal/domain"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,6 +23,22 @@ func NewPublicationRepository(pool *pgxpool.Pool) domain.PublicationRepository {
 	return &publicationRepository{pool: pool}
 }
 
+// publicCondition restricts publications to those anyone may see
+const publicCondition = "(p.visibility = 'public' AND p.community_id IS NULL)"
+
+// visibleCondition restricts publications to those the viewer may see: their own, and
+//   - outside communities: public ones and community ones of authors the viewer follows (approved follows only);
+//   - inside a community: non-private ones, to community members only.
+//
+// viewer is a placeholder such as $1; a NULL viewer sees public publications outside communities only.
+func visibleCondition(viewer string) string {
+	return fmt.Sprintf(`(p.author_id = %[1]s
+		OR (p.community_id IS NULL AND (p.visibility = 'public' OR (p.visibility = 'community' AND EXISTS (
+			SELECT 1 FROM user_follows vf WHERE vf.follower_id = %[1]s AND vf.following_id = p.author_id))))
+		OR (p.community_id IS NOT NULL AND p.visibility <> 'private' AND EXISTS (
+			SELECT 1 FROM community_members vm WHERE vm.community_id = p.community_id AND vm.user_id = %[1]s)))`, viewer)
+}
+
 func (r *publicationRepository) Create(ctx context.Context, publication *domain.Publication, mediaIDs []string) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -31,12 +50,13 @@ func (r *publicationRepository) Create(ctx context.Context, publication *domain.
 
 	// Insert publication
 	query := `
-		INSERT INTO publications (id, author_id, type, title, content, source, publication_date, visibility)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO publications (id, author_id, type, title, content, source, publication_date, updated_at, visibility, community_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 	_, err = tx.Exec(ctx, query,
 		publication.ID, publication.AuthorID, publication.Type, publication.Title, publication.Content,
-		publication.Source, publication.PublicationDate, publication.Visibility,
+		publication.Source, publication.PublicationDate, publication.UpdatedAt, publication.Visibility,
+		publication.CommunityID,
 	)
 	if err != nil {
 		return err
@@ -53,12 +73,16 @@ func (r *publicationRepository) Create(ctx context.Context, publication *domain.
 		}
 	}
 
+	if err := writeOutbox(ctx, tx, domain.NewEvent(domain.EventPublicationCreated, publication.AuthorID, publication.ID)); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
 func (r *publicationRepository) GetByID(ctx context.Context, id string) (*domain.Publication, error) {
 	query := `
-		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
 		       COALESCE(likes.count, 0) as likes_count,
 		       COALESCE(comments.count, 0) as comments_count,
 		       COALESCE(saved.count, 0) as saved_count
@@ -75,7 +99,7 @@ func (r *publicationRepository) GetByID(ctx context.Context, id string) (*domain
 	var pub domain.Publication
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&pub.ID, &pub.AuthorID, &pub.Type, &pub.Title, &pub.Content, &pub.Source,
-		&pub.PublicationDate, &pub.Visibility, &pub.LikesCount,
+		&pub.PublicationDate, &pub.UpdatedAt, &pub.Visibility, &pub.CommunityID, &pub.LikesCount,
 		&pub.CommentsCount, &pub.SavedCount,
 	)
 	if err == sql.ErrNoRows {
@@ -96,6 +120,22 @@ func (r *publicationRepository) GetByIDWithLikeStatus(ctx context.Context, id st
 		IsSaved:     false,
 	}
 
+	// Publications outside the viewer's visibility or between users who blocked each other are hidden
+	var visible bool
+	err = r.pool.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM publications p
+			WHERE p.id = $1 AND `+visibleCondition("$2::uuid")+`
+			  AND `+notBlockedCondition("p.author_id", "$2::uuid")+`
+		)
+	`, id, viewerUserID).Scan(&visible)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, fmt.Errorf("publication not found")
+	}
+
 	if viewerUserID != nil {
 		isLiked, err := r.IsLiked(ctx, *viewerUserID, id)
 		if err == nil {
@@ -123,11 +163,12 @@ func (r *publicationRepository) Update(ctx context.Context, publication *domain.
 	// Update publication
 	query := `
 		UPDATE publications
-		SET title = $2, content = $3, source = $4, visibility = $5
+		SET title = $2, content = $3, source = $4, visibility = $5, updated_at = $6
 		WHERE id = $1
 	`
 	_, err = tx.Exec(ctx, query,
 		publication.ID, publication.Title, publication.Content, publication.Source, publication.Visibility,
+		publication.UpdatedAt,
 	)
 	if err != nil {
 		return err
@@ -150,12 +191,36 @@ func (r *publicationRepository) Update(ctx context.Context, publication *domain.
 		}
 	}
 
+	if err := writeOutbox(ctx, tx, domain.NewEvent(domain.EventPublicationUpdated, publication.AuthorID, publication.ID)); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
 func (r *publicationRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.pool.Exec(ctx, `DELETE FROM publications WHERE id = $1`, id)
-	return err
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var authorID string
+	err = tx.QueryRow(ctx, `DELETE FROM publications WHERE id = $1 RETURNING author_id`, id).Scan(&authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutbox(ctx, tx, domain.NewEvent(domain.EventPublicationDeleted, authorID, id)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *publicationRepository) GetFeed(ctx context.Context, userID *string, filters *domain.FeedFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
@@ -200,11 +265,14 @@ func (r *publicationRepository) GetFeed(ctx context.Context, userID *string, fil
 		}
 	}
 
-	// If userID provided, filter by visibility (public or community for logged in users)
+	// If userID provided, filter by visibility (community only for approved followers)
+	// and hide authors the user blocked, was blocked by or muted
 	if userID != nil {
-		where = append(where, fmt.Sprintf("(p.visibility = 'public' OR p.visibility = 'community' OR p.author_id = $%d)", userIDArgIndex))
+		viewer := fmt.Sprintf("$%d", userIDArgIndex)
+		where = append(where, visibleCondition(viewer))
+		where = append(where, notBlockedCondition("p.author_id", viewer), notMutedCondition("p.author_id", viewer))
 	} else {
-		where = append(where, "p.visibility = 'public'")
+		where = append(where, publicCondition)
 	}
 
 	whereClause := strings.Join(where, " AND ")
@@ -221,7 +289,7 @@ func (r *publicationRepository) GetFeed(ctx context.Context, userID *string, fil
 	var query string
 	if userID != nil {
 		query = fmt.Sprintf(`
-			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
+			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
 			       COALESCE(likes.count, 0) as likes_count,
 			       COALESCE(comments.count, 0) as comments_count,
 			       COALESCE(saved.count, 0) as saved_count,
@@ -242,7 +310,7 @@ func (r *publicationRepository) GetFeed(ctx context.Context, userID *string, fil
 		`, userIDArgIndex, userIDArgIndex, whereClause, argIndex, argIndex+1)
 	} else {
 		query = fmt.Sprintf(`
-			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
+			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
 			       COALESCE(likes.count, 0) as likes_count,
 			       COALESCE(comments.count, 0) as comments_count,
 			       COALESCE(saved.count, 0) as saved_count,
@@ -273,7 +341,7 @@ func (r *publicationRepository) GetFeed(ctx context.Context, userID *string, fil
 		var pub domain.PublicationWithLikeStatus
 		err := rows.Scan(
 			&pub.ID, &pub.AuthorID, &pub.Type, &pub.Title, &pub.Content, &pub.Source,
-			&pub.PublicationDate, &pub.Visibility, &pub.LikesCount,
+			&pub.PublicationDate, &pub.UpdatedAt, &pub.Visibility, &pub.CommunityID, &pub.LikesCount,
 			&pub.CommentsCount, &pub.SavedCount, &pub.IsLiked, &pub.IsSaved,
 		)
 		if err != nil {
@@ -301,6 +369,13 @@ func (r *publicationRepository) GetByAuthor(ctx context.Context, authorID string
 			countArgs = append(countArgs, *filters.Visibility)
 		}
 	}
+	if viewerUserID != nil {
+		countArgs = append(countArgs, *viewerUserID)
+		viewer := fmt.Sprintf("$%d", len(countArgs))
+		countWhere = append(countWhere, visibleCondition(viewer), notBlockedCondition("p.author_id", viewer))
+	} else {
+		countWhere = append(countWhere, publicCondition)
+	}
 	countWhereClause := strings.Join(countWhere, " AND ")
 
 	var total int
@@ -331,6 +406,12 @@ func (r *publicationRepository) GetByAuthor(ctx context.Context, authorID string
 			queryIdx++
 		}
 	}
+	if viewerUserID != nil {
+		viewer := fmt.Sprintf("$%d", viewerUserIDArgIndex)
+		queryWhere = append(queryWhere, visibleCondition(viewer), notBlockedCondition("p.author_id", viewer))
+	} else {
+		queryWhere = append(queryWhere, publicCondition)
+	}
 	queryWhereClause := strings.Join(queryWhere, " AND ")
 
 	limitPlaceholder := queryIdx
@@ -339,7 +420,7 @@ func (r *publicationRepository) GetByAuthor(ctx context.Context, authorID string
 	var query string
 	if viewerUserID != nil {
 		query = fmt.Sprintf(`
-			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
+			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
 			       COALESCE(likes.count, 0) as likes_count,
 			       COALESCE(comments.count, 0) as comments_count,
 			       COALESCE(saved.count, 0) as saved_count,
@@ -360,7 +441,7 @@ func (r *publicationRepository) GetByAuthor(ctx context.Context, authorID string
 		`, viewerUserIDArgIndex, viewerUserIDArgIndex, queryWhereClause, limitPlaceholder, offsetPlaceholder)
 	} else {
 		query = fmt.Sprintf(`
-			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
+			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
 			       COALESCE(likes.count, 0) as likes_count,
 			       COALESCE(comments.count, 0) as comments_count,
 			       COALESCE(saved.count, 0) as saved_count,
@@ -392,7 +473,7 @@ func (r *publicationRepository) GetByAuthor(ctx context.Context, authorID string
 		var pub domain.PublicationWithLikeStatus
 		if err := rows.Scan(
 			&pub.ID, &pub.AuthorID, &pub.Type, &pub.Title, &pub.Content, &pub.Source,
-			&pub.PublicationDate, &pub.Visibility, &pub.LikesCount,
+			&pub.PublicationDate, &pub.UpdatedAt, &pub.Visibility, &pub.CommunityID, &pub.LikesCount,
 			&pub.CommentsCount, &pub.SavedCount, &pub.IsLiked, &pub.IsSaved,
 		); err != nil {
 			return nil, 0, err
@@ -429,15 +510,30 @@ func (r *publicationRepository) Like(ctx context.Context, userID, publicationID
 		if err != nil {
 			return false, err
 		}
+		if err := writeOutbox(ctx, tx, domain.NewEvent(domain.EventPublicationUnliked, userID, publicationID)); err != nil {
+			return false, err
+		}
 		return false, tx.Commit(ctx)
 	} else {
-		// Like
+		// Like, unless the author and the user blocked each other
+		var authorID string
+		err = tx.QueryRow(ctx, `SELECT author_id FROM publications WHERE id = $1`, publicationID).Scan(&authorID)
+		if err != nil {
+			return false, err
+		}
+		if err := ensureNotBlocked(ctx, tx, userID, authorID); err != nil {
+			return false, err
+		}
+
 		_, err = tx.Exec(ctx, `
 			INSERT INTO publication_likes (user_id, publication_id) VALUES ($1, $2)
 		`, userID, publicationID)
 		if err != nil {
 			return false, err
 		}
+		if err := writeOutbox(ctx, tx, domain.NewEvent(domain.EventPublicationLiked, userID, publicationID)); err != nil {
+			return false, err
+		}
 		return true, tx.Commit(ctx)
 	}
 }
@@ -470,7 +566,7 @@ func (r *publicationRepository) GetLikedUsers(ctx context.Context, publicationID
 
 	// Get users
 	rows, err := r.pool.Query(ctx, `
-		SELECT u.id, u.username, u.email, u.phone, u.icon_url, u.description, u.role, u.registered_at
+		SELECT `+publicUserColumns+`
 		FROM users u
 		INNER JOIN publication_likes pl ON u.id = pl.user_id
 		WHERE pl.publication_id = $1
@@ -486,8 +582,8 @@ func (r *publicationRepository) GetLikedUsers(ctx context.Context, publicationID
 	for rows.Next() {
 		var user domain.User
 		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.Phone, &user.IconURL,
-			&user.Description, &user.Role, &user.RegisteredAt,
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -524,7 +620,7 @@ func (r *publicationRepository) IsSaved(ctx context.Context, userID, publication
 }
 
 func (r *publicationRepository) GetSaved(ctx context.Context, userID string, filters *domain.PublicationFilters, limit, offset int) ([]*domain.SavedPublicationWithLikeStatus, int, error) {
-	where := []string{"si.user_id = $1"}
+	where := []string{"si.user_id = $1", visibleCondition("$1"), notBlockedCondition("p.author_id", "$1")}
 	args := []interface{}{userID}
 	argIndex := 2
 
@@ -557,7 +653,7 @@ func (r *publicationRepository) GetSaved(ctx context.Context, userID string, fil
 
 	// Get saved publications with like status (userID is the viewer)
 	query := fmt.Sprintf(`
-		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
 		       COALESCE(likes.count, 0) as likes_count,
 		       COALESCE(comments.count, 0) as comments_count,
 		       COALESCE(saved.count, 0) as saved_count,
@@ -590,7 +686,7 @@ func (r *publicationRepository) GetSaved(ctx context.Context, userID string, fil
 		var sp domain.SavedPublicationWithLikeStatus
 		err := rows.Scan(
 			&sp.ID, &sp.AuthorID, &sp.Type, &sp.Title, &sp.Content, &sp.Source,
-			&sp.PublicationDate, &sp.Visibility, &sp.LikesCount,
+			&sp.PublicationDate, &sp.UpdatedAt, &sp.Visibility, &sp.CommunityID, &sp.LikesCount,
 			&sp.CommentsCount, &sp.SavedCount, &sp.SavedNote, &sp.SavedAt, &sp.IsLiked, &sp.IsSaved,
 		)
 		if err != nil {
@@ -602,125 +698,276 @@ func (r *publicationRepository) GetSaved(ctx context.Context, userID string, fil
 	return saved, total, rows.Err()
 }
 
-func (r *publicationRepository) Search(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
-	searchQuery := `%` + query + `%`
-	where := []string{"(p.content ILIKE $1 OR p.title ILIKE $1)"}
-	filterValues := []interface{}{}
-
-	// viewerUserID placeholder (used only in JOIN, not in WHERE)
-	viewerUserIDArgIndex := 0
+// searchConditions returns the visibility and filter conditions of a publication search, appending
+// their arguments to args, and the viewer placeholder, empty for anonymous viewers
+func searchConditions(viewerUserID *string, filters *domain.SearchFilters, args []interface{}) ([]string, []interface{}, string) {
+	var where []string
 
-	// Filters start after search query and optional viewer placeholder
-	filterStartIndex := 2
+	viewer := ""
 	if viewerUserID != nil {
-		viewerUserIDArgIndex = filterStartIndex
-		filterStartIndex++
+		args = append(args, *viewerUserID)
+		viewer = fmt.Sprintf("$%d", len(args))
+		where = append(where, visibleCondition(viewer), notBlockedCondition("p.author_id", viewer))
+	} else {
+		where = append(where, publicCondition)
 	}
 
-	if filters != nil {
-		if filters.Type != nil {
-			where = append(where, fmt.Sprintf("p.type = $%d", filterStartIndex))
-			filterValues = append(filterValues, *filters.Type)
-			filterStartIndex++
+	if filters == nil {
+		return where, args, viewer
+	}
+	if filters.Type != nil {
+		args = append(args, *filters.Type)
+		where = append(where, fmt.Sprintf("p.type = $%d", len(args)))
+	}
+	if filters.Visibility != nil {
+		args = append(args, *filters.Visibility)
+		where = append(where, fmt.Sprintf("p.visibility = $%d", len(args)))
+	}
+	if filters.AuthorID != nil {
+		args = append(args, *filters.AuthorID)
+		where = append(where, fmt.Sprintf("p.author_id = $%d", len(args)))
+	}
+	if len(filters.Tags) > 0 {
+		// No wanted tag is missing from the publication
+		args = append(args, filters.Tags)
+		where = append(where, fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM unnest($%d::text[]) AS wanted(name)
+			WHERE NOT EXISTS (
+				SELECT 1 FROM publication_tags pt
+				INNER JOIN tags t ON t.id = pt.tag_id
+				WHERE pt.publication_id = p.id AND t.name = wanted.name
+			)
+		)`, len(args)))
+	}
+	if filters.DateFrom != nil {
+		args = append(args, *filters.DateFrom)
+		where = append(where, fmt.Sprintf("p.publication_date >= $%d", len(args)))
+	}
+	if filters.DateTo != nil {
+		args = append(args, *filters.DateTo)
+		where = append(where, fmt.Sprintf("p.publication_date < $%d", len(args)))
+	}
+	return where, args, viewer
+}
+
+func (r *publicationRepository) SearchFacets(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, buckets []domain.DateBucket, limit int) (*domain.SearchFacets, error) {
+	conditions, args, _ := searchConditions(viewerUserID, filters, []interface{}{query})
+	where := append([]string{searchMatch}, conditions...)
+
+	names := make([]string, len(buckets))
+	froms := make([]*time.Time, len(buckets))
+	tos := make([]*time.Time, len(buckets))
+	for i, bucket := range buckets {
+		names[i], froms[i], tos[i] = bucket.Name, bucket.DateFrom, bucket.DateTo
+	}
+	args = append(args, names, froms, tos, limit)
+
+	// Every facet is counted over the same matched publications in one round trip
+	queryStr := fmt.Sprintf(`
+		WITH q AS (%[1]s),
+		matched AS (
+			SELECT p.id, p.type, p.author_id, p.publication_date
+			FROM publications p, q
+			WHERE %[2]s
+		)
+		(SELECT 'type' AS facet, m.type::text AS value, NULL::text AS label, COUNT(*) AS count
+		 FROM matched m
+		 GROUP BY m.type
+		 ORDER BY count DESC)
+		UNION ALL
+		(SELECT 'tag', t.name, NULL, COUNT(*)
+		 FROM matched m
+		 INNER JOIN publication_tags pt ON pt.publication_id = m.id
+		 INNER JOIN tags t ON t.id = pt.tag_id
+		 GROUP BY t.name
+		 ORDER BY COUNT(*) DESC, t.name
+		 LIMIT $%[6]d)
+		UNION ALL
+		(SELECT 'author', m.author_id::text, u.username, COUNT(*)
+		 FROM matched m
+		 INNER JOIN users u ON u.id = m.author_id
+		 GROUP BY m.author_id, u.username
+		 ORDER BY COUNT(*) DESC, u.username
+		 LIMIT $%[6]d)
+		UNION ALL
+		(SELECT 'date', b.name, NULL, COUNT(m.id)
+		 FROM unnest($%[3]d::text[], $%[4]d::timestamptz[], $%[5]d::timestamptz[]) AS b(name, date_from, date_to)
+		 LEFT JOIN matched m ON (b.date_from IS NULL OR m.publication_date >= b.date_from)
+		                    AND (b.date_to IS NULL OR m.publication_date < b.date_to)
+		 GROUP BY b.name)
+	`, searchQueries, strings.Join(where, " AND "), len(args)-3, len(args)-2, len(args)-1, len(args))
+
+	rows, err := r.pool.Query(ctx, queryStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facets := &domain.SearchFacets{
+		Types:   []*domain.FacetCount{},
+		Tags:    []*domain.FacetCount{},
+		Authors: []*domain.FacetCount{},
+	}
+	dates := make(map[string]int, len(buckets))
+	for rows.Next() {
+		var (
+			facet string
+			count domain.FacetCount
+		)
+		if err := rows.Scan(&facet, &count.Value, &count.Label, &count.Count); err != nil {
+			return nil, err
 		}
-		if filters.Visibility != nil {
-			where = append(where, fmt.Sprintf("p.visibility = $%d", filterStartIndex))
-			filterValues = append(filterValues, *filters.Visibility)
-			filterStartIndex++
+		switch facet {
+		case "type":
+			facets.Types = append(facets.Types, &count)
+		case "tag":
+			facets.Tags = append(facets.Tags, &count)
+		case "author":
+			facets.Authors = append(facets.Authors, &count)
+		case "date":
+			dates[count.Value] = count.Count
 		}
-		if filters.AuthorID != nil {
-			where = append(where, fmt.Sprintf("p.author_id = $%d", filterStartIndex))
-			filterValues = append(filterValues, *filters.AuthorID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Date buckets keep the order they were asked in
+	facets.Dates = make([]*domain.FacetCount, len(buckets))
+	for i, bucket := range buckets {
+		facets.Dates[i] = &domain.FacetCount{
+			Value:    bucket.Name,
+			DateFrom: bucket.DateFrom,
+			DateTo:   bucket.DateTo,
+			Count:    dates[bucket.Name],
 		}
 	}
 
-	whereClause := strings.Join(where, " AND ")
+	return facets, nil
+}
 
-	// Count query args (exclude viewerUserID to keep placeholders dense)
-	countArgs := []interface{}{searchQuery}
-	countArgs = append(countArgs, filterValues...)
-	var total int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM publications p WHERE %s", whereClause)
-	if err := r.pool.QueryRow(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+func (r *publicationRepository) SearchSources(ctx context.Context, query string, viewerUserID *string, limit int) ([]*domain.SourceHit, int, error) {
+	conditions, args, _ := searchConditions(viewerUserID, nil, []interface{}{escapeLike(strings.TrimSpace(query))})
+	args = append(args, limit)
+
+	// The window count is taken over all groups before LIMIT
+	queryStr := fmt.Sprintf(`
+		SELECT p.source, COUNT(*), COUNT(*) OVER ()
+		FROM publications p
+		WHERE p.source ILIKE '%%' || $1::text || '%%' AND %s
+		GROUP BY p.source
+		ORDER BY COUNT(*) DESC, p.source
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.pool.Query(ctx, queryStr, args...)
+	if err != nil {
 		return nil, 0, err
 	}
+	defer rows.Close()
 
-	// Main query args: search query, optional viewer, filters
-	args := []interface{}{searchQuery}
-	if viewerUserID != nil {
-		viewerUserIDArgIndex = len(args) + 1 // 2
-		args = append(args, *viewerUserID)
+	var (
+		sources []*domain.SourceHit
+		total   int
+	)
+	for rows.Next() {
+		var source domain.SourceHit
+		if err := rows.Scan(&source.Source, &source.PublicationsCount, &total); err != nil {
+			return nil, 0, err
+		}
+		sources = append(sources, &source)
 	}
-	args = append(args, filterValues...)
 
-	limitPlaceholder := len(args) + 1
-	offsetPlaceholder := len(args) + 2
+	return sources, total, rows.Err()
+}
 
-	var queryStr string
-	if viewerUserID != nil {
-		queryStr = fmt.Sprintf(`
-			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
-			       COALESCE(likes.count, 0) as likes_count,
-			       COALESCE(comments.count, 0) as comments_count,
-			       COALESCE(saved.count, 0) as saved_count,
-			       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
-			       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved
-			FROM publications p
-			LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $%d
-			LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $%d
-			LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
-			  ON p.id = likes.publication_id
-			LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
-			  ON p.id = comments.publication_id
-			LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
-			  ON p.id = saved.publication_id
-			WHERE %s
-			ORDER BY p.publication_date DESC
-			LIMIT $%d OFFSET $%d
-		`, viewerUserIDArgIndex, viewerUserIDArgIndex, whereClause, limitPlaceholder, offsetPlaceholder)
-	} else {
-		queryStr = fmt.Sprintf(`
-			SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.visibility,
-			       COALESCE(likes.count, 0) as likes_count,
-			       COALESCE(comments.count, 0) as comments_count,
-			       COALESCE(saved.count, 0) as saved_count,
-			       false as is_liked,
-			       false as is_saved
-			FROM publications p
-			LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
-			  ON p.id = likes.publication_id
-			LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
-			  ON p.id = comments.publication_id
-			LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
-			  ON p.id = saved.publication_id
-			WHERE %s
-			ORDER BY p.publication_date DESC
-			LIMIT $%d OFFSET $%d
-		`, whereClause, limitPlaceholder, offsetPlaceholder)
+func reindexConditions(filters *domain.SearchFilters, args []interface{}) ([]string, []interface{}) {
+	where := []string{"TRUE"}
+	if filters == nil {
+		return where, args
+	}
+	if filters.Type != nil {
+		args = append(args, *filters.Type)
+		where = append(where, fmt.Sprintf("p.type = $%d", len(args)))
+	}
+	if filters.Visibility != nil {
+		args = append(args, *filters.Visibility)
+		where = append(where, fmt.Sprintf("p.visibility = $%d", len(args)))
 	}
+	if filters.AuthorID != nil {
+		args = append(args, *filters.AuthorID)
+		where = append(where, fmt.Sprintf("p.author_id = $%d", len(args)))
+	}
+	return where, args
+}
 
-	args = append(args, limit, offset)
+func (r *publicationRepository) CountForReindex(ctx context.Context, filters *domain.SearchFilters) (int, error) {
+	where, args := reindexConditions(filters, nil)
 
-	rows, err := r.pool.Query(ctx, queryStr, args...)
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM publications p WHERE `+strings.Join(where, " AND "), args...).Scan(&count)
+	return count, err
+}
+
+// searchDocumentColumns are the columns scanned by scanSearchDocuments
+const searchDocumentColumns = `p.id, p.author_id, p.type, p.visibility, p.community_id, p.title, p.content, p.source,
+	p.publication_date, ARRAY(
+		SELECT t.name FROM publication_tags pt INNER JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.publication_id = p.id ORDER BY t.name
+	)`
+
+func (r *publicationRepository) GetSearchDocuments(ctx context.Context, ids []string) ([]*domain.SearchDocument, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+searchDocumentColumns+`
+		FROM publications p
+		WHERE p.id = ANY($1::uuid[])
+	`, ids)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
-	var publications []*domain.PublicationWithLikeStatus
+	return scanSearchDocuments(rows)
+}
+
+func (r *publicationRepository) ListSearchDocuments(ctx context.Context, filters *domain.SearchFilters, afterID string, limit int) ([]*domain.SearchDocument, error) {
+	if afterID == "" {
+		afterID = "00000000-0000-0000-0000-000000000000"
+	}
+	where, args := reindexConditions(filters, []interface{}{afterID, limit})
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT `+searchDocumentColumns+`
+		FROM publications p
+		WHERE p.id > $1::uuid AND %s
+		ORDER BY p.id
+		LIMIT $2
+	`, strings.Join(where, " AND ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSearchDocuments(rows)
+}
+
+func scanSearchDocuments(rows pgx.Rows) ([]*domain.SearchDocument, error) {
+	var docs []*domain.SearchDocument
 	for rows.Next() {
-		var pub domain.PublicationWithLikeStatus
+		var doc domain.SearchDocument
 		if err := rows.Scan(
-			&pub.ID, &pub.AuthorID, &pub.Type, &pub.Title, &pub.Content, &pub.Source,
-			&pub.PublicationDate, &pub.Visibility, &pub.LikesCount,
-			&pub.CommentsCount, &pub.SavedCount, &pub.IsLiked, &pub.IsSaved,
+			&doc.ID, &doc.AuthorID, &doc.Type, &doc.Visibility, &doc.CommunityID, &doc.Title, &doc.Content,
+			&doc.Source, &doc.PublicationDate, &doc.Tags,
 		); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
-		publications = append(publications, &pub)
+		docs = append(docs, &doc)
 	}
-
-	return publications, total, rows.Err()
+	return docs, rows.Err()
 }
 
 func (r *publicationRepository) GetMediaIDs(ctx context.Context, publicationID string) ([]string, error) {
@@ -745,3 +992,256 @@ func (r *publicationRepository) GetMediaIDs(ctx context.Context, publicationID s
 
 	return mediaIDs, rows.Err()
 }
+
+func (r *publicationRepository) GetByIDs(ctx context.Context, ids []string, viewerUserID *string) ([]*domain.PublicationWithLikeStatus, error) {
+	return r.getByIDs(ctx, ids, viewerUserID, "")
+}
+
+func (r *publicationRepository) GetFeedByIDs(ctx context.Context, userID string, ids []string) ([]*domain.PublicationWithLikeStatus, error) {
+	return r.getByIDs(ctx, ids, &userID, " AND "+notMutedCondition("p.author_id", "$1"))
+}
+
+// getByIDs retrieves visible publications by IDs matching the extra condition, if any
+func (r *publicationRepository) getByIDs(ctx context.Context, ids []string, viewerUserID *string, extra string) ([]*domain.PublicationWithLikeStatus, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// A NULL viewer matches no like/save rows and sees public publications only
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
+		       COALESCE(likes.count, 0) as likes_count,
+		       COALESCE(comments.count, 0) as comments_count,
+		       COALESCE(saved.count, 0) as saved_count,
+		       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+		       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved
+		FROM publications p
+		LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $1
+		LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $1
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
+		  ON p.id = likes.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
+		  ON p.id = comments.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
+		  ON p.id = saved.publication_id
+		WHERE p.id = ANY($2::uuid[])
+		  AND `+visibleCondition("$1::uuid")+`
+		  AND `+notBlockedCondition("p.author_id", "$1")+extra+`
+	`, viewerUserID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPublicationsWithLikeStatus(rows)
+}
+
+func (r *publicationRepository) GetByTag(ctx context.Context, tagID string, viewerUserID *string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	// A NULL viewer sees public publications only
+	visibility := visibleCondition("$1::uuid") +
+		" AND " + notBlockedCondition("p.author_id", "$1")
+
+	var total int
+	err := r.pool.QueryRow(ctx, fmt.Sprintf(`
+		SELECT COUNT(*) FROM publications p
+		INNER JOIN publication_tags pt ON pt.publication_id = p.id AND pt.tag_id = $2
+		WHERE %s
+	`, visibility), viewerUserID, tagID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
+		       COALESCE(likes.count, 0) as likes_count,
+		       COALESCE(comments.count, 0) as comments_count,
+		       COALESCE(saved.count, 0) as saved_count,
+		       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+		       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved
+		FROM publications p
+		INNER JOIN publication_tags pt ON pt.publication_id = p.id AND pt.tag_id = $2
+		LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $1
+		LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $1
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
+		  ON p.id = likes.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
+		  ON p.id = comments.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
+		  ON p.id = saved.publication_id
+		WHERE %s
+		ORDER BY p.publication_date DESC
+		LIMIT $3 OFFSET $4
+	`, visibility), viewerUserID, tagID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	publications, err := scanPublicationsWithLikeStatus(rows)
+	return publications, total, err
+}
+
+func (r *publicationRepository) GetByCommunity(ctx context.Context, communityID string, viewerUserID *string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	// A NULL viewer is a member of no community and sees nothing
+	visibility := visibleCondition("$1::uuid") +
+		" AND " + notBlockedCondition("p.author_id", "$1")
+
+	var total int
+	err := r.pool.QueryRow(ctx, fmt.Sprintf(`
+		SELECT COUNT(*) FROM publications p
+		WHERE p.community_id = $2 AND %s
+	`, visibility), viewerUserID, communityID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
+		       COALESCE(likes.count, 0) as likes_count,
+		       COALESCE(comments.count, 0) as comments_count,
+		       COALESCE(saved.count, 0) as saved_count,
+		       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+		       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved
+		FROM publications p
+		LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $1
+		LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $1
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
+		  ON p.id = likes.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
+		  ON p.id = comments.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
+		  ON p.id = saved.publication_id
+		WHERE p.community_id = $2 AND %s
+		ORDER BY p.publication_date DESC
+		LIMIT $3 OFFSET $4
+	`, visibility), viewerUserID, communityID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	publications, err := scanPublicationsWithLikeStatus(rows)
+	return publications, total, err
+}
+
+func (r *publicationRepository) GetByFollowing(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.PublicationWithLikeStatus, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
+		       COALESCE(likes.count, 0) as likes_count,
+		       COALESCE(comments.count, 0) as comments_count,
+		       COALESCE(saved.count, 0) as saved_count,
+		       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+		       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved
+		FROM publications p
+		INNER JOIN user_follows uf ON uf.following_id = p.author_id AND uf.follower_id = $1
+		LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $1
+		LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $1
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
+		  ON p.id = likes.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
+		  ON p.id = comments.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
+		  ON p.id = saved.publication_id
+		WHERE `+visibleCondition("$1")+` AND p.publication_date >= $2
+		  AND `+notBlockedCondition("p.author_id", "$1")+`
+		  AND `+notMutedCondition("p.author_id", "$1")+`
+		ORDER BY p.publication_date DESC
+		LIMIT $3
+	`, userID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPublicationsWithLikeStatus(rows)
+}
+
+func (r *publicationRepository) GetPopularInUserTags(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.PublicationWithLikeStatus, error) {
+	// Tags the user engaged with: tags of publications they liked, saved or commented on
+	rows, err := r.pool.Query(ctx, `
+		WITH user_tags AS (
+			SELECT DISTINCT pt.tag_id
+			FROM publication_tags pt
+			WHERE pt.publication_id IN (
+				SELECT publication_id FROM publication_likes WHERE user_id = $1
+				UNION
+				SELECT publication_id FROM saved_items WHERE user_id = $1
+				UNION
+				SELECT publication_id FROM comments WHERE author_id = $1
+			)
+		)
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
+		       COALESCE(likes.count, 0) as likes_count,
+		       COALESCE(comments.count, 0) as comments_count,
+		       COALESCE(saved.count, 0) as saved_count,
+		       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+		       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved
+		FROM publications p
+		LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $1
+		LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $1
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes 
+		  ON p.id = likes.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments 
+		  ON p.id = comments.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved 
+		  ON p.id = saved.publication_id
+		WHERE p.id IN (SELECT publication_id FROM publication_tags WHERE tag_id IN (SELECT tag_id FROM user_tags))
+		  AND p.author_id <> $1
+		  AND `+visibleCondition("$1")+`
+		  AND p.publication_date >= $2
+		  AND `+notBlockedCondition("p.author_id", "$1")+`
+		  AND `+notMutedCondition("p.author_id", "$1")+`
+		ORDER BY COALESCE(likes.count, 0) + COALESCE(saved.count, 0) DESC, p.publication_date DESC
+		LIMIT $3
+	`, userID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPublicationsWithLikeStatus(rows)
+}
+
+func (r *publicationRepository) GetAuthorAffinity(ctx context.Context, userID string) (map[string]int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.author_id, COUNT(*)
+		FROM publication_likes pl
+		INNER JOIN publications p ON p.id = pl.publication_id
+		WHERE pl.user_id = $1
+		GROUP BY p.author_id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	affinity := make(map[string]int)
+	for rows.Next() {
+		var authorID string
+		var count int
+		if err := rows.Scan(&authorID, &count); err != nil {
+			return nil, err
+		}
+		affinity[authorID] = count
+	}
+
+	return affinity, rows.Err()
+}
+
+// scanPublicationsWithLikeStatus scans rows selected with the common publication column list
+func scanPublicationsWithLikeStatus(rows pgx.Rows) ([]*domain.PublicationWithLikeStatus, error) {
+	var publications []*domain.PublicationWithLikeStatus
+	for rows.Next() {
+		var pub domain.PublicationWithLikeStatus
+		if err := rows.Scan(
+			&pub.ID, &pub.AuthorID, &pub.Type, &pub.Title, &pub.Content, &pub.Source,
+			&pub.PublicationDate, &pub.UpdatedAt, &pub.Visibility, &pub.CommunityID, &pub.LikesCount,
+			&pub.CommentsCount, &pub.SavedCount, &pub.IsLiked, &pub.IsSaved,
+		); err != nil {
+			return nil, err
+		}
+		publications = append(publications, &pub)
+	}
+
+	return publications, rows.Err()
+}
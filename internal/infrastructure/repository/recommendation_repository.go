@@ -77,10 +77,13 @@ func (r *recommendationRepository) Hide(ctx context.Context, recommendationID st
 
 func (r *recommendationRepository) GetPublicationIDs(ctx context.Context, userID string, limit int) ([]string, error) {
 	rows, err := r.pool.Query(ctx, `
-		SELECT publication_id
-		FROM recommendations
-		WHERE user_id = $1 AND hidden = false
-		ORDER BY rank ASC, created_at DESC
+		SELECT r.publication_id
+		FROM recommendations r
+		INNER JOIN publications p ON p.id = r.publication_id
+		WHERE r.user_id = $1 AND r.hidden = false
+		  AND `+notBlockedCondition("p.author_id", "$1")+`
+		  AND `+notMutedCondition("p.author_id", "$1")+`
+		ORDER BY r.rank ASC, r.created_at DESC
 		LIMIT $2
 	`, userID, limit)
 	if err != nil {
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type reindexTaskRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReindexTaskRepository creates a new reindex task repository
+func NewReindexTaskRepository(pool *pgxpool.Pool) domain.ReindexTaskRepository {
+	return &reindexTaskRepository{pool: pool}
+}
+
+func (r *reindexTaskRepository) Create(ctx context.Context, task *domain.ReindexTask) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO search_reindex_tasks (id, requested_by, filters, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, task.ID, task.RequestedBy, task.Filters, task.Status, task.CreatedAt)
+	return err
+}
+
+func (r *reindexTaskRepository) GetByID(ctx context.Context, id string) (*domain.ReindexTask, error) {
+	var task domain.ReindexTask
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, requested_by, filters, status, total, processed, cursor, errors, created_at, started_at, finished_at
+		FROM search_reindex_tasks
+		WHERE id = $1
+	`, id).Scan(
+		&task.ID, &task.RequestedBy, &task.Filters, &task.Status, &task.Total, &task.Processed,
+		&task.Cursor, &task.Errors, &task.CreatedAt, &task.StartedAt, &task.FinishedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrReindexTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *reindexTaskRepository) Update(ctx context.Context, task *domain.ReindexTask) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE search_reindex_tasks
+		SET status = $2, total = $3, processed = $4, cursor = $5, errors = COALESCE($6, '{}'), started_at = $7, finished_at = $8
+		WHERE id = $1
+	`, task.ID, task.Status, task.Total, task.Processed, task.Cursor, task.Errors, task.StartedAt, task.FinishedAt)
+	return err
+}
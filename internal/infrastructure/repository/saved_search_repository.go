@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type savedSearchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSavedSearchRepository creates a new saved search repository
+func NewSavedSearchRepository(pool *pgxpool.Pool) domain.SavedSearchRepository {
+	return &savedSearchRepository{pool: pool}
+}
+
+// savedSearchColumns selects saved searches s with the number of their unseen matches
+const savedSearchColumns = `s.id, s.user_id, s.name, s.query, s.filters, s.notify,
+	(SELECT COUNT(*) FROM saved_search_matches m WHERE m.saved_search_id = s.id AND m.found_at > s.seen_at),
+	s.last_run_at, s.seen_at, s.created_at, s.updated_at`
+
+func (r *savedSearchRepository) Create(ctx context.Context, search *domain.SavedSearch) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO saved_searches (id, user_id, name, query, filters, notify, seen_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, search.ID, search.UserID, search.Name, search.Query, search.Filters, search.Notify,
+		search.SeenAt, search.CreatedAt, search.UpdatedAt)
+	return err
+}
+
+func (r *savedSearchRepository) GetByID(ctx context.Context, userID, searchID string) (*domain.SavedSearch, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+savedSearchColumns+` FROM saved_searches s WHERE s.id = $1 AND s.user_id = $2
+	`, searchID, userID)
+	return scanSavedSearch(row)
+}
+
+func (r *savedSearchRepository) GetByUser(ctx context.Context, userID string) ([]*domain.SavedSearch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+savedSearchColumns+` FROM saved_searches s WHERE s.user_id = $1 ORDER BY s.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSavedSearches(rows)
+}
+
+func (r *savedSearchRepository) CountByUser(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM saved_searches WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *savedSearchRepository) Update(ctx context.Context, search *domain.SavedSearch) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE saved_searches
+		SET name = $3, query = $4, filters = $5, notify = $6, updated_at = $7
+		WHERE id = $1 AND user_id = $2
+	`, search.ID, search.UserID, search.Name, search.Query, search.Filters, search.Notify, search.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) Delete(ctx context.Context, userID, searchID string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, searchID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) List(ctx context.Context, afterID string, limit int) ([]*domain.SavedSearch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+savedSearchColumns+`
+		FROM saved_searches s
+		WHERE $1 = '' OR s.id > $1::uuid
+		ORDER BY s.id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSavedSearches(rows)
+}
+
+func (r *savedSearchRepository) AddMatches(ctx context.Context, searchID string, publicationIDs []string, runAt time.Time) (int, int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO saved_search_matches (saved_search_id, publication_id, found_at)
+		SELECT $1, id, $3 FROM unnest($2::uuid[]) AS id
+		ON CONFLICT DO NOTHING
+	`, searchID, publicationIDs, runAt)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var unseen int
+	err = tx.QueryRow(ctx, `
+		UPDATE saved_searches s SET last_run_at = $2
+		WHERE s.id = $1
+		RETURNING (SELECT COUNT(*) FROM saved_search_matches m WHERE m.saved_search_id = s.id AND m.found_at > s.seen_at)
+	`, searchID, runAt).Scan(&unseen)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, domain.ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+	return int(tag.RowsAffected()), unseen, nil
+}
+
+func (r *savedSearchRepository) GetUnseen(ctx context.Context, searchID string, limit, offset int) ([]string, int, error) {
+	const unseen = `
+		FROM saved_search_matches m
+		INNER JOIN saved_searches s ON s.id = m.saved_search_id
+		WHERE m.saved_search_id = $1 AND m.found_at > s.seen_at
+	`
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) `+unseen, searchID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT m.publication_id `+unseen+`
+		ORDER BY m.found_at DESC, m.publication_id
+		LIMIT $2 OFFSET $3
+	`, searchID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, total, rows.Err()
+}
+
+func (r *savedSearchRepository) MarkSeen(ctx context.Context, userID, searchID string, at time.Time) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE saved_searches SET seen_at = GREATEST(seen_at, $3)
+		WHERE id = $1 AND user_id = $2
+	`, searchID, userID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func scanSavedSearch(row pgx.Row) (*domain.SavedSearch, error) {
+	var search domain.SavedSearch
+	err := row.Scan(
+		&search.ID, &search.UserID, &search.Name, &search.Query, &search.Filters, &search.Notify,
+		&search.NewCount, &search.LastRunAt, &search.SeenAt, &search.CreatedAt, &search.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func scanSavedSearches(rows pgx.Rows) ([]*domain.SavedSearch, error) {
+	searches := []*domain.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, search)
+	}
+	return searches, rows.Err()
+}
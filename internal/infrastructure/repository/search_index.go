@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresSearchIndex struct {
+	pool *pgxpool.Pool
+}
+
+// NewSearchIndex creates a search index over the stored tsvector columns of publications
+func NewSearchIndex(pool *pgxpool.Pool) domain.SearchIndex {
+	return &postgresSearchIndex{pool: pool}
+}
+
+// searchQueries parses the query placeholder in both search configurations; websearch_to_tsquery
+// accepts "quoted phrases", OR and -excluded words and never fails on user input
+const searchQueries = `SELECT websearch_to_tsquery('russian', $1) AS ru, websearch_to_tsquery('english', $1) AS en`
+
+// searchMatch matches publications against the parsed queries q using the GIN indexes
+const searchMatch = "(p.search_ru @@ q.ru OR p.search_en @@ q.en)"
+
+// searchRank ranks by cover density, taking the better of the two configurations
+const searchRank = "GREATEST(ts_rank_cd(p.search_ru, q.ru), ts_rank_cd(p.search_en, q.en))"
+
+// Highlight options; whole titles are returned, content is cut to the best fragments
+const (
+	titleHeadlineOptions   = `HighlightAll=true, StartSel=<mark>, StopSel=</mark>`
+	contentHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+)
+
+// escapeHTML returns SQL escaping column for HTML, so that only the highlight markup is markup
+func escapeHTML(column string) string {
+	return fmt.Sprintf(`replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;')`, column)
+}
+
+// Index does nothing: Postgres computes the stored vectors on every write of a publication, and
+// a change of their definition is a migration that recomputes them
+func (i *postgresSearchIndex) Index(ctx context.Context, docs ...*domain.SearchDocument) error {
+	return nil
+}
+
+// Delete does nothing: the vectors are deleted with their publications
+func (i *postgresSearchIndex) Delete(ctx context.Context, ids ...string) error {
+	return nil
+}
+
+func (i *postgresSearchIndex) Query(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.SearchHit, int, error) {
+	conditions, args, _ := searchConditions(viewerUserID, filters, []interface{}{query})
+	whereClause := strings.Join(append([]string{searchMatch}, conditions...), " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf(`
+		WITH q AS (%s)
+		SELECT COUNT(*) FROM publications p, q WHERE %s
+	`, searchQueries, whereClause)
+	if err := i.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Highlights are built for the requested page only, as ts_headline reparses the text
+	args = append(args, limit, offset)
+	queryStr := fmt.Sprintf(`
+		WITH q AS (%[1]s),
+		hits AS (
+			SELECT p.id, %[2]s AS rank
+			FROM publications p, q
+			WHERE %[3]s
+			ORDER BY rank DESC, p.publication_date DESC
+			LIMIT $%[4]d OFFSET $%[5]d
+		)
+		SELECT p.id, hits.rank,
+		       ts_headline('russian', %[6]s, q.ru, '%[7]s'),
+		       CASE WHEN p.content IS NOT NULL THEN ts_headline('russian', %[8]s, q.ru, '%[9]s') END
+		FROM hits
+		INNER JOIN publications p ON p.id = hits.id
+		CROSS JOIN q
+		ORDER BY hits.rank DESC, p.publication_date DESC
+	`, searchQueries, searchRank, whereClause, len(args)-1, len(args),
+		escapeHTML("p.title"), titleHeadlineOptions, escapeHTML("p.content"), contentHeadlineOptions)
+
+	rows, err := i.pool.Query(ctx, queryStr, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var hits []*domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		if err := rows.Scan(&hit.ID, &hit.Rank, &hit.Highlights.Title, &hit.Highlights.Content); err != nil {
+			return nil, 0, err
+		}
+		hits = append(hits, &hit)
+	}
+
+	return hits, total, rows.Err()
+}
+
+// Suggest completes prefix with words of public titles; the trigram index narrows the titles
+// before they are split into words
+func (i *postgresSearchIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = escapeLike(strings.ToLower(strings.TrimSpace(prefix)))
+	if prefix == "" {
+		return []string{}, nil
+	}
+
+	rows, err := i.pool.Query(ctx, `
+		SELECT word
+		FROM publications p, regexp_split_to_table(lower(p.title), '[^[:alnum:]]+') AS word
+		WHERE p.title ILIKE '%' || $1::text || '%' AND `+publicCondition+` AND word LIKE $1::text || '%'
+		GROUP BY word
+		ORDER BY COUNT(DISTINCT p.id) DESC, word
+		LIMIT $2
+	`, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	words := []string{}
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+	}
+	return words, rows.Err()
+}
+
+// Correct looks up each word unknown to the stored vectors among the words of public titles
+// with the trigram index; stop words count as known
+func (i *postgresSearchIndex) Correct(ctx context.Context, words []string) (map[string]string, error) {
+	corrections := make(map[string]string)
+	for _, word := range words {
+		normalized := strings.ToLower(word)
+		if utf8.RuneCountInString(normalized) < domain.MinCorrectedWordLength {
+			continue
+		}
+
+		var known bool
+		err := i.pool.QueryRow(ctx, `
+			WITH q AS (SELECT plainto_tsquery('russian', $1) AS ru, plainto_tsquery('english', $1) AS en)
+			SELECT numnode(q.ru) = 0 OR numnode(q.en) = 0 OR EXISTS (
+				SELECT 1 FROM publications p WHERE `+searchMatch+` AND `+publicCondition+`
+			)
+			FROM q
+		`, normalized).Scan(&known)
+		if err != nil {
+			return nil, err
+		}
+		if known {
+			continue
+		}
+
+		var correction string
+		err = i.pool.QueryRow(ctx, `
+			SELECT word
+			FROM (
+				SELECT DISTINCT word
+				FROM publications p, regexp_split_to_table(lower(p.title), '[^[:alnum:]]+') AS word
+				WHERE $1 <% p.title AND `+publicCondition+`
+			) words
+			WHERE word % $1 AND word <> $1
+			ORDER BY similarity(word, $1) DESC, word
+			LIMIT 1
+		`, normalized).Scan(&correction)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		corrections[word] = correction
+	}
+	return corrections, nil
+}
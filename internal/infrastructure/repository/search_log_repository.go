@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type searchLogRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSearchLogRepository creates a new search log repository
+func NewSearchLogRepository(pool *pgxpool.Pool) domain.SearchLogRepository {
+	return &searchLogRepository{pool: pool}
+}
+
+func (r *searchLogRepository) LogQuery(ctx context.Context, entry *domain.SearchQueryLog) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO search_queries (id, user_id, query, normalized, results_count, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, entry.ID, entry.UserID, entry.Query, entry.Normalized, entry.ResultsCount, entry.CreatedAt)
+	return err
+}
+
+func (r *searchLogRepository) LogClick(ctx context.Context, click *domain.SearchClick) error {
+	// Selecting both rows turns unknown IDs into no insert rather than a foreign key error; only
+	// the user who searched, or anyone for anonymous searches, clicks its results, once per result
+	var found bool
+	err := r.pool.QueryRow(ctx, `
+		WITH target AS (
+			SELECT q.id AS query_id, p.id AS publication_id
+			FROM search_queries q, publications p
+			WHERE q.id = $1 AND p.id = $2 AND q.user_id IS NOT DISTINCT FROM $5::uuid
+		), inserted AS (
+			INSERT INTO search_clicks (query_id, publication_id, position, created_at)
+			SELECT query_id, publication_id, $3, $4 FROM target
+			ON CONFLICT (query_id, publication_id) DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM target)
+	`, click.QueryID, click.PublicationID, click.Position, click.CreatedAt, click.UserID).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return domain.ErrSearchQueryNotFound
+	}
+	return nil
+}
+
+// clickWeight is how many searches a search with a clicked result counts as; further clicks
+// on its results add nothing
+const clickWeight = 2
+
+func (r *searchLogRepository) PopularQueries(ctx context.Context, prefix string, since time.Time, minSearchers, limit int) ([]string, error) {
+	// Anonymous searches count as distinct searchers
+	rows, err := r.pool.Query(ctx, `
+		SELECT q.normalized
+		FROM search_queries q
+		LEFT JOIN search_clicks c ON c.query_id = q.id
+		WHERE q.normalized LIKE $1 || '%' AND q.created_at >= $2 AND q.results_count > 0
+		GROUP BY q.normalized
+		HAVING COUNT(DISTINCT COALESCE(q.user_id, q.id)) >= $3
+		ORDER BY COUNT(DISTINCT q.id) + $4 * COUNT(DISTINCT c.query_id) DESC, q.normalized
+		LIMIT $5
+	`, escapeLike(prefix), since, minSearchers, clickWeight, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	queries := []string{}
+	for rows.Next() {
+		var query string
+		if err := rows.Scan(&query); err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	return queries, rows.Err()
+}
+
+func (r *searchLogRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM search_queries WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
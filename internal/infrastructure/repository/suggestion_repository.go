@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type suggestionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSuggestionRepository creates a new follow suggestion repository
+func NewSuggestionRepository(pool *pgxpool.Pool) domain.SuggestionRepository {
+	return &suggestionRepository{pool: pool}
+}
+
+func (r *suggestionRepository) GetCandidates(ctx context.Context, userID string, limit int) ([]*domain.SuggestionCandidate, error) {
+	// Candidates come from three signals: friends of friends, users liking the same
+	// publications and authors publishing in the tags the user engaged with.
+	// The preselection order is a plain sum; the use case applies the real weights.
+	rows, err := r.pool.Query(ctx, `
+		WITH friends_of_friends AS (
+			SELECT f2.following_id AS candidate_id,
+			       COUNT(*) AS mutual_follows,
+			       (array_agg(mu.username ORDER BY mu.username))[1:2] AS mutual_names
+			FROM user_follows f1
+			INNER JOIN user_follows f2 ON f2.follower_id = f1.following_id
+			INNER JOIN users mu ON mu.id = f1.following_id
+			WHERE f1.follower_id = $1
+			GROUP BY f2.following_id
+		),
+		co_likes AS (
+			SELECT theirs.user_id AS candidate_id, COUNT(*) AS co_likes
+			FROM publication_likes mine
+			INNER JOIN publication_likes theirs
+			  ON theirs.publication_id = mine.publication_id AND theirs.user_id <> mine.user_id
+			WHERE mine.user_id = $1
+			GROUP BY theirs.user_id
+		),
+		user_tags AS (
+			SELECT pt.tag_id, COUNT(*) AS weight
+			FROM publication_tags pt
+			WHERE pt.publication_id IN (
+				SELECT publication_id FROM publication_likes WHERE user_id = $1
+				UNION ALL
+				SELECT publication_id FROM saved_items WHERE user_id = $1
+				UNION ALL
+				SELECT id FROM publications WHERE author_id = $1
+			)
+			GROUP BY pt.tag_id
+		),
+		tag_authors AS (
+			SELECT p.author_id AS candidate_id,
+			       COUNT(DISTINCT pt.tag_id) AS shared_tags,
+			       (array_agg(t.name ORDER BY ut.weight DESC, t.name))[1] AS top_tag
+			FROM publications p
+			INNER JOIN publication_tags pt ON pt.publication_id = p.id
+			INNER JOIN user_tags ut ON ut.tag_id = pt.tag_id
+			INNER JOIN tags t ON t.id = pt.tag_id
+			WHERE `+publicCondition+`
+			GROUP BY p.author_id
+		),
+		candidates AS (
+			SELECT candidate_id FROM friends_of_friends
+			UNION
+			SELECT candidate_id FROM co_likes
+			UNION
+			SELECT candidate_id FROM tag_authors
+		)
+		SELECT `+publicUserColumns+`,
+		       COALESCE(fof.mutual_follows, 0), COALESCE(fof.mutual_names, '{}'),
+		       COALESCE(cl.co_likes, 0), COALESCE(ta.shared_tags, 0), ta.top_tag
+		FROM candidates c
+		INNER JOIN users u ON u.id = c.candidate_id
+		LEFT JOIN friends_of_friends fof ON fof.candidate_id = c.candidate_id
+		LEFT JOIN co_likes cl ON cl.candidate_id = c.candidate_id
+		LEFT JOIN tag_authors ta ON ta.candidate_id = c.candidate_id
+		WHERE c.candidate_id <> $1
+		  AND NOT EXISTS (SELECT 1 FROM user_follows uf WHERE uf.follower_id = $1 AND uf.following_id = c.candidate_id)
+		  AND NOT EXISTS (SELECT 1 FROM follow_requests fr WHERE fr.requester_id = $1 AND fr.target_id = c.candidate_id)
+		  AND NOT EXISTS (SELECT 1 FROM dismissed_suggestions ds WHERE ds.user_id = $1 AND ds.dismissed_id = c.candidate_id)
+		  AND `+notBlockedCondition("c.candidate_id", "$1")+`
+		  AND `+notMutedCondition("c.candidate_id", "$1")+`
+		ORDER BY COALESCE(fof.mutual_follows, 0) + COALESCE(cl.co_likes, 0) + COALESCE(ta.shared_tags, 0) DESC, u.username
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*domain.SuggestionCandidate
+	for rows.Next() {
+		var user domain.User
+		candidate := domain.SuggestionCandidate{User: &user}
+		if err := rows.Scan(
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+			&candidate.MutualFollows, &candidate.MutualNames,
+			&candidate.CoLikes, &candidate.SharedTags, &candidate.TopTag,
+		); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &candidate)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (r *suggestionRepository) Dismiss(ctx context.Context, userID, dismissedID string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO dismissed_suggestions (user_id, dismissed_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, dismissed_id) DO NOTHING
+	`, userID, dismissedID)
+	return err
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type trendingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTrendingRepository creates a new trending repository
+func NewTrendingRepository(pool *pgxpool.Pool) domain.TrendingRepository {
+	return &trendingRepository{pool: pool}
+}
+
+// publicationLanguageExpr guesses publication language: Cyrillic text is Russian, anything else English
+const publicationLanguageExpr = `CASE WHEN (p.title || ' ' || COALESCE(p.content, '')) ~ '[А-Яа-яЁё]' THEN 'ru' ELSE 'en' END`
+
+func (r *trendingRepository) Refresh(ctx context.Context, params *domain.TrendingParams) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	since := time.Now().Add(-params.Window)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM trending_tags`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM trending_publications`); err != nil {
+		return err
+	}
+
+	// Each event contributes weight * 2^(-age / half_life)
+	query := fmt.Sprintf(`
+		WITH events AS (
+			SELECT publication_id, created_at AS happened_at, 'like' AS kind, $2::float8 AS weight
+			FROM publication_likes WHERE created_at >= $1
+			UNION ALL
+			SELECT publication_id, added_at, 'save', $3::float8
+			FROM saved_items WHERE added_at >= $1
+			UNION ALL
+			SELECT publication_id, created_at, 'comment', $4::float8
+			FROM comments WHERE created_at >= $1
+			UNION ALL
+			SELECT publication_id, viewed_at, 'view', $5::float8
+			FROM publications_views WHERE viewed_at >= $1
+		), scored AS (
+			SELECT publication_id,
+			       SUM(weight * power(0.5, EXTRACT(EPOCH FROM (now() - happened_at)) / $6::float8)) AS score,
+			       COUNT(*) FILTER (WHERE kind = 'like') AS likes_count,
+			       COUNT(*) FILTER (WHERE kind = 'save') AS saves_count,
+			       COUNT(*) FILTER (WHERE kind = 'comment') AS comments_count,
+			       COUNT(*) FILTER (WHERE kind = 'view') AS views_count
+			FROM events
+			GROUP BY publication_id
+		)
+		INSERT INTO trending_publications
+			(publication_id, type, language, score, likes_count, saves_count, comments_count, views_count, computed_at)
+		SELECT s.publication_id, p.type, %s, s.score,
+		       s.likes_count, s.saves_count, s.comments_count, s.views_count, now()
+		FROM scored s
+		INNER JOIN publications p ON p.id = s.publication_id
+		WHERE `+publicCondition+` AND s.score > 0
+	`, publicationLanguageExpr)
+	_, err = tx.Exec(ctx, query,
+		since, params.LikeWeight, params.SaveWeight, params.CommentWeight, params.ViewWeight,
+		params.HalfLife.Seconds(),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO trending_tags (tag_id, type, language, score, publications_count, computed_at)
+		SELECT pt.tag_id, tp.type, tp.language, SUM(tp.score), COUNT(*), now()
+		FROM trending_publications tp
+		INNER JOIN publication_tags pt ON pt.publication_id = tp.publication_id
+		GROUP BY pt.tag_id, tp.type, tp.language
+	`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *trendingRepository) GetPublications(ctx context.Context, viewerUserID *string, filters *domain.TrendingFilters, limit, offset int) ([]*domain.TrendingPublication, int, error) {
+	countWhere, countArgs := trendingWhere("tp", filters, 1)
+	countArgs = append(countArgs, viewerUserID)
+	countWhere = append(countWhere, notBlockedCondition("p.author_id", fmt.Sprintf("$%d::uuid", len(countArgs))))
+
+	var total int
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM trending_publications tp
+		INNER JOIN publications p ON p.id = tp.publication_id
+		WHERE %s AND `+publicCondition+`
+	`, strings.Join(countWhere, " AND "))
+	if err := r.pool.QueryRow(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// $1 is the viewer (NULL for anonymous readers), filters follow
+	where, args := trendingWhere("tp", filters, 2)
+	queryArgs := append([]interface{}{viewerUserID}, args...)
+	query := fmt.Sprintf(`
+		SELECT p.id, p.author_id, p.type, p.title, p.content, p.source, p.publication_date, p.updated_at, p.visibility, p.community_id,
+		       COALESCE(likes.count, 0) as likes_count,
+		       COALESCE(comments.count, 0) as comments_count,
+		       COALESCE(saved.count, 0) as saved_count,
+		       CASE WHEN pl.user_id IS NOT NULL THEN true ELSE false END as is_liked,
+		       CASE WHEN si.user_id IS NOT NULL THEN true ELSE false END as is_saved,
+		       tp.score
+		FROM trending_publications tp
+		INNER JOIN publications p ON p.id = tp.publication_id
+		LEFT JOIN publication_likes pl ON p.id = pl.publication_id AND pl.user_id = $1
+		LEFT JOIN saved_items si ON p.id = si.publication_id AND si.user_id = $1
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM publication_likes GROUP BY publication_id) likes
+		  ON p.id = likes.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM comments GROUP BY publication_id) comments
+		  ON p.id = comments.publication_id
+		LEFT JOIN (SELECT publication_id, COUNT(*) as count FROM saved_items GROUP BY publication_id) saved
+		  ON p.id = saved.publication_id
+		WHERE %s AND `+publicCondition+` AND %s
+		ORDER BY tp.score DESC, p.publication_date DESC
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(where, " AND "), notBlockedCondition("p.author_id", "$1"), len(queryArgs)+1, len(queryArgs)+2)
+	queryArgs = append(queryArgs, limit, offset)
+
+	rows, err := r.pool.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var publications []*domain.TrendingPublication
+	for rows.Next() {
+		var pub domain.TrendingPublication
+		if err := rows.Scan(
+			&pub.ID, &pub.AuthorID, &pub.Type, &pub.Title, &pub.Content, &pub.Source,
+			&pub.PublicationDate, &pub.UpdatedAt, &pub.Visibility, &pub.CommunityID, &pub.LikesCount,
+			&pub.CommentsCount, &pub.SavedCount, &pub.IsLiked, &pub.IsSaved,
+			&pub.TrendingScore,
+		); err != nil {
+			return nil, 0, err
+		}
+		publications = append(publications, &pub)
+	}
+
+	return publications, total, rows.Err()
+}
+
+func (r *trendingRepository) GetTags(ctx context.Context, filters *domain.TrendingFilters, limit int) ([]*domain.TrendingTag, error) {
+	where, args := trendingWhere("tt", filters, 1)
+
+	query := fmt.Sprintf(`
+		SELECT t.id, t.name, t.description, t.usage_count, t.created_at,
+		       SUM(tt.score) AS score, SUM(tt.publications_count) AS publications_count
+		FROM trending_tags tt
+		INNER JOIN tags t ON t.id = tt.tag_id
+		WHERE %s
+		GROUP BY t.id
+		ORDER BY score DESC, t.name ASC
+		LIMIT $%d
+	`, strings.Join(where, " AND "), len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*domain.TrendingTag
+	for rows.Next() {
+		var tag domain.TrendingTag
+		if err := rows.Scan(
+			&tag.ID, &tag.Name, &tag.Description, &tag.UsageCount, &tag.CreatedAt,
+			&tag.TrendingScore, &tag.TrendingPublicationsCount,
+		); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// trendingWhere builds filter conditions for a trending table alias, numbering placeholders from startIndex
+func trendingWhere(alias string, filters *domain.TrendingFilters, startIndex int) ([]string, []interface{}) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	argIndex := startIndex
+
+	if filters != nil {
+		if filters.Type != nil {
+			where = append(where, fmt.Sprintf("%s.type = $%d", alias, argIndex))
+			args = append(args, *filters.Type)
+			argIndex++
+		}
+		if filters.Language != nil {
+			where = append(where, fmt.Sprintf("%s.language = $%d", alias, argIndex))
+			args = append(args, *filters.Language)
+		}
+	}
+
+	return where, args
+}
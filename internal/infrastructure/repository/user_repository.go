@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"sense-backend/internal/domain"
 
@@ -19,6 +20,9 @@ func NewUserRepository(pool *pgxpool.Pool) domain.UserRepository {
 	return &userRepository{pool: pool}
 }
 
+// publicUserColumns are the profile fields of users u shown to other users; contacts stay private
+const publicUserColumns = `u.id, u.username, u.icon_url, u.description, u.role, u.registered_at, u.is_private`
+
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
 		INSERT INTO users (id, username, email, phone, icon_url, description, role, password_hash, registered_at)
@@ -34,7 +38,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, phone, icon_url, description, role, registered_at, password_hash
+		SELECT id, username, email, phone, icon_url, description, role, registered_at, is_private, password_hash
 		FROM users
 		WHERE id = $1
 	`
@@ -42,7 +46,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&user.ID, &user.Username, &user.Email, &user.Phone, &user.IconURL,
-		&user.Description, &user.Role, &user.RegisteredAt, &user.PasswordHash,
+		&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate, &user.PasswordHash,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -52,7 +56,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, phone, icon_url, description, role, registered_at, password_hash
+		SELECT id, username, email, phone, icon_url, description, role, registered_at, is_private, password_hash
 		FROM users
 		WHERE username = $1
 	`
@@ -60,7 +64,7 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*d
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, username).Scan(
 		&user.ID, &user.Username, &user.Email, &user.Phone, &user.IconURL,
-		&user.Description, &user.Role, &user.RegisteredAt, &user.PasswordHash,
+		&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate, &user.PasswordHash,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -70,7 +74,7 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*d
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, phone, icon_url, description, role, registered_at, password_hash
+		SELECT id, username, email, phone, icon_url, description, role, registered_at, is_private, password_hash
 		FROM users
 		WHERE email = $1
 	`
@@ -78,7 +82,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, email).Scan(
 		&user.ID, &user.Username, &user.Email, &user.Phone, &user.IconURL,
-		&user.Description, &user.Role, &user.RegisteredAt, &user.PasswordHash,
+		&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate, &user.PasswordHash,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -88,7 +92,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 
 func (r *userRepository) GetByLogin(ctx context.Context, login string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, phone, icon_url, description, role, registered_at, password_hash
+		SELECT id, username, email, phone, icon_url, description, role, registered_at, is_private, password_hash
 		FROM users
 		WHERE username = $1 OR email = $1
 	`
@@ -96,7 +100,7 @@ func (r *userRepository) GetByLogin(ctx context.Context, login string) (*domain.
 	var user domain.User
 	err := r.pool.QueryRow(ctx, query, login).Scan(
 		&user.ID, &user.Username, &user.Email, &user.Phone, &user.IconURL,
-		&user.Description, &user.Role, &user.RegisteredAt, &user.PasswordHash,
+		&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate, &user.PasswordHash,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -107,13 +111,13 @@ func (r *userRepository) GetByLogin(ctx context.Context, login string) (*domain.
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET username = $2, email = $3, phone = $4, icon_url = $5, description = $6, role = $7
+		SET username = $2, email = $3, phone = $4, icon_url = $5, description = $6, role = $7, is_private = $8
 		WHERE id = $1
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		user.ID, user.Username, user.Email, user.Phone, user.IconURL,
-		user.Description, user.Role,
+		user.Description, user.Role, user.IsPrivate,
 	)
 	return err
 }
@@ -193,6 +197,10 @@ func (r *userRepository) IsFollowing(ctx context.Context, followerID, followingI
 }
 
 func (r *userRepository) Follow(ctx context.Context, followerID, followingID string) error {
+	if err := ensureNotBlocked(ctx, r.pool, followerID, followingID); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO user_follows (follower_id, following_id)
 		VALUES ($1, $2)
@@ -211,35 +219,64 @@ func (r *userRepository) Unfollow(ctx context.Context, followerID, followingID s
 	return err
 }
 
-func (r *userRepository) Search(ctx context.Context, query string, role *domain.UserRole, limit, offset int) ([]*domain.User, int, error) {
-	searchQuery := `%` + query + `%`
-	baseQuery := `
-		SELECT id, username, email, phone, icon_url, description, role, registered_at
-		FROM users
-		WHERE (username ILIKE $1 OR description ILIKE $1)
-	`
-	args := []interface{}{searchQuery}
-	argIndex := 2
+// User search terms: $1 is the lowercased query and $2 the same query escaped for LIKE
+const (
+	// userMatchTier is 3 for an exact username, 2 for a prefix, 1 for a similar or containing
+	// username and 0 for a description match
+	userMatchTier = `CASE
+		WHEN lower(u.username) = $1 THEN 3
+		WHEN u.username ILIKE $2::text || '%' THEN 2
+		WHEN u.username % $1 OR u.username ILIKE '%' || $2::text || '%' THEN 1
+		ELSE 0
+	END`
+
+	// userSearchBoost adds a tenth of the log of followers and a half for users the viewer ($3) follows
+	userSearchBoost = `similarity(u.username, $1)
+		+ ln(1 + u.followers_count) / 10
+		+ CASE WHEN EXISTS (SELECT 1 FROM user_follows mf WHERE mf.follower_id = $3 AND mf.following_id = u.id)
+		       THEN 0.5 ELSE 0 END`
+)
+
+// userMatches are the user match tiers from best to worst
+var userMatches = [...]domain.UserMatch{
+	3: domain.UserMatchExact,
+	2: domain.UserMatchPrefix,
+	1: domain.UserMatchFuzzy,
+	0: domain.UserMatchDescription,
+}
+
+func (r *userRepository) Search(ctx context.Context, query string, viewerUserID *string, role *domain.UserRole, limit, offset int) ([]*domain.UserSearchResult, int, error) {
+	term := strings.ToLower(strings.TrimSpace(query))
+	// % is the pg_trgm similarity operator, served by the trigram indexes like ILIKE
+	where := `(u.username % $1 OR u.username ILIKE '%' || $2::text || '%' OR u.description ILIKE '%' || $2::text || '%') AND ` +
+		notBlockedCondition("u.id", "$3::uuid")
+	args := []interface{}{term, escapeLike(term), viewerUserID}
+	argIndex := 4
 
 	if role != nil {
-		baseQuery += fmt.Sprintf(" AND role = $%d", argIndex)
+		where += fmt.Sprintf(" AND u.role = $%d", argIndex)
 		args = append(args, *role)
 		argIndex++
 	}
 
 	// Get total count
 	var total int
-	countQuery := "SELECT COUNT(*) FROM users WHERE (username ILIKE $1 OR description ILIKE $1)"
-	if role != nil {
-		countQuery += " AND role = $2"
-	}
+	countQuery := "SELECT COUNT(*) FROM users u WHERE " + where
 	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get users
-	baseQuery += fmt.Sprintf(" ORDER BY username LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	baseQuery := fmt.Sprintf(`
+		SELECT `+publicUserColumns+`,
+		       EXISTS (SELECT 1 FROM user_follows mf WHERE mf.follower_id = $3 AND mf.following_id = u.id) AS is_followed_by_me,
+		       EXISTS (SELECT 1 FROM user_follows fm WHERE fm.follower_id = u.id AND fm.following_id = $3) AS follows_me,
+		       u.followers_count, %s AS tier, %s AS rank
+		FROM users u
+		WHERE %s
+		ORDER BY tier DESC, rank DESC, u.username
+		LIMIT $%d OFFSET $%d`, userMatchTier, userSearchBoost, where, argIndex, argIndex+1)
 	args = append(args, limit, offset)
 
 	rows, err := r.pool.Query(ctx, baseQuery, args...)
@@ -248,18 +285,243 @@ func (r *userRepository) Search(ctx context.Context, query string, role *domain.
 	}
 	defer rows.Close()
 
-	var users []*domain.User
+	var users []*domain.UserSearchResult
 	for rows.Next() {
-		var user domain.User
+		var (
+			user domain.UserSearchResult
+			tier int
+		)
 		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.Phone, &user.IconURL,
-			&user.Description, &user.Role, &user.RegisteredAt,
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+			&user.IsFollowedByMe, &user.FollowsMe, &user.FollowersCount, &tier, &user.Rank,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
+		user.Match = userMatches[tier]
 		users = append(users, &user)
 	}
 
 	return users, total, rows.Err()
 }
+
+func (r *userRepository) Autocomplete(ctx context.Context, prefix string, viewerUserID *string, limit int) ([]*domain.UserSuggestion, error) {
+	term := strings.ToLower(strings.TrimSpace(prefix))
+	// Only usernames are matched, and followed users come first within a tier
+	query := fmt.Sprintf(`
+		SELECT u.id, u.username, u.icon_url,
+		       EXISTS (SELECT 1 FROM user_follows mf WHERE mf.follower_id = $3 AND mf.following_id = u.id) AS is_followed_by_me,
+		       %s AS tier
+		FROM users u
+		WHERE (u.username ILIKE $2::text || '%%' OR u.username %% $1) AND %s
+		ORDER BY tier DESC, is_followed_by_me DESC, u.followers_count DESC, u.username
+		LIMIT $4`, userMatchTier, notBlockedCondition("u.id", "$3::uuid"))
+
+	rows, err := r.pool.Query(ctx, query, term, escapeLike(term), viewerUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.UserSuggestion
+	for rows.Next() {
+		var (
+			user domain.UserSuggestion
+			tier int
+		)
+		if err := rows.Scan(&user.ID, &user.Username, &user.IconURL, &user.IsFollowedByMe, &tier); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// escapeLike escapes the LIKE wildcards in s, so that it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (r *userRepository) GetFollowers(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	return r.listFollowCards(ctx, "f.follower_id", "f.following_id = $1", userID, viewerUserID, limit, offset)
+}
+
+func (r *userRepository) GetFollowing(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	return r.listFollowCards(ctx, "f.following_id", "f.follower_id = $1", userID, viewerUserID, limit, offset)
+}
+
+func (r *userRepository) GetMutuals(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	where := `f.following_id = $1 AND EXISTS (
+		SELECT 1 FROM user_follows back WHERE back.follower_id = $1 AND back.following_id = f.follower_id)`
+	return r.listFollowCards(ctx, "f.follower_id", where, userID, viewerUserID, limit, offset)
+}
+
+// listFollowCards lists users referenced by userColumn of user_follows rows matching where,
+// newest follows first; $1 is userID and $2 is the viewer
+func (r *userRepository) listFollowCards(ctx context.Context, userColumn, where, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	where += " AND " + notBlockedCondition(userColumn, "$2::uuid")
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM user_follows f WHERE " + where
+	if err := r.pool.QueryRow(ctx, countQuery, userID, viewerUserID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// A NULL viewer follows nobody and is followed by nobody
+	query := fmt.Sprintf(`
+		SELECT `+publicUserColumns+`,
+		       EXISTS (SELECT 1 FROM user_follows mf WHERE mf.follower_id = $2 AND mf.following_id = u.id) AS is_followed_by_me,
+		       EXISTS (SELECT 1 FROM user_follows fm WHERE fm.follower_id = u.id AND fm.following_id = $2) AS follows_me
+		FROM user_follows f
+		INNER JOIN users u ON u.id = %s
+		WHERE %s
+		ORDER BY f.created_at DESC
+		LIMIT $3 OFFSET $4
+	`, userColumn, where)
+	rows, err := r.pool.Query(ctx, query, userID, viewerUserID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var cards []*domain.UserCard
+	for rows.Next() {
+		var card domain.UserCard
+		if err := rows.Scan(
+			&card.ID, &card.Username, &card.IconURL,
+			&card.Description, &card.Role, &card.RegisteredAt, &card.IsPrivate,
+			&card.IsFollowedByMe, &card.FollowsMe,
+		); err != nil {
+			return nil, 0, err
+		}
+		cards = append(cards, &card)
+	}
+
+	return cards, total, rows.Err()
+}
+
+func (r *userRepository) CreateFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	if err := ensureNotBlocked(ctx, r.pool, requesterID, targetID); err != nil {
+		return err
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO follow_requests (requester_id, target_id)
+		VALUES ($1, $2)
+		ON CONFLICT (requester_id, target_id) DO NOTHING
+	`, requesterID, targetID)
+	return err
+}
+
+func (r *userRepository) HasFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM follow_requests WHERE requester_id = $1 AND target_id = $2)
+	`, requesterID, targetID).Scan(&exists)
+	return exists, err
+}
+
+func (r *userRepository) DeleteFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2
+	`, requesterID, targetID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *userRepository) GetFollowRequests(ctx context.Context, targetID string, limit, offset int) ([]*domain.FollowRequest, int, error) {
+	// Requests from users blocked in either direction are not shown
+	where := "fr.target_id = $1 AND " + notBlockedCondition("fr.requester_id", "$1")
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM follow_requests fr WHERE " + where
+	if err := r.pool.QueryRow(ctx, countQuery, targetID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT fr.requester_id, fr.target_id, fr.created_at,
+		       `+publicUserColumns+`
+		FROM follow_requests fr
+		INNER JOIN users u ON u.id = fr.requester_id
+		WHERE `+where+`
+		ORDER BY fr.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, targetID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var requests []*domain.FollowRequest
+	for rows.Next() {
+		var request domain.FollowRequest
+		var user domain.User
+		if err := rows.Scan(
+			&request.RequesterID, &request.TargetID, &request.CreatedAt,
+			&user.ID, &user.Username, &user.IconURL,
+			&user.Description, &user.Role, &user.RegisteredAt, &user.IsPrivate,
+		); err != nil {
+			return nil, 0, err
+		}
+		request.Requester = &user
+		requests = append(requests, &request)
+	}
+
+	return requests, total, rows.Err()
+}
+
+func (r *userRepository) ApproveFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2
+	`, requesterID, targetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	if err := ensureNotBlocked(ctx, tx, requesterID, targetID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_follows (follower_id, following_id)
+		VALUES ($1, $2)
+		ON CONFLICT (follower_id, following_id) DO NOTHING
+	`, requesterID, targetID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *userRepository) ApproveAllFollowRequests(ctx context.Context, targetID string) error {
+	// Requests between users who blocked each other are dropped instead of approved
+	_, err := r.pool.Exec(ctx, `
+		WITH approved AS (
+			DELETE FROM follow_requests fr
+			WHERE fr.target_id = $1
+			RETURNING fr.requester_id
+		)
+		INSERT INTO user_follows (follower_id, following_id)
+		SELECT a.requester_id, $1 FROM approved a
+		WHERE `+notBlockedCondition("a.requester_id", "$1")+`
+		ON CONFLICT (follower_id, following_id) DO NOTHING
+	`, targetID)
+	return err
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(pool *pgxpool.Pool) domain.WebhookRepository {
+	return &webhookRepository{pool: pool}
+}
+
+const webhookColumns = `id, user_id, url, description, event_types, secret, is_active, failure_count, disabled_at, created_at, updated_at`
+
+const deliveryColumns = `d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+	d.last_attempt_at, d.response_status, d.response_body, d.error, d.duration_ms, d.replay_of, d.created_at`
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO webhooks (id, user_id, url, description, event_types, secret, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, webhook.ID, webhook.UserID, webhook.URL, webhook.Description, eventTypeStrings(webhook.EventTypes),
+		webhook.Secret, webhook.IsActive, webhook.CreatedAt, webhook.UpdatedAt)
+	return err
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, userID, webhookID string) (*domain.Webhook, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1 AND user_id = $2`, webhookID, userID)
+	return scanWebhook(row)
+}
+
+func (r *webhookRepository) GetWebhook(ctx context.Context, webhookID string) (*domain.Webhook, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, webhookID)
+	return scanWebhook(row)
+}
+
+func (r *webhookRepository) GetByUser(ctx context.Context, userID string) ([]*domain.Webhook, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+webhookColumns+` FROM webhooks WHERE user_id = $1 ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+func (r *webhookRepository) CountByUser(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM webhooks WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE webhooks
+		SET url = $3, description = $4, event_types = $5, is_active = $6,
+		    failure_count = $7, disabled_at = $8, updated_at = $9
+		WHERE id = $1 AND user_id = $2
+	`, webhook.ID, webhook.UserID, webhook.URL, webhook.Description, eventTypeStrings(webhook.EventTypes),
+		webhook.IsActive, webhook.FailureCount, webhook.DisabledAt, webhook.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, userID, webhookID string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1 AND user_id = $2`, webhookID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetActiveForEvent(ctx context.Context, userIDs []string, eventType domain.EventType) ([]*domain.Webhook, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE user_id = ANY($1::uuid[]) AND is_active AND $2 = ANY(event_types)
+	`, userIDs, string(eventType))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWebhooks(rows)
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, next_attempt_at, replay_of, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (webhook_id, event_id) WHERE replay_of IS NULL DO NOTHING
+	`, delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Payload, delivery.Status,
+		delivery.NextAttemptAt, delivery.ReplayOf, delivery.CreatedAt)
+	return err
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, userID, webhookID, deliveryID string) (*domain.WebhookDelivery, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		INNER JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.id = $1 AND d.webhook_id = $2 AND w.user_id = $3
+	`, deliveryID, webhookID, userID)
+	delivery, err := scanDelivery(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return delivery, err
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, webhookID string, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1
+	`, webhookID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1
+		ORDER BY d.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, webhookID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries, err := scanDeliveries(rows)
+	return deliveries, total, err
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $1 + make_interval(secs => $2)
+		WHERE d.id IN (
+			SELECT due.id
+			FROM webhook_deliveries due
+			INNER JOIN webhooks w ON w.id = due.webhook_id
+			WHERE due.status = 'pending' AND due.next_attempt_at <= $1 AND w.is_active
+			ORDER BY due.next_attempt_at
+			LIMIT $3
+			FOR UPDATE OF due SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns+`
+	`, now, lease.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+		    response_status = $6, response_body = $7, error = $8, duration_ms = $9
+		WHERE id = $1
+	`, delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		delivery.ResponseStatus, delivery.ResponseBody, delivery.Error, delivery.DurationMs)
+	return err
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, webhookID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhooks SET failure_count = 0 WHERE id = $1 AND failure_count <> 0
+	`, webhookID)
+	return err
+}
+
+func (r *webhookRepository) RecordFailure(ctx context.Context, webhookID string, disableAfter int) (bool, error) {
+	var disabled bool
+	err := r.pool.QueryRow(ctx, `
+		UPDATE webhooks w
+		SET failure_count = w.failure_count + 1,
+		    is_active = w.is_active AND w.failure_count + 1 < $2,
+		    disabled_at = CASE
+		        WHEN w.is_active AND w.failure_count + 1 >= $2 THEN now()
+		        ELSE w.disabled_at
+		    END
+		FROM (SELECT id, is_active FROM webhooks WHERE id = $1 FOR UPDATE) old
+		WHERE w.id = old.id
+		RETURNING old.is_active AND NOT w.is_active
+	`, webhookID, disableAfter).Scan(&disabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return disabled, err
+}
+
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var (
+		webhook    domain.Webhook
+		eventTypes []string
+	)
+	err := row.Scan(
+		&webhook.ID, &webhook.UserID, &webhook.URL, &webhook.Description, &eventTypes, &webhook.Secret,
+		&webhook.IsActive, &webhook.FailureCount, &webhook.DisabledAt, &webhook.CreatedAt, &webhook.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range eventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, domain.EventType(t))
+	}
+	return &webhook, nil
+}
+
+func scanWebhooks(rows pgx.Rows) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func scanDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := row.Scan(
+		&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastAttemptAt, &delivery.ResponseStatus,
+		&delivery.ResponseBody, &delivery.Error, &delivery.DurationMs, &delivery.ReplayOf, &delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func scanDeliveries(rows pgx.Rows) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func eventTypeStrings(types []domain.EventType) []string {
+	values := make([]string, len(types))
+	for i, t := range types {
+		values[i] = string(t)
+	}
+	return values
+}
@@ -0,0 +1,121 @@
+package searchindex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is a word of a text with its byte offsets
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits text into words of letters and digits
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for offset, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWord && start < 0:
+			start = offset
+		case !isWord && start >= 0:
+			tokens = append(tokens, token{term: normalize(text[start:offset]), start: start, end: offset})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{term: normalize(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+// terms returns the normalized words of text
+func terms(text string) []string {
+	tokens := tokenize(text)
+	result := make([]string, len(tokens))
+	for i, t := range tokens {
+		result[i] = t.term
+	}
+	return result
+}
+
+// normalize lowercases a word and folds ё into е, as Russian texts use both
+func normalize(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+}
+
+// parsedQuery is a search query in the websearch_to_tsquery syntax: every clause must match one
+// of its terms, and no excluded term may match
+type parsedQuery struct {
+	clauses  [][]string
+	excluded []string
+}
+
+// parseQuery parses words, "quoted phrases", OR between words and -excluded words. Phrases match
+// as all of their words, in any order
+func parseQuery(text string) *parsedQuery {
+	q := &parsedQuery{}
+	or := false
+	for _, field := range splitQuery(text) {
+		if !field.quoted && field.text == "OR" {
+			or = len(q.clauses) > 0
+			continue
+		}
+		if !field.quoted && strings.HasPrefix(field.text, "-") {
+			q.excluded = append(q.excluded, terms(field.text[1:])...)
+			or = false
+			continue
+		}
+
+		fieldTerms := terms(field.text)
+		for i, term := range fieldTerms {
+			if or && i == 0 {
+				last := len(q.clauses) - 1
+				q.clauses[last] = append(q.clauses[last], term)
+				continue
+			}
+			q.clauses = append(q.clauses, []string{term})
+		}
+		if len(fieldTerms) > 0 {
+			or = false
+		}
+	}
+	return q
+}
+
+// queryField is a whitespace-separated word or a quoted phrase of a query
+type queryField struct {
+	text   string
+	quoted bool
+}
+
+// splitQuery splits a query into words and quoted phrases; an unclosed quote runs to the end
+func splitQuery(text string) []queryField {
+	var fields []queryField
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		switch {
+		case unicode.IsSpace(r):
+			text = text[size:]
+		case r == '"':
+			text = text[size:]
+			end := strings.IndexByte(text, '"')
+			if end < 0 {
+				end = len(text)
+			}
+			fields = append(fields, queryField{text: text[:end], quoted: true})
+			text = text[min(end+1, len(text)):]
+		default:
+			end := strings.IndexFunc(text, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if end < 0 {
+				end = len(text)
+			}
+			fields = append(fields, queryField{text: text[:end]})
+			text = text[end:]
+		}
+	}
+	return fields
+}
@@ -0,0 +1,99 @@
+package searchindex
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"sense-backend/internal/domain"
+)
+
+// Correct maps each word missing from public documents to the public term at the smallest edit
+// distance, the most common one on ties. Up to one edit is allowed in words of five letters or
+// less and two in longer ones
+func (i *Index) Correct(ctx context.Context, words []string) (map[string]string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	corrections := make(map[string]string)
+	for _, word := range words {
+		term := normalize(strings.TrimSpace(word))
+		length := utf8.RuneCountInString(term)
+		if length < domain.MinCorrectedWordLength || i.publicDocs[term] > 0 {
+			continue
+		}
+
+		maxDistance := 1
+		if length > 5 {
+			maxDistance = 2
+		}
+
+		best, bestDistance := "", maxDistance+1
+		for candidate, count := range i.publicDocs {
+			if abs(utf8.RuneCountInString(candidate)-length) > maxDistance {
+				continue
+			}
+			d := distance(term, candidate, maxDistance)
+			if d > maxDistance {
+				continue
+			}
+			if d < bestDistance || (d == bestDistance && better(candidate, best, count, i.publicDocs[best])) {
+				best, bestDistance = candidate, d
+			}
+		}
+		if best != "" {
+			corrections[word] = best
+		}
+	}
+	return corrections, nil
+}
+
+// better tells whether candidate beats best at the same distance: more common, then first in order
+func better(candidate, best string, count, bestCount int) bool {
+	if count != bestCount {
+		return count > bestCount
+	}
+	return best == "" || candidate < best
+}
+
+// distance returns the optimal string alignment distance between a and b: insertions, deletions,
+// substitutions and transpositions of adjacent letters. Distances above limit are reported as
+// limit+1 without being computed to the end
+func distance(a, b string, limit int) int {
+	x, y := []rune(a), []rune(b)
+	// Three rows of the dynamic programming table: two back, previous and current
+	prev2 := make([]int, len(y)+1)
+	prev := make([]int, len(y)+1)
+	curr := make([]int, len(y)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return min(prev[len(y)], limit+1)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
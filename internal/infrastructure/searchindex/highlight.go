@@ -0,0 +1,63 @@
+package searchindex
+
+import (
+	"html"
+	"strings"
+)
+
+// Highlight markup and content fragment size, as in the Postgres backend
+const (
+	markStart = "<mark>"
+	markEnd   = "</mark>"
+	// fragmentWords is the number of words of a content fragment
+	fragmentWords = 35
+	// fragmentLead is the number of words kept before the first match
+	fragmentLead = 10
+	// fragmentEllipsis marks text cut from a fragment
+	fragmentEllipsis = "…"
+)
+
+// highlight returns text escaped for HTML with the words matching terms wrapped in <mark>
+func highlight(text string, matches map[string]bool) string {
+	var b strings.Builder
+	prev := 0
+	for _, t := range tokenize(text) {
+		if !matches[t.term] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[prev:t.start]))
+		b.WriteString(markStart)
+		b.WriteString(html.EscapeString(text[t.start:t.end]))
+		b.WriteString(markEnd)
+		prev = t.end
+	}
+	b.WriteString(html.EscapeString(text[prev:]))
+	return b.String()
+}
+
+// fragment returns up to fragmentWords words of text around its first match, highlighted
+func fragment(text string, matches map[string]bool) string {
+	tokens := tokenize(text)
+	if len(tokens) <= fragmentWords {
+		return highlight(text, matches)
+	}
+
+	first := 0
+	for i, t := range tokens {
+		if matches[t.term] {
+			first = i
+			break
+		}
+	}
+	from := max(0, min(first-fragmentLead, len(tokens)-fragmentWords))
+	to := from + fragmentWords
+
+	result := highlight(text[tokens[from].start:tokens[to-1].end], matches)
+	if from > 0 {
+		result = fragmentEllipsis + " " + result
+	}
+	if to < len(tokens) {
+		result += " " + fragmentEllipsis
+	}
+	return result
+}
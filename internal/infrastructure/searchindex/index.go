@@ -0,0 +1,419 @@
+// Package searchindex is an embedded full-text index of publications for deployments that
+// do not use the Postgres search backend
+package searchindex
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Field weights: a title word counts as three content words
+const (
+	titleWeight   = 3
+	tagWeight     = 2
+	contentWeight = 1
+	sourceWeight  = 1
+)
+
+// snapshotVersion is bumped when the file format changes; files of other versions are ignored
+// and the index is rebuilt by a reindex
+const snapshotVersion = 1
+
+// snapshot is the file form of the index. Only documents are stored; term statistics are
+// rebuilt on load
+type snapshot struct {
+	Version   int
+	Documents []*domain.SearchDocument
+}
+
+// document is an indexed publication with its weighted term frequencies
+type document struct {
+	*domain.SearchDocument
+	terms  map[string]float64
+	length float64
+}
+
+// Index is an in-memory BM25 inverted index persisted to a file.
+//
+// It knows no follows, community members or blocks, so queries find the viewer's own
+// publications and public ones outside communities; callers are expected to load the hits
+// through the visibility rules of the database.
+type Index struct {
+	path string
+
+	mu          sync.RWMutex
+	docs        map[string]*document
+	postings    map[string]map[string]float64 // term to document ID to weighted frequency
+	publicDocs  map[string]int                // term to the number of public documents using it
+	totalLength float64
+	dirty       bool
+
+	// flushMu serializes writes of the file
+	flushMu sync.Mutex
+}
+
+// Open loads the index stored at path; a missing file opens an empty index
+func Open(path string) (*Index, error) {
+	index := &Index{
+		path:       path,
+		docs:       make(map[string]*document),
+		postings:   make(map[string]map[string]float64),
+		publicDocs: make(map[string]int),
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snap snapshot
+	if err := gob.NewDecoder(file).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("decode search index %s: %w", path, err)
+	}
+	if snap.Version != snapshotVersion {
+		return index, nil
+	}
+	for _, doc := range snap.Documents {
+		index.add(doc)
+	}
+	return index, nil
+}
+
+func (i *Index) Index(ctx context.Context, docs ...*domain.SearchDocument) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, doc := range docs {
+		i.remove(doc.ID)
+		i.add(doc)
+	}
+	i.dirty = i.dirty || len(docs) > 0
+	return nil
+}
+
+func (i *Index) Delete(ctx context.Context, ids ...string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, id := range ids {
+		if i.remove(id) {
+			i.dirty = true
+		}
+	}
+	return nil
+}
+
+func (i *Index) Query(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.SearchHit, int, error) {
+	parsed := parseQuery(query)
+	if len(parsed.clauses) == 0 {
+		return nil, 0, nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	scores := i.score(parsed)
+	for _, term := range parsed.excluded {
+		for id := range i.postings[term] {
+			delete(scores, id)
+		}
+	}
+
+	type scored struct {
+		doc   *document
+		score float64
+	}
+	matched := make([]scored, 0, len(scores))
+	for id, score := range scores {
+		doc := i.docs[id]
+		if visible(doc, viewerUserID) && matchesFilters(doc, filters) {
+			matched = append(matched, scored{doc: doc, score: score})
+		}
+	}
+	slices.SortFunc(matched, func(x, y scored) int {
+		if x.score != y.score {
+			if x.score > y.score {
+				return -1
+			}
+			return 1
+		}
+		return y.doc.PublicationDate.Compare(x.doc.PublicationDate)
+	})
+
+	total := len(matched)
+	page := matched[min(offset, total):min(offset+limit, total)]
+
+	// Highlights are built for the requested page only
+	highlighted := make(map[string]bool)
+	for _, clause := range parsed.clauses {
+		for _, term := range clause {
+			highlighted[term] = true
+		}
+	}
+	hits := make([]*domain.SearchHit, len(page))
+	for n, m := range page {
+		hits[n] = &domain.SearchHit{
+			ID:   m.doc.ID,
+			Rank: m.score,
+			Highlights: domain.SearchHighlights{
+				Title: highlight(m.doc.Title, highlighted),
+			},
+		}
+		if m.doc.Content != nil {
+			content := fragment(*m.doc.Content, highlighted)
+			hits[n].Highlights.Content = &content
+		}
+	}
+	return hits, total, nil
+}
+
+func (i *Index) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = normalize(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return []string{}, nil
+	}
+
+	i.mu.RLock()
+	words := make([]string, 0)
+	for word := range i.publicDocs {
+		if strings.HasPrefix(word, prefix) {
+			words = append(words, word)
+		}
+	}
+	slices.SortFunc(words, func(x, y string) int {
+		if cx, cy := i.publicDocs[x], i.publicDocs[y]; cx != cy {
+			return cy - cx
+		}
+		return strings.Compare(x, y)
+	})
+	i.mu.RUnlock()
+
+	return words[:min(limit, len(words))], nil
+}
+
+// Flush writes the index to its file if it changed since the last flush. The file is replaced
+// atomically, so a crash leaves the previous version
+func (i *Index) Flush() error {
+	i.flushMu.Lock()
+	defer i.flushMu.Unlock()
+
+	i.mu.Lock()
+	if !i.dirty {
+		i.mu.Unlock()
+		return nil
+	}
+	snap := snapshot{Version: snapshotVersion, Documents: make([]*domain.SearchDocument, 0, len(i.docs))}
+	for _, doc := range i.docs {
+		snap.Documents = append(snap.Documents, doc.SearchDocument)
+	}
+	i.dirty = false
+	i.mu.Unlock()
+
+	if err := i.write(&snap); err != nil {
+		i.mu.Lock()
+		i.dirty = true
+		i.mu.Unlock()
+		return fmt.Errorf("write search index %s: %w", i.path, err)
+	}
+	return nil
+}
+
+// RunFlusher flushes the index every interval until ctx is cancelled
+func (i *Index) RunFlusher(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := i.Flush(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (i *Index) write(snap *snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(i.path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(i.path), filepath.Base(i.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(snap); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), i.path)
+}
+
+// score sums the BM25 scores of the documents matching every clause; a clause scores its best
+// matching term
+func (i *Index) score(q *parsedQuery) map[string]float64 {
+	var scores map[string]float64
+	n := float64(len(i.docs))
+	avgLength := i.totalLength / max(n, 1)
+
+	for c, clause := range q.clauses {
+		clauseScores := make(map[string]float64)
+		for _, term := range clause {
+			postings := i.postings[term]
+			df := float64(len(postings))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for id, tf := range postings {
+				if c > 0 {
+					if _, ok := scores[id]; !ok {
+						continue
+					}
+				}
+				norm := tf * (k1 + 1) / (tf + k1*(1-b+b*i.docs[id].length/avgLength))
+				clauseScores[id] = max(clauseScores[id], idf*norm)
+			}
+		}
+
+		if c == 0 {
+			scores = clauseScores
+			continue
+		}
+		for id := range scores {
+			if s, ok := clauseScores[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+// add indexes doc, which must not be indexed yet
+func (i *Index) add(doc *domain.SearchDocument) {
+	d := &document{SearchDocument: doc, terms: make(map[string]float64)}
+	count := func(text string, weight float64) {
+		for _, term := range terms(text) {
+			d.terms[term] += weight
+			d.length += weight
+		}
+	}
+	count(doc.Title, titleWeight)
+	if doc.Content != nil {
+		count(*doc.Content, contentWeight)
+	}
+	if doc.Source != nil {
+		count(*doc.Source, sourceWeight)
+	}
+	for _, tag := range doc.Tags {
+		count(tag, tagWeight)
+	}
+
+	i.docs[doc.ID] = d
+	i.totalLength += d.length
+	public := isPublic(doc)
+	for term, tf := range d.terms {
+		postings, ok := i.postings[term]
+		if !ok {
+			postings = make(map[string]float64)
+			i.postings[term] = postings
+		}
+		postings[doc.ID] = tf
+		if public {
+			i.publicDocs[term]++
+		}
+	}
+}
+
+// remove drops the document with id, reporting whether it was indexed
+func (i *Index) remove(id string) bool {
+	d, ok := i.docs[id]
+	if !ok {
+		return false
+	}
+
+	public := isPublic(d.SearchDocument)
+	for term := range d.terms {
+		postings := i.postings[term]
+		delete(postings, id)
+		if len(postings) == 0 {
+			delete(i.postings, term)
+		}
+		if public {
+			if i.publicDocs[term]--; i.publicDocs[term] == 0 {
+				delete(i.publicDocs, term)
+			}
+		}
+	}
+	i.totalLength -= d.length
+	delete(i.docs, id)
+	return true
+}
+
+// isPublic tells whether everyone may see doc
+func isPublic(doc *domain.SearchDocument) bool {
+	return doc.Visibility == domain.VisibilityTypePublic && doc.CommunityID == nil
+}
+
+// visible tells whether doc may be shown to the viewer without knowing their relationships
+func visible(doc *document, viewerUserID *string) bool {
+	return isPublic(doc.SearchDocument) || (viewerUserID != nil && doc.AuthorID == *viewerUserID)
+}
+
+func matchesFilters(doc *document, filters *domain.SearchFilters) bool {
+	if filters == nil {
+		return true
+	}
+	if filters.Type != nil && doc.Type != *filters.Type {
+		return false
+	}
+	if filters.Visibility != nil && doc.Visibility != *filters.Visibility {
+		return false
+	}
+	if filters.AuthorID != nil && doc.AuthorID != *filters.AuthorID {
+		return false
+	}
+	for _, tag := range filters.Tags {
+		if !slices.Contains(doc.Tags, tag) {
+			return false
+		}
+	}
+	if filters.DateFrom != nil && doc.PublicationDate.Before(*filters.DateFrom) {
+		return false
+	}
+	if filters.DateTo != nil && !doc.PublicationDate.Before(*filters.DateTo) {
+		return false
+	}
+	return true
+}
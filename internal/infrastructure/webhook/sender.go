@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// maxResponseBody is the number of response bytes read
+const maxResponseBody = 4096
+
+// errForbiddenAddress is returned when a webhook URL resolves to a loopback, private or link-local address
+var errForbiddenAddress = errors.New("webhook address is not public")
+
+// Params configures the webhook sender
+type Params struct {
+	// Timeout limits one delivery attempt
+	Timeout time.Duration
+	// AllowPrivateNetworks permits deliveries to loopback and private addresses, for local runs
+	AllowPrivateNetworks bool
+}
+
+// DefaultParams are the sender settings used in production
+var DefaultParams = Params{
+	Timeout: 10 * time.Second,
+}
+
+type sender struct {
+	client *http.Client
+}
+
+// NewSender creates an HTTP webhook sender. Unless private networks are allowed, connections to
+// internal addresses are refused after DNS resolution, so user-supplied URLs cannot reach
+// services behind the firewall
+func NewSender(params Params) domain.WebhookSender {
+	dialer := &net.Dialer{Timeout: params.Timeout}
+	if !params.AllowPrivateNetworks {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublic(ip) {
+				return fmt.Errorf("%w: %s", errForbiddenAddress, host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &sender{
+		client: &http.Client{
+			Timeout:   params.Timeout,
+			Transport: transport,
+			// A redirect is reported as the response; following it would bypass the address check
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *sender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (*domain.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sense-Webhooks/1.0")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, err
+	}
+	// Drain a little more so the connection can be reused
+	_, _ = io.CopyN(io.Discard, resp.Body, 64*1024)
+
+	return &domain.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(responseBody),
+	}, nil
+}
+
+func isPublic(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
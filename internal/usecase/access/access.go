@@ -0,0 +1,20 @@
+// Package access holds permission checks shared by use cases
+package access
+
+import (
+	"context"
+
+	"sense-backend/internal/domain"
+)
+
+// RequireSuperUser checks the stored role, so a demoted user loses access before the token expires
+func RequireSuperUser(ctx context.Context, users domain.UserRepository, userID string) error {
+	user, err := users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != domain.UserRoleSuper {
+		return domain.ErrSuperUserRequired
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRequireSuperUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetByID(gomock.Any(), "root").Return(&domain.User{ID: "root", Role: domain.UserRoleSuper}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "alice").Return(&domain.User{ID: "alice", Role: domain.UserRoleExpert}, nil)
+	dbErr := errors.New("db down")
+	userRepo.EXPECT().GetByID(gomock.Any(), "ghost").Return(nil, dbErr)
+
+	assert.NoError(t, RequireSuperUser(context.Background(), userRepo, "root"))
+	assert.ErrorIs(t, RequireSuperUser(context.Background(), userRepo, "alice"), domain.ErrSuperUserRequired)
+	assert.ErrorIs(t, RequireSuperUser(context.Background(), userRepo, "ghost"), dbErr)
+}
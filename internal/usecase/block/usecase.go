@@ -0,0 +1,73 @@
+package block
+
+import (
+	"context"
+	"errors"
+
+	"sense-backend/internal/domain"
+)
+
+var (
+	// ErrSelfBlock is returned when a user tries to block themselves
+	ErrSelfBlock = errors.New("cannot block yourself")
+	// ErrSelfMute is returned when a user tries to mute themselves
+	ErrSelfMute = errors.New("cannot mute yourself")
+)
+
+// UseCase handles block and mute use cases
+type UseCase struct {
+	blockRepo domain.BlockRepository
+	userRepo  domain.UserRepository
+}
+
+// NewUseCase creates a new block use case
+func NewUseCase(blockRepo domain.BlockRepository, userRepo domain.UserRepository) *UseCase {
+	return &UseCase{
+		blockRepo: blockRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Block blocks a user; follow relationships in both directions are removed
+func (uc *UseCase) Block(ctx context.Context, blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return ErrSelfBlock
+	}
+	// Check if user exists
+	if _, err := uc.userRepo.GetByID(ctx, blockedID); err != nil {
+		return err
+	}
+	return uc.blockRepo.Block(ctx, blockerID, blockedID)
+}
+
+// Unblock removes a block
+func (uc *UseCase) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	return uc.blockRepo.Unblock(ctx, blockerID, blockedID)
+}
+
+// GetBlocked retrieves users blocked by user
+func (uc *UseCase) GetBlocked(ctx context.Context, userID string, limit, offset int) ([]*domain.User, int, error) {
+	return uc.blockRepo.GetBlocked(ctx, userID, limit, offset)
+}
+
+// Mute mutes a user
+func (uc *UseCase) Mute(ctx context.Context, muterID, mutedID string) error {
+	if muterID == mutedID {
+		return ErrSelfMute
+	}
+	// Check if user exists
+	if _, err := uc.userRepo.GetByID(ctx, mutedID); err != nil {
+		return err
+	}
+	return uc.blockRepo.Mute(ctx, muterID, mutedID)
+}
+
+// Unmute removes a mute
+func (uc *UseCase) Unmute(ctx context.Context, muterID, mutedID string) error {
+	return uc.blockRepo.Unmute(ctx, muterID, mutedID)
+}
+
+// GetMuted retrieves users muted by user
+func (uc *UseCase) GetMuted(ctx context.Context, userID string, limit, offset int) ([]*domain.User, int, error) {
+	return uc.blockRepo.GetMuted(ctx, userID, limit, offset)
+}
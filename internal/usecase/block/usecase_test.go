@@ -0,0 +1,101 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlock_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(blockRepo, userRepo)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-2").Return(&domain.User{ID: "user-2"}, nil)
+	blockRepo.EXPECT().Block(gomock.Any(), "user-1", "user-2").Return(nil)
+
+	err := uc.Block(context.Background(), "user-1", "user-2")
+
+	require.NoError(t, err)
+}
+
+func TestBlock_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUseCase(mocks.NewMockBlockRepository(ctrl), mocks.NewMockUserRepository(ctrl))
+
+	err := uc.Block(context.Background(), "user-1", "user-1")
+
+	assert.ErrorIs(t, err, ErrSelfBlock)
+}
+
+func TestBlock_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(blockRepo, userRepo)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "missing").Return(nil, errors.New("user not found"))
+
+	err := uc.Block(context.Background(), "user-1", "missing")
+
+	assert.Error(t, err)
+}
+
+func TestMute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(blockRepo, userRepo)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-2").Return(&domain.User{ID: "user-2"}, nil)
+	blockRepo.EXPECT().Mute(gomock.Any(), "user-1", "user-2").Return(nil)
+
+	err := uc.Mute(context.Background(), "user-1", "user-2")
+
+	require.NoError(t, err)
+}
+
+func TestMute_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUseCase(mocks.NewMockBlockRepository(ctrl), mocks.NewMockUserRepository(ctrl))
+
+	err := uc.Mute(context.Background(), "user-1", "user-1")
+
+	assert.ErrorIs(t, err, ErrSelfMute)
+}
+
+func TestGetBlocked_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	uc := NewUseCase(blockRepo, mocks.NewMockUserRepository(ctrl))
+
+	blockRepo.EXPECT().
+		GetBlocked(gomock.Any(), "user-1", 20, 0).
+		Return([]*domain.User{{ID: "user-2"}}, 1, nil)
+
+	users, total, err := uc.GetBlocked(context.Background(), "user-1", 20, 0)
+
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, 1, total)
+}
@@ -13,11 +13,12 @@ import (
 // UseCase handles comment use cases
 type UseCase struct {
 	commentRepo domain.CommentRepository
+	events      domain.EventPublisher
 }
 
-// NewUseCase creates a new comment use case
-func NewUseCase(commentRepo domain.CommentRepository) *UseCase {
-	return &UseCase{commentRepo: commentRepo}
+// NewUseCase creates a new comment use case; events may be nil
+func NewUseCase(commentRepo domain.CommentRepository, events domain.EventPublisher) *UseCase {
+	return &UseCase{commentRepo: commentRepo, events: events}
 }
 
 // CreateRequest represents create comment request
@@ -42,6 +43,7 @@ func (uc *UseCase) Create(ctx context.Context, publicationID, authorID string, r
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
+	uc.emit(ctx, domain.EventCommentCreated, authorID, comment.ID)
 	return comment, nil
 }
 
@@ -90,6 +92,12 @@ func (uc *UseCase) Like(ctx context.Context, commentID, userID string) (bool, in
 		return false, 0, err
 	}
 
+	if liked {
+		uc.emit(ctx, domain.EventCommentLiked, userID, commentID)
+	} else {
+		uc.emit(ctx, domain.EventCommentUnliked, userID, commentID)
+	}
+
 	count, err := uc.commentRepo.GetLikesCount(ctx, commentID)
 	if err != nil {
 		return false, 0, err
@@ -98,8 +106,14 @@ func (uc *UseCase) Like(ctx context.Context, commentID, userID string) (bool, in
 	return liked, count, nil
 }
 
-// GetByPublication retrieves comments for publication
-func (uc *UseCase) GetByPublication(ctx context.Context, publicationID string, limit, offset int) ([]*domain.Comment, int, error) {
-	return uc.commentRepo.GetByPublication(ctx, publicationID, limit, offset)
+// GetByPublication retrieves comments for publication visible to viewer
+func (uc *UseCase) GetByPublication(ctx context.Context, publicationID string, viewerUserID *string, limit, offset int) ([]*domain.Comment, int, error) {
+	return uc.commentRepo.GetByPublication(ctx, publicationID, viewerUserID, limit, offset)
 }
 
+// emit publishes a domain event when an event publisher is configured
+func (uc *UseCase) emit(ctx context.Context, eventType domain.EventType, actorID, targetID string) {
+	if uc.events != nil {
+		uc.events.Publish(ctx, domain.NewEvent(eventType, actorID, targetID))
+	}
+}
@@ -30,7 +30,7 @@ func TestCreate_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	req := &CreateRequest{
 		Text: "Test comment",
@@ -57,7 +57,7 @@ func TestCreate_WithParent(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	parentID := "parent-123"
 	req := &CreateRequest{
@@ -84,7 +84,7 @@ func TestGet_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	comment := createTestComment()
 
@@ -104,7 +104,7 @@ func TestGet_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	commentRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
@@ -121,7 +121,7 @@ func TestUpdate_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	comment := createTestComment()
 
@@ -148,7 +148,7 @@ func TestUpdate_NotAuthor(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	comment := createTestComment()
 
@@ -168,7 +168,7 @@ func TestDelete_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	comment := createTestComment()
 
@@ -190,7 +190,7 @@ func TestDelete_NotAuthor(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	comment := createTestComment()
 
@@ -209,7 +209,7 @@ func TestLike_Toggle(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	commentRepo.EXPECT().
 		Like(gomock.Any(), "user-123", "comment-123").
@@ -231,18 +231,20 @@ func TestGetByPublication_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	commentRepo := mocks.NewMockCommentRepository(ctrl)
-	uc := NewUseCase(commentRepo)
+	uc := NewUseCase(commentRepo, nil)
 
 	comments := []*domain.Comment{
 		createTestComment(),
 		createTestComment(),
 	}
 
+	viewerUserID := "user-456"
+
 	commentRepo.EXPECT().
-		GetByPublication(gomock.Any(), "pub-123", 10, 0).
+		GetByPublication(gomock.Any(), "pub-123", &viewerUserID, 10, 0).
 		Return(comments, 2, nil)
 
-	result, total, err := uc.GetByPublication(context.Background(), "pub-123", 10, 0)
+	result, total, err := uc.GetByPublication(context.Background(), "pub-123", &viewerUserID, 10, 0)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -0,0 +1,362 @@
+package community
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotMember is returned when a non-member accesses members-only content
+	ErrNotMember = errors.New("not a community member")
+	// ErrInsufficientRole is returned when the actor's role does not allow the action
+	ErrInsufficientRole = errors.New("insufficient community role")
+	// ErrInviteRequired is returned when joining an invite-only community without an invite
+	ErrInviteRequired = errors.New("community is invite only")
+	// ErrOwnerCannotLeave is returned when the owner tries to leave or be removed
+	ErrOwnerCannotLeave = errors.New("community owner cannot leave")
+	// ErrJoinRequestNotFound is returned when there is no pending join request to act on
+	ErrJoinRequestNotFound = errors.New("join request not found")
+	// ErrAlreadyMember is returned when inviting a user who is already a member
+	ErrAlreadyMember = errors.New("already a community member")
+)
+
+// JoinStatus represents the outcome of joining a community
+type JoinStatus string
+
+const (
+	JoinStatusMember  JoinStatus = "member"
+	JoinStatusPending JoinStatus = "pending"
+)
+
+// UseCase handles community use cases
+type UseCase struct {
+	communityRepo   domain.CommunityRepository
+	publicationRepo domain.PublicationRepository
+	userRepo        domain.UserRepository
+	blockRepo       domain.BlockRepository
+	events          domain.EventPublisher
+}
+
+// NewUseCase creates a new community use case
+func NewUseCase(
+	communityRepo domain.CommunityRepository,
+	publicationRepo domain.PublicationRepository,
+	userRepo domain.UserRepository,
+	blockRepo domain.BlockRepository,
+	events domain.EventPublisher,
+) *UseCase {
+	return &UseCase{
+		communityRepo:   communityRepo,
+		publicationRepo: publicationRepo,
+		userRepo:        userRepo,
+		blockRepo:       blockRepo,
+		events:          events,
+	}
+}
+
+// CreateRequest represents create community request
+type CreateRequest struct {
+	Name        string                     `json:"name" validate:"required,max=100"`
+	Description *string                    `json:"description,omitempty" validate:"omitempty,max=1000"`
+	IconURL     *string                    `json:"icon_url,omitempty"`
+	JoinPolicy  domain.CommunityJoinPolicy `json:"join_policy,omitempty" validate:"omitempty,oneof=open request invite"`
+}
+
+// UpdateRequest represents update community request
+type UpdateRequest struct {
+	Name        *string                     `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string                     `json:"description,omitempty" validate:"omitempty,max=1000"`
+	IconURL     *string                     `json:"icon_url,omitempty"`
+	JoinPolicy  *domain.CommunityJoinPolicy `json:"join_policy,omitempty" validate:"omitempty,oneof=open request invite"`
+}
+
+// SetRoleRequest represents change member role request; ownership cannot be transferred this way
+type SetRoleRequest struct {
+	Role domain.CommunityRole `json:"role" validate:"required,oneof=member moderator"`
+}
+
+// Create creates a community owned by the user
+func (uc *UseCase) Create(ctx context.Context, ownerID string, req *CreateRequest) (*domain.Community, error) {
+	joinPolicy := req.JoinPolicy
+	if joinPolicy == "" {
+		joinPolicy = domain.CommunityJoinPolicyOpen
+	}
+
+	ownerRole := domain.CommunityRoleOwner
+	community := &domain.Community{
+		ID:           uuid.New().String(),
+		Name:         req.Name,
+		Description:  req.Description,
+		IconURL:      req.IconURL,
+		JoinPolicy:   joinPolicy,
+		OwnerID:      ownerID,
+		CreatedAt:    time.Now(),
+		MembersCount: 1,
+		ViewerRole:   &ownerRole,
+	}
+
+	if err := uc.communityRepo.Create(ctx, community); err != nil {
+		return nil, fmt.Errorf("failed to create community: %w", err)
+	}
+	return community, nil
+}
+
+// Get retrieves community with the viewer's role
+func (uc *UseCase) Get(ctx context.Context, id, viewerUserID string) (*domain.Community, error) {
+	return uc.communityRepo.GetByID(ctx, id, &viewerUserID)
+}
+
+// List retrieves communities matching query
+func (uc *UseCase) List(ctx context.Context, query, viewerUserID string, limit, offset int) ([]*domain.Community, int, error) {
+	return uc.communityRepo.List(ctx, query, &viewerUserID, limit, offset)
+}
+
+// Update updates community settings; only the owner may do it
+func (uc *UseCase) Update(ctx context.Context, id, actorID string, req *UpdateRequest) (*domain.Community, error) {
+	community, err := uc.communityRepo.GetByID(ctx, id, &actorID)
+	if err != nil {
+		return nil, err
+	}
+	if community.OwnerID != actorID {
+		return nil, ErrInsufficientRole
+	}
+
+	if req.Name != nil {
+		community.Name = *req.Name
+	}
+	if req.Description != nil {
+		community.Description = req.Description
+	}
+	if req.IconURL != nil {
+		community.IconURL = req.IconURL
+	}
+	if req.JoinPolicy != nil {
+		community.JoinPolicy = *req.JoinPolicy
+	}
+
+	if err := uc.communityRepo.Update(ctx, community); err != nil {
+		return nil, fmt.Errorf("failed to update community: %w", err)
+	}
+	return community, nil
+}
+
+// Delete deletes community with its publications; only the owner may do it
+func (uc *UseCase) Delete(ctx context.Context, id, actorID string) error {
+	community, err := uc.communityRepo.GetByID(ctx, id, &actorID)
+	if err != nil {
+		return err
+	}
+	if community.OwnerID != actorID {
+		return ErrInsufficientRole
+	}
+	return uc.communityRepo.Delete(ctx, id)
+}
+
+// GetFeed retrieves publications posted into the community; members only
+func (uc *UseCase) GetFeed(ctx context.Context, id, viewerUserID string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	if _, err := uc.requireRole(ctx, id, viewerUserID, false); err != nil {
+		return nil, 0, err
+	}
+	return uc.publicationRepo.GetByCommunity(ctx, id, &viewerUserID, limit, offset)
+}
+
+// Join joins the community according to its join policy
+func (uc *UseCase) Join(ctx context.Context, id, userID string) (JoinStatus, error) {
+	community, err := uc.communityRepo.GetByID(ctx, id, &userID)
+	if err != nil {
+		return "", err
+	}
+	if community.ViewerRole != nil {
+		return JoinStatusMember, nil
+	}
+
+	switch community.JoinPolicy {
+	case domain.CommunityJoinPolicyRequest:
+		if err := uc.communityRepo.CreateJoinRequest(ctx, id, userID); err != nil {
+			return "", err
+		}
+		return JoinStatusPending, nil
+	case domain.CommunityJoinPolicyInvite:
+		invited, err := uc.communityRepo.HasInvite(ctx, id, userID)
+		if err != nil {
+			return "", err
+		}
+		if !invited {
+			return "", ErrInviteRequired
+		}
+	}
+
+	if err := uc.communityRepo.AddMember(ctx, id, userID, domain.CommunityRoleMember); err != nil {
+		return "", err
+	}
+	return JoinStatusMember, nil
+}
+
+// Leave leaves the community or cancels a pending join request
+func (uc *UseCase) Leave(ctx context.Context, id, userID string) error {
+	community, err := uc.communityRepo.GetByID(ctx, id, &userID)
+	if err != nil {
+		return err
+	}
+	if community.OwnerID == userID {
+		return ErrOwnerCannotLeave
+	}
+	if _, err := uc.communityRepo.DeleteJoinRequest(ctx, id, userID); err != nil {
+		return err
+	}
+	return uc.communityRepo.RemoveMember(ctx, id, userID)
+}
+
+// GetMembers retrieves community members
+func (uc *UseCase) GetMembers(ctx context.Context, id string, limit, offset int) ([]*domain.CommunityMember, int, error) {
+	return uc.communityRepo.GetMembers(ctx, id, limit, offset)
+}
+
+// RemoveMember removes a member; moderators remove members, only the owner removes moderators
+func (uc *UseCase) RemoveMember(ctx context.Context, id, actorID, userID string) error {
+	actorRole, err := uc.requireRole(ctx, id, actorID, true)
+	if err != nil {
+		return err
+	}
+
+	role, err := uc.communityRepo.GetRole(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return nil
+	}
+	if *role == domain.CommunityRoleOwner {
+		return ErrOwnerCannotLeave
+	}
+	if *role == domain.CommunityRoleModerator && *actorRole != domain.CommunityRoleOwner {
+		return ErrInsufficientRole
+	}
+	return uc.communityRepo.RemoveMember(ctx, id, userID)
+}
+
+// SetRole promotes a member to moderator or demotes them; only the owner may do it
+func (uc *UseCase) SetRole(ctx context.Context, id, actorID, userID string, role domain.CommunityRole) error {
+	actorRole, err := uc.requireRole(ctx, id, actorID, true)
+	if err != nil {
+		return err
+	}
+	if *actorRole != domain.CommunityRoleOwner {
+		return ErrInsufficientRole
+	}
+	if actorID == userID {
+		return ErrOwnerCannotLeave
+	}
+	return uc.communityRepo.SetRole(ctx, id, userID, role)
+}
+
+// GetJoinRequests retrieves pending join requests; moderators only
+func (uc *UseCase) GetJoinRequests(ctx context.Context, id, actorID string, limit, offset int) ([]*domain.CommunityJoinRequest, int, error) {
+	if _, err := uc.requireRole(ctx, id, actorID, true); err != nil {
+		return nil, 0, err
+	}
+	return uc.communityRepo.GetJoinRequests(ctx, id, limit, offset)
+}
+
+// ApproveJoinRequest accepts a pending join request and notifies the user; moderators only
+func (uc *UseCase) ApproveJoinRequest(ctx context.Context, id, actorID, userID string) error {
+	if _, err := uc.requireRole(ctx, id, actorID, true); err != nil {
+		return err
+	}
+
+	deleted, err := uc.communityRepo.DeleteJoinRequest(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrJoinRequestNotFound
+	}
+	if err := uc.communityRepo.AddMember(ctx, id, userID, domain.CommunityRoleMember); err != nil {
+		return err
+	}
+
+	uc.emit(ctx, domain.EventCommunityJoinApproved, actorID, id, userID)
+	return nil
+}
+
+// RejectJoinRequest declines a pending join request; moderators only
+func (uc *UseCase) RejectJoinRequest(ctx context.Context, id, actorID, userID string) error {
+	if _, err := uc.requireRole(ctx, id, actorID, true); err != nil {
+		return err
+	}
+
+	deleted, err := uc.communityRepo.DeleteJoinRequest(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrJoinRequestNotFound
+	}
+	return nil
+}
+
+// Invite invites a user to the community and notifies them; moderators only
+func (uc *UseCase) Invite(ctx context.Context, id, actorID, userID string) error {
+	if _, err := uc.requireRole(ctx, id, actorID, true); err != nil {
+		return err
+	}
+	// Check if user exists
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	role, err := uc.communityRepo.GetRole(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if role != nil {
+		return ErrAlreadyMember
+	}
+
+	blocked, err := uc.blockRepo.IsBlockedEither(ctx, actorID, userID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return domain.ErrBlocked
+	}
+
+	if err := uc.communityRepo.CreateInvite(ctx, id, userID, actorID); err != nil {
+		return err
+	}
+
+	uc.emit(ctx, domain.EventCommunityInvited, actorID, id, userID)
+	return nil
+}
+
+// requireRole returns the user's role in the community, failing for non-members and,
+// when moderate is set, for members who cannot moderate
+func (uc *UseCase) requireRole(ctx context.Context, id, userID string, moderate bool) (*domain.CommunityRole, error) {
+	role, err := uc.communityRepo.GetRole(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrNotMember
+	}
+	if moderate && !role.CanModerate() {
+		return nil, ErrInsufficientRole
+	}
+	return role, nil
+}
+
+// emit publishes a domain event about an action of actorID in the community concerning userID
+// when an event publisher is configured
+func (uc *UseCase) emit(ctx context.Context, eventType domain.EventType, actorID, communityID, userID string) {
+	if uc.events != nil {
+		event := domain.NewEvent(eventType, actorID, communityID)
+		event.UserID = userID
+		uc.events.Publish(ctx, event)
+	}
+}
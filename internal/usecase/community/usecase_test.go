@@ -0,0 +1,172 @@
+package community
+
+import (
+	"context"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	communityRepo   *mocks.MockCommunityRepository
+	publicationRepo *mocks.MockPublicationRepository
+	userRepo        *mocks.MockUserRepository
+	blockRepo       *mocks.MockBlockRepository
+	events          *mocks.MockEventPublisher
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		communityRepo:   mocks.NewMockCommunityRepository(ctrl),
+		publicationRepo: mocks.NewMockPublicationRepository(ctrl),
+		userRepo:        mocks.NewMockUserRepository(ctrl),
+		blockRepo:       mocks.NewMockBlockRepository(ctrl),
+		events:          mocks.NewMockEventPublisher(ctrl),
+	}
+	uc := NewUseCase(deps.communityRepo, deps.publicationRepo, deps.userRepo, deps.blockRepo, deps.events)
+	return uc, deps
+}
+
+func rolePtr(role domain.CommunityRole) *domain.CommunityRole {
+	return &role
+}
+
+func TestJoin_OpenCommunityAddsMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetByID(gomock.Any(), "c-1", gomock.Any()).
+		Return(&domain.Community{ID: "c-1", JoinPolicy: domain.CommunityJoinPolicyOpen}, nil)
+	deps.communityRepo.EXPECT().AddMember(gomock.Any(), "c-1", "user-1", domain.CommunityRoleMember).Return(nil)
+
+	status, err := uc.Join(context.Background(), "c-1", "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, JoinStatusMember, status)
+}
+
+func TestJoin_RequestPolicyCreatesJoinRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetByID(gomock.Any(), "c-1", gomock.Any()).
+		Return(&domain.Community{ID: "c-1", JoinPolicy: domain.CommunityJoinPolicyRequest}, nil)
+	deps.communityRepo.EXPECT().CreateJoinRequest(gomock.Any(), "c-1", "user-1").Return(nil)
+
+	status, err := uc.Join(context.Background(), "c-1", "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, JoinStatusPending, status)
+}
+
+func TestJoin_InviteOnlyWithoutInvite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetByID(gomock.Any(), "c-1", gomock.Any()).
+		Return(&domain.Community{ID: "c-1", JoinPolicy: domain.CommunityJoinPolicyInvite}, nil)
+	deps.communityRepo.EXPECT().HasInvite(gomock.Any(), "c-1", "user-1").Return(false, nil)
+
+	_, err := uc.Join(context.Background(), "c-1", "user-1")
+
+	assert.ErrorIs(t, err, ErrInviteRequired)
+}
+
+func TestGetFeed_NonMemberForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "user-1").Return(nil, nil)
+
+	_, _, err := uc.GetFeed(context.Background(), "c-1", "user-1", 20, 0)
+
+	assert.ErrorIs(t, err, ErrNotMember)
+}
+
+func TestRemoveMember_ModeratorCannotRemoveModerator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "mod-1").Return(rolePtr(domain.CommunityRoleModerator), nil)
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "mod-2").Return(rolePtr(domain.CommunityRoleModerator), nil)
+
+	err := uc.RemoveMember(context.Background(), "c-1", "mod-1", "mod-2")
+
+	assert.ErrorIs(t, err, ErrInsufficientRole)
+}
+
+func TestApproveJoinRequest_AddsMemberAndNotifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "owner-1").Return(rolePtr(domain.CommunityRoleOwner), nil)
+	deps.communityRepo.EXPECT().DeleteJoinRequest(gomock.Any(), "c-1", "user-1").Return(true, nil)
+	deps.communityRepo.EXPECT().AddMember(gomock.Any(), "c-1", "user-1", domain.CommunityRoleMember).Return(nil)
+	deps.events.EXPECT().Publish(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *domain.Event) {
+			assert.Equal(t, domain.EventCommunityJoinApproved, event.Type)
+			assert.Equal(t, "owner-1", event.ActorID)
+			assert.Equal(t, "c-1", event.TargetID)
+			assert.Equal(t, "user-1", event.UserID)
+		})
+
+	err := uc.ApproveJoinRequest(context.Background(), "c-1", "owner-1", "user-1")
+
+	require.NoError(t, err)
+}
+
+func TestInvite_PublishesEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "mod-1").Return(rolePtr(domain.CommunityRoleModerator), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "user-1").Return(&domain.User{ID: "user-1"}, nil)
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "user-1").Return(nil, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "mod-1", "user-1").Return(false, nil)
+	deps.communityRepo.EXPECT().CreateInvite(gomock.Any(), "c-1", "user-1", "mod-1").Return(nil)
+	deps.events.EXPECT().Publish(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *domain.Event) {
+			assert.Equal(t, domain.EventCommunityInvited, event.Type)
+			assert.Equal(t, "mod-1", event.ActorID)
+			assert.Equal(t, "c-1", event.TargetID)
+			assert.Equal(t, "user-1", event.UserID)
+		})
+
+	err := uc.Invite(context.Background(), "c-1", "mod-1", "user-1")
+
+	require.NoError(t, err)
+}
+
+func TestInvite_ExistingMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "mod-1").Return(rolePtr(domain.CommunityRoleModerator), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "user-1").Return(&domain.User{ID: "user-1"}, nil)
+	deps.communityRepo.EXPECT().GetRole(gomock.Any(), "c-1", "user-1").Return(rolePtr(domain.CommunityRoleMember), nil)
+
+	err := uc.Invite(context.Background(), "c-1", "mod-1", "user-1")
+
+	assert.ErrorIs(t, err, ErrAlreadyMember)
+}
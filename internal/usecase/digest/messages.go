@@ -0,0 +1,72 @@
+package digest
+
+import "sense-backend/internal/domain"
+
+// DefaultLocale is used when the configured locale has no texts
+const DefaultLocale = "ru"
+
+// texts are the localised strings of a digest email
+type texts struct {
+	Subject          map[domain.DigestFrequency]string
+	Greeting         string
+	Intro            map[domain.DigestFrequency]string
+	Notifications    string
+	AllNotifications string
+	Publications     string
+	Likes            string
+	Comments         string
+	Followers        string
+	MoreFollowers    string // format of the number of followers not listed
+	Footer           string
+	Unsubscribe      string
+}
+
+var locales = map[string]texts{
+	"ru": {
+		Subject: map[domain.DigestFrequency]string{
+			domain.DigestFrequencyDaily:  "Sense: что произошло за день",
+			domain.DigestFrequencyWeekly: "Sense: что произошло за неделю",
+		},
+		Greeting: "Здравствуйте",
+		Intro: map[domain.DigestFrequency]string{
+			domain.DigestFrequencyDaily:  "Вот что произошло за последний день.",
+			domain.DigestFrequencyWeekly: "Вот что произошло за последнюю неделю.",
+		},
+		Notifications:    "Непрочитанные уведомления",
+		AllNotifications: "Все уведомления",
+		Publications:     "Популярное у авторов, на которых вы подписаны",
+		Likes:            "нравится",
+		Comments:         "комментариев",
+		Followers:        "Новые подписчики",
+		MoreFollowers:    "и ещё %d",
+		Footer:           "Вы получили это письмо, потому что подписаны на дайджест Sense. Частоту можно изменить в настройках уведомлений.",
+		Unsubscribe:      "Отписаться от дайджеста",
+	},
+	"en": {
+		Subject: map[domain.DigestFrequency]string{
+			domain.DigestFrequencyDaily:  "Sense: your daily digest",
+			domain.DigestFrequencyWeekly: "Sense: your weekly digest",
+		},
+		Greeting: "Hi",
+		Intro: map[domain.DigestFrequency]string{
+			domain.DigestFrequencyDaily:  "Here is what happened over the last day.",
+			domain.DigestFrequencyWeekly: "Here is what happened over the last week.",
+		},
+		Notifications:    "Unread notifications",
+		AllNotifications: "All notifications",
+		Publications:     "Popular from people you follow",
+		Likes:            "likes",
+		Comments:         "comments",
+		Followers:        "New followers",
+		MoreFollowers:    "and %d more",
+		Footer:           "You are receiving this email because you subscribed to the Sense digest. You can change how often it comes in your notification settings.",
+		Unsubscribe:      "Unsubscribe from the digest",
+	},
+}
+
+func localeTexts(locale string) texts {
+	if t, ok := locales[locale]; ok {
+		return t
+	}
+	return locales[DefaultLocale]
+}
@@ -0,0 +1,51 @@
+package digest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidToken is returned when an unsubscribe token is malformed or its signature does not match
+var ErrInvalidToken = errors.New("invalid unsubscribe token")
+
+// unsubscribePurpose separates unsubscribe signatures from other uses of the same key
+const unsubscribePurpose = "digest-unsubscribe:"
+
+// signUnsubscribeToken returns "<user id>.<signature>" in base64url. The token does not expire:
+// links in old emails keep working
+func signUnsubscribeToken(secret []byte, userID string) string {
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString([]byte(userID)) + "." + encoding.EncodeToString(unsubscribeMAC(secret, userID))
+}
+
+// verifyUnsubscribeToken returns the user ID the token was signed for
+func verifyUnsubscribeToken(secret []byte, token string) (string, error) {
+	encoding := base64.RawURLEncoding
+
+	encodedID, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	userID, err := encoding.DecodeString(encodedID)
+	if err != nil || len(userID) == 0 {
+		return "", ErrInvalidToken
+	}
+	mac, err := encoding.DecodeString(encodedMAC)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	if !hmac.Equal(mac, unsubscribeMAC(secret, string(userID))) {
+		return "", ErrInvalidToken
+	}
+	return string(userID), nil
+}
+
+func unsubscribeMAC(secret []byte, userID string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(unsubscribePurpose + userID))
+	return h.Sum(nil)
+}
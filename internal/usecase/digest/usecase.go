@@ -0,0 +1,314 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+const (
+	// maxNotifications is the number of unread notifications listed in a digest
+	maxNotifications = 10
+	// maxPublications is the number of top publications listed in a digest
+	maxPublications = 5
+	// publicationCandidates is the number of recent publications the top ones are picked from
+	publicationCandidates = 50
+	// maxFollowers is the number of new followers listed by name
+	maxFollowers = 5
+)
+
+// JobSend is the scheduled background job type sending the digests that are due
+const JobSend = "digest.send"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/digest.html.tmpl"))
+	textTemplate = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/digest.txt.tmpl"))
+)
+
+// Params configures the digest
+type Params struct {
+	// Locale is the language of the emails: ru or en
+	Locale string
+	// PublicURL is the base URL of links in the emails
+	PublicURL string
+	// Secret signs unsubscribe links
+	Secret []byte
+	// BatchSize is the number of recipients claimed at a time
+	BatchSize int
+}
+
+// UseCase handles email digest use cases
+type UseCase struct {
+	digestRepo       domain.DigestRepository
+	notificationRepo domain.NotificationRepository
+	settingsRepo     domain.NotificationSettingsRepository
+	publicationRepo  domain.PublicationRepository
+	mailer           domain.Mailer
+	params           Params
+}
+
+// NewUseCase creates a new email digest use case
+func NewUseCase(
+	digestRepo domain.DigestRepository,
+	notificationRepo domain.NotificationRepository,
+	settingsRepo domain.NotificationSettingsRepository,
+	publicationRepo domain.PublicationRepository,
+	mailer domain.Mailer,
+	params Params,
+) *UseCase {
+	if params.BatchSize <= 0 {
+		params.BatchSize = 100
+	}
+	params.PublicURL = strings.TrimRight(params.PublicURL, "/")
+	return &UseCase{
+		digestRepo:       digestRepo,
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		publicationRepo:  publicationRepo,
+		mailer:           mailer,
+		params:           params,
+	}
+}
+
+// SendDue sends the digests due at now and returns how many were sent. Digests with nothing to
+// tell are skipped but count as sent; failed ones are released and retried on the next run
+func (uc *UseCase) SendDue(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for {
+		recipients, err := uc.digestRepo.ClaimDue(ctx, now, uc.params.BatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim digests: %w", err)
+		}
+
+		var errs []error
+		for _, recipient := range recipients {
+			ok, err := uc.send(ctx, recipient, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("digest for user %s: %w", recipient.User.ID, err))
+				if err := uc.digestRepo.Release(ctx, recipient.User.ID, recipient.LastSentAt); err != nil {
+					errs = append(errs, fmt.Errorf("release digest of user %s: %w", recipient.User.ID, err))
+				}
+				continue
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		// Stopping on failures keeps released recipients from being claimed again in this run
+		if len(errs) > 0 {
+			return sent, errors.Join(errs...)
+		}
+		if len(recipients) < uc.params.BatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// Run is the JobSend handler; failed digests are released and go out on the next scheduled run
+func (uc *UseCase) Run(ctx context.Context, job *domain.Job) error {
+	_, err := uc.SendDue(ctx, time.Now())
+	return err
+}
+
+// build collects the recipient's activity since the previous digest, or over one period
+// when none was sent yet
+func (uc *UseCase) build(ctx context.Context, recipient *domain.DigestRecipient, settings *domain.NotificationSettings, now time.Time) (*domain.Digest, error) {
+	userID := recipient.User.ID
+	since := now.Add(-recipient.Frequency.Period())
+	if recipient.LastSentAt != nil && recipient.LastSentAt.After(since) {
+		since = *recipient.LastSentAt
+	}
+
+	digest := &domain.Digest{Recipient: recipient, Since: since}
+
+	notifications, _, err := uc.notificationRepo.GetByUser(ctx, userID, true, maxNotifications*2, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notifications {
+		// Follows are listed in their own section
+		if n.Type == domain.NotificationTypeFollow || !n.CreatedAt.After(since) || !settings.Enabled(n.Type, domain.NotificationChannelEmail) {
+			continue
+		}
+		digest.Notifications = append(digest.Notifications, n)
+		if len(digest.Notifications) == maxNotifications {
+			break
+		}
+	}
+
+	publications, err := uc.publicationRepo.GetByFollowing(ctx, userID, since, publicationCandidates)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(publications, func(i, j int) bool {
+		if publications[i].LikesCount != publications[j].LikesCount {
+			return publications[i].LikesCount > publications[j].LikesCount
+		}
+		return publications[i].CommentsCount > publications[j].CommentsCount
+	})
+	if len(publications) > maxPublications {
+		publications = publications[:maxPublications]
+	}
+	digest.Publications = publications
+
+	digest.NewFollowers, digest.NewFollowersTotal, err = uc.digestRepo.GetNewFollowers(ctx, userID, since, maxFollowers)
+	if err != nil {
+		return nil, err
+	}
+
+	return digest, nil
+}
+
+// render renders the digest email in the configured locale with times in the user's time zone
+func (uc *UseCase) render(digest *domain.Digest, location *time.Location) (*domain.Email, error) {
+	user := digest.Recipient.User
+	if user.Email == nil {
+		return nil, errors.New("user has no email")
+	}
+
+	t := localeTexts(uc.params.Locale)
+	unsubscribeURL := uc.UnsubscribeURL(user.ID)
+	view := &emailView{
+		Locale:           uc.params.Locale,
+		T:                t,
+		Subject:          t.Subject[digest.Recipient.Frequency],
+		Intro:            t.Intro[digest.Recipient.Frequency],
+		Username:         user.Username,
+		NotificationsURL: uc.params.PublicURL + "/notifications",
+		UnsubscribeURL:   unsubscribeURL,
+		MoreFollowers:    digest.NewFollowersTotal - len(digest.NewFollowers),
+	}
+	for _, n := range digest.Notifications {
+		view.Notifications = append(view.Notifications, notificationView{
+			Title:   n.Title,
+			Message: n.Message,
+			Time:    n.CreatedAt.In(location).Format("02.01.2006 15:04"),
+		})
+	}
+	for _, p := range digest.Publications {
+		view.Publications = append(view.Publications, publicationView{
+			Title:    p.Title,
+			URL:      uc.params.PublicURL + "/publication/" + url.PathEscape(p.ID),
+			Likes:    p.LikesCount,
+			Comments: p.CommentsCount,
+		})
+	}
+	for _, f := range digest.NewFollowers {
+		view.Followers = append(view.Followers, followerView{
+			Username: f.Username,
+			URL:      uc.params.PublicURL + "/profile/" + url.PathEscape(f.ID),
+		})
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("render html: %w", err)
+	}
+	if err := textTemplate.Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("render text: %w", err)
+	}
+
+	return &domain.Email{
+		To:      *user.Email,
+		Subject: view.Subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+		Headers: map[string]string{
+			// One-click unsubscribe (RFC 8058): mail clients POST to the link
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
+
+// UnsubscribeURL returns the signed link turning the user's digest off
+func (uc *UseCase) UnsubscribeURL(userID string) string {
+	return uc.params.PublicURL + "/unsubscribe?token=" + url.QueryEscape(signUnsubscribeToken(uc.params.Secret, userID))
+}
+
+// Unsubscribe turns the digest off for the user the token was signed for
+func (uc *UseCase) Unsubscribe(ctx context.Context, token string) error {
+	userID, err := verifyUnsubscribeToken(uc.params.Secret, token)
+	if err != nil {
+		return err
+	}
+
+	settings, err := uc.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if settings.Digest == domain.DigestFrequencyOff {
+		return nil
+	}
+	settings.Digest = domain.DigestFrequencyOff
+	return uc.settingsRepo.Update(ctx, settings)
+}
+
+// send builds and mails one digest, reporting whether there was anything to send
+func (uc *UseCase) send(ctx context.Context, recipient *domain.DigestRecipient, now time.Time) (bool, error) {
+	settings, err := uc.settingsRepo.Get(ctx, recipient.User.ID)
+	if err != nil {
+		return false, err
+	}
+
+	digest, err := uc.build(ctx, recipient, settings, now)
+	if err != nil {
+		return false, err
+	}
+	if digest.IsEmpty() {
+		return false, nil
+	}
+
+	email, err := uc.render(digest, settings.Location())
+	if err != nil {
+		return false, err
+	}
+
+	return true, uc.mailer.Send(ctx, email)
+}
+
+type emailView struct {
+	Locale           string
+	T                texts
+	Subject          string
+	Intro            string
+	Username         string
+	Notifications    []notificationView
+	NotificationsURL string
+	Publications     []publicationView
+	Followers        []followerView
+	MoreFollowers    int
+	UnsubscribeURL   string
+}
+
+type notificationView struct {
+	Title   string
+	Message string
+	Time    string
+}
+
+type publicationView struct {
+	Title    string
+	URL      string
+	Likes    int
+	Comments int
+}
+
+type followerView struct {
+	Username string
+	URL      string
+}
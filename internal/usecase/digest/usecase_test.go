@@ -0,0 +1,174 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	digestRepo       *mocks.MockDigestRepository
+	notificationRepo *mocks.MockNotificationRepository
+	settingsRepo     *mocks.MockNotificationSettingsRepository
+	publicationRepo  *mocks.MockPublicationRepository
+	mailer           *mocks.MockMailer
+}
+
+var testParams = Params{
+	Locale:    "en",
+	PublicURL: "https://sense.example/",
+	Secret:    []byte("secret"),
+	BatchSize: 2,
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		digestRepo:       mocks.NewMockDigestRepository(ctrl),
+		notificationRepo: mocks.NewMockNotificationRepository(ctrl),
+		settingsRepo:     mocks.NewMockNotificationSettingsRepository(ctrl),
+		publicationRepo:  mocks.NewMockPublicationRepository(ctrl),
+		mailer:           mocks.NewMockMailer(ctrl),
+	}
+	uc := NewUseCase(deps.digestRepo, deps.notificationRepo, deps.settingsRepo, deps.publicationRepo, deps.mailer, testParams)
+	return uc, deps
+}
+
+func recipient(id string) *domain.DigestRecipient {
+	email := id + "@example.com"
+	return &domain.DigestRecipient{
+		User:      &domain.User{ID: id, Username: id, Email: &email},
+		Frequency: domain.DigestFrequencyWeekly,
+	}
+}
+
+func publication(id, title string, likes int) *domain.PublicationWithLikeStatus {
+	return &domain.PublicationWithLikeStatus{Publication: domain.Publication{ID: id, Title: title, LikesCount: likes}}
+}
+
+func TestSendDue_SendsDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	alice := recipient("alice")
+	since := now.Add(-7 * 24 * time.Hour)
+
+	deps.digestRepo.EXPECT().ClaimDue(gomock.Any(), now, 2).Return([]*domain.DigestRecipient{alice}, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "alice").Return(domain.DefaultNotificationSettings("alice"), nil)
+	deps.notificationRepo.EXPECT().GetByUser(gomock.Any(), "alice", true, gomock.Any(), 0).Return([]*domain.Notification{
+		{Type: domain.NotificationTypeComment, Title: "New comment on your publication", CreatedAt: now.Add(-time.Hour)},
+		{Type: domain.NotificationTypeFollow, Title: "New follower", CreatedAt: now.Add(-time.Hour)},
+		{Type: domain.NotificationTypeLike, Title: "Old like", CreatedAt: since.Add(-time.Hour)},
+	}, 3, nil)
+	deps.publicationRepo.EXPECT().GetByFollowing(gomock.Any(), "alice", since, gomock.Any()).Return([]*domain.PublicationWithLikeStatus{
+		publication("p1", "Quiet one", 1),
+		publication("p2", "Popular one", 10),
+	}, nil)
+	deps.digestRepo.EXPECT().GetNewFollowers(gomock.Any(), "alice", since, gomock.Any()).
+		Return([]*domain.User{{ID: "bob", Username: "bob"}}, 3, nil)
+
+	var sent *domain.Email
+	deps.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, email *domain.Email) error {
+		sent = email
+		return nil
+	})
+
+	count, err := uc.SendDue(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NotNil(t, sent)
+	assert.Equal(t, "alice@example.com", sent.To)
+	assert.Equal(t, "Sense: your weekly digest", sent.Subject)
+	assert.Contains(t, sent.Text, "New comment on your publication")
+	assert.NotContains(t, sent.Text, "New follower\n")
+	assert.NotContains(t, sent.Text, "Old like")
+	assert.Less(t, strings.Index(sent.Text, "Popular one"), strings.Index(sent.Text, "Quiet one"))
+	assert.Contains(t, sent.Text, "bob and 2 more")
+	assert.Contains(t, sent.HTML, `href="https://sense.example/publication/p2"`)
+	assert.Equal(t, "<"+uc.UnsubscribeURL("alice")+">", sent.Headers["List-Unsubscribe"])
+}
+
+func TestSendDue_SkipsEmptyDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	now := time.Now()
+
+	deps.digestRepo.EXPECT().ClaimDue(gomock.Any(), now, 2).Return([]*domain.DigestRecipient{recipient("alice")}, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "alice").Return(domain.DefaultNotificationSettings("alice"), nil)
+	deps.notificationRepo.EXPECT().GetByUser(gomock.Any(), "alice", true, gomock.Any(), 0).Return(nil, 0, nil)
+	deps.publicationRepo.EXPECT().GetByFollowing(gomock.Any(), "alice", gomock.Any(), gomock.Any()).Return(nil, nil)
+	deps.digestRepo.EXPECT().GetNewFollowers(gomock.Any(), "alice", gomock.Any(), gomock.Any()).Return(nil, 0, nil)
+
+	count, err := uc.SendDue(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSendDue_ReleasesFailedDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	now := time.Now()
+	lastSent := now.Add(-8 * 24 * time.Hour)
+	alice, bob := recipient("alice"), recipient("bob")
+	alice.LastSentAt = &lastSent
+
+	// A full batch would normally be followed by another claim; the failure stops the run
+	deps.digestRepo.EXPECT().ClaimDue(gomock.Any(), now, 2).Return([]*domain.DigestRecipient{alice, bob}, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "alice").Return(nil, errors.New("db down"))
+	deps.digestRepo.EXPECT().Release(gomock.Any(), "alice", &lastSent).Return(nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "bob").Return(domain.DefaultNotificationSettings("bob"), nil)
+	deps.notificationRepo.EXPECT().GetByUser(gomock.Any(), "bob", true, gomock.Any(), 0).Return(nil, 0, nil)
+	deps.publicationRepo.EXPECT().GetByFollowing(gomock.Any(), "bob", gomock.Any(), gomock.Any()).
+		Return([]*domain.PublicationWithLikeStatus{publication("p1", "Post", 1)}, nil)
+	deps.digestRepo.EXPECT().GetNewFollowers(gomock.Any(), "bob", gomock.Any(), gomock.Any()).Return(nil, 0, nil)
+	deps.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	count, err := uc.SendDue(context.Background(), now)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "alice").Return(domain.DefaultNotificationSettings("alice"), nil)
+	deps.settingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, settings *domain.NotificationSettings) error {
+		assert.Equal(t, domain.DigestFrequencyOff, settings.Digest)
+		return nil
+	})
+
+	token := signUnsubscribeToken(testParams.Secret, "alice")
+	require.NoError(t, uc.Unsubscribe(context.Background(), token))
+}
+
+func TestUnsubscribe_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, _ := newTestUseCase(ctrl)
+
+	forged := signUnsubscribeToken([]byte("other secret"), "alice")
+	for _, token := range []string{"", "garbage", forged} {
+		assert.ErrorIs(t, uc.Unsubscribe(context.Background(), token), ErrInvalidToken)
+	}
+}
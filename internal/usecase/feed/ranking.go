@@ -0,0 +1,244 @@
+package feed
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+const (
+	// candidateWindow limits how old candidates for the ranked feed may be
+	candidateWindow = 14 * 24 * time.Hour
+	// candidateLimit limits how many candidates each source may produce
+	candidateLimit = 200
+)
+
+// Candidate represents a publication considered by the ranking pipeline
+type Candidate struct {
+	Publication *domain.PublicationWithLikeStatus
+	Sources     []string
+	Score       float64
+}
+
+// RankingContext holds per-request data shared by scorers
+type RankingContext struct {
+	UserID         string
+	Now            time.Time
+	AuthorAffinity map[string]int
+}
+
+// CandidateSource produces candidate publications for a viewer
+type CandidateSource interface {
+	Name() string
+	Candidates(ctx context.Context, userID string, since time.Time) ([]*domain.PublicationWithLikeStatus, error)
+}
+
+// Scorer scores a candidate, returning a value in [0, 1]
+type Scorer interface {
+	Name() string
+	Score(rc *RankingContext, c *Candidate) float64
+}
+
+// WeightedScorer is a scorer with its weight inside a strategy
+type WeightedScorer struct {
+	Scorer Scorer
+	Weight float64
+}
+
+// Strategy describes how the ranked feed is built
+type Strategy struct {
+	Name      string
+	Sources   []CandidateSource
+	Scorers   []WeightedScorer
+	Diversify bool
+}
+
+// followingSource yields recent publications of followed authors
+type followingSource struct {
+	publicationRepo domain.PublicationRepository
+}
+
+func (s *followingSource) Name() string { return "following" }
+
+func (s *followingSource) Candidates(ctx context.Context, userID string, since time.Time) ([]*domain.PublicationWithLikeStatus, error) {
+	return s.publicationRepo.GetByFollowing(ctx, userID, since, candidateLimit)
+}
+
+// tagsSource yields popular publications in tags the user engages with
+type tagsSource struct {
+	publicationRepo domain.PublicationRepository
+}
+
+func (s *tagsSource) Name() string { return "tags" }
+
+func (s *tagsSource) Candidates(ctx context.Context, userID string, since time.Time) ([]*domain.PublicationWithLikeStatus, error) {
+	return s.publicationRepo.GetPopularInUserTags(ctx, userID, since, candidateLimit)
+}
+
+// recommendationsSource yields publications from the recommendations table; like the other
+// sources it leaves out authors the user muted
+type recommendationsSource struct {
+	publicationRepo    domain.PublicationRepository
+	recommendationRepo domain.RecommendationRepository
+}
+
+func (s *recommendationsSource) Name() string { return "recommendations" }
+
+func (s *recommendationsSource) Candidates(ctx context.Context, userID string, _ time.Time) ([]*domain.PublicationWithLikeStatus, error) {
+	ids, err := s.recommendationRepo.GetPublicationIDs(ctx, userID, candidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	return s.publicationRepo.GetFeedByIDs(ctx, userID, ids)
+}
+
+// recencyScorer decays exponentially with publication age
+type recencyScorer struct {
+	halfLife time.Duration
+}
+
+func (s *recencyScorer) Name() string { return "recency" }
+
+func (s *recencyScorer) Score(rc *RankingContext, c *Candidate) float64 {
+	age := rc.Now.Sub(c.Publication.PublicationDate)
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, age.Hours()/s.halfLife.Hours())
+}
+
+// velocityScorer rewards likes and saves gathered per hour of age
+type velocityScorer struct{}
+
+func (s *velocityScorer) Name() string { return "velocity" }
+
+func (s *velocityScorer) Score(rc *RankingContext, c *Candidate) float64 {
+	ageHours := math.Max(rc.Now.Sub(c.Publication.PublicationDate).Hours(), 0)
+	engagement := float64(c.Publication.LikesCount + 2*c.Publication.SavedCount)
+	velocity := engagement / math.Pow(ageHours+2, 1.5)
+	return velocity / (1 + velocity)
+}
+
+// affinityScorer rewards authors the user liked before
+type affinityScorer struct{}
+
+func (s *affinityScorer) Name() string { return "affinity" }
+
+func (s *affinityScorer) Score(rc *RankingContext, c *Candidate) float64 {
+	likes := float64(rc.AuthorAffinity[c.Publication.AuthorID])
+	return likes / (likes + 3)
+}
+
+// defaultStrategies builds the strategies selectable through the feed API
+func defaultStrategies(publicationRepo domain.PublicationRepository, recommendationRepo domain.RecommendationRepository) map[string]*Strategy {
+	following := &followingSource{publicationRepo: publicationRepo}
+	tags := &tagsSource{publicationRepo: publicationRepo}
+	recommendations := &recommendationsSource{publicationRepo: publicationRepo, recommendationRepo: recommendationRepo}
+	allSources := []CandidateSource{following, tags, recommendations}
+
+	recency := &recencyScorer{halfLife: 24 * time.Hour}
+	velocity := &velocityScorer{}
+	affinity := &affinityScorer{}
+
+	strategies := []*Strategy{
+		{
+			Name:      StrategyBalanced,
+			Sources:   allSources,
+			Scorers:   []WeightedScorer{{recency, 1.0}, {velocity, 1.0}, {affinity, 1.0}},
+			Diversify: true,
+		},
+		{
+			Name:      StrategyFresh,
+			Sources:   allSources,
+			Scorers:   []WeightedScorer{{recency, 3.0}, {velocity, 0.5}, {affinity, 0.5}},
+			Diversify: true,
+		},
+		{
+			Name:      StrategyPopular,
+			Sources:   allSources,
+			Scorers:   []WeightedScorer{{recency, 0.5}, {velocity, 3.0}},
+			Diversify: true,
+		},
+		{
+			Name:      StrategySocial,
+			Sources:   []CandidateSource{following, recommendations},
+			Scorers:   []WeightedScorer{{recency, 1.0}, {affinity, 3.0}},
+			Diversify: true,
+		},
+	}
+
+	result := make(map[string]*Strategy, len(strategies))
+	for _, s := range strategies {
+		result[s.Name] = s
+	}
+	return result
+}
+
+// rank runs the strategy pipeline: gather, deduplicate, score, sort and diversify
+func rank(ctx context.Context, strategy *Strategy, rc *RankingContext) ([]*Candidate, error) {
+	since := rc.Now.Add(-candidateWindow)
+
+	byID := make(map[string]*Candidate)
+	var candidates []*Candidate
+	for _, source := range strategy.Sources {
+		publications, err := source.Candidates(ctx, rc.UserID, since)
+		if err != nil {
+			return nil, err
+		}
+		for _, pub := range publications {
+			if c, ok := byID[pub.ID]; ok {
+				c.Sources = append(c.Sources, source.Name())
+				continue
+			}
+			c := &Candidate{Publication: pub, Sources: []string{source.Name()}}
+			byID[pub.ID] = c
+			candidates = append(candidates, c)
+		}
+	}
+
+	for _, c := range candidates {
+		for _, ws := range strategy.Scorers {
+			c.Score += ws.Weight * ws.Scorer.Score(rc, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Publication.PublicationDate.After(candidates[j].Publication.PublicationDate)
+	})
+
+	if strategy.Diversify {
+		candidates = diversify(candidates)
+	}
+
+	return candidates, nil
+}
+
+// diversify reorders candidates so the same author does not appear twice in a row
+// whenever another author is available further down the list
+func diversify(candidates []*Candidate) []*Candidate {
+	result := make([]*Candidate, 0, len(candidates))
+	pending := append([]*Candidate(nil), candidates...)
+
+	for len(pending) > 0 {
+		pick := 0
+		if len(result) > 0 {
+			lastAuthor := result[len(result)-1].Publication.AuthorID
+			for i, c := range pending {
+				if c.Publication.AuthorID != lastAuthor {
+					pick = i
+					break
+				}
+			}
+		}
+		result = append(result, pending[pick])
+		pending = append(pending[:pick], pending[pick+1:]...)
+	}
+
+	return result
+}
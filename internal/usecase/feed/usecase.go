@@ -2,17 +2,38 @@ package feed
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"sense-backend/internal/domain"
 )
 
+// Feed ranking strategies
+const (
+	StrategyChronological = "chronological"
+	StrategyBalanced      = "balanced"
+	StrategyFresh         = "fresh"
+	StrategyPopular       = "popular"
+	StrategySocial        = "social"
+)
+
+// ErrUnknownStrategy is returned when requested feed strategy does not exist
+var ErrUnknownStrategy = errors.New("unknown feed strategy")
+
 // UseCase handles feed use cases
 type UseCase struct {
-	publicationRepo domain.PublicationRepository
+	publicationRepo    domain.PublicationRepository
+	recommendationRepo domain.RecommendationRepository
+	strategies         map[string]*Strategy
 }
 
 // NewUseCase creates a new feed use case
-func NewUseCase(publicationRepo domain.PublicationRepository) *UseCase {
-	return &UseCase{publicationRepo: publicationRepo}
+func NewUseCase(publicationRepo domain.PublicationRepository, recommendationRepo domain.RecommendationRepository) *UseCase {
+	return &UseCase{
+		publicationRepo:    publicationRepo,
+		recommendationRepo: recommendationRepo,
+		strategies:         defaultStrategies(publicationRepo, recommendationRepo),
+	}
 }
 
 // GetFeed retrieves feed with filters and like status for viewer
@@ -20,6 +41,51 @@ func (uc *UseCase) GetFeed(ctx context.Context, userID *string, filters *domain.
 	return uc.publicationRepo.GetFeed(ctx, userID, filters, limit, offset)
 }
 
+// GetRankedFeed retrieves the personalised "For you" feed built by the given strategy
+func (uc *UseCase) GetRankedFeed(ctx context.Context, userID, strategyName string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	if strategyName == "" {
+		strategyName = StrategyBalanced
+	}
+	if strategyName == StrategyChronological {
+		return uc.publicationRepo.GetFeed(ctx, &userID, &domain.FeedFilters{}, limit, offset)
+	}
+
+	strategy, ok := uc.strategies[strategyName]
+	if !ok {
+		return nil, 0, ErrUnknownStrategy
+	}
+
+	affinity, err := uc.publicationRepo.GetAuthorAffinity(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ranked, err := rank(ctx, strategy, &RankingContext{
+		UserID:         userID,
+		Now:            time.Now(),
+		AuthorAffinity: affinity,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(ranked)
+	if offset >= total {
+		return []*domain.PublicationWithLikeStatus{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	publications := make([]*domain.PublicationWithLikeStatus, 0, end-offset)
+	for _, c := range ranked[offset:end] {
+		publications = append(publications, c.Publication)
+	}
+
+	return publications, total, nil
+}
+
 // GetUserFeed retrieves publications by user with like status for viewer
 func (uc *UseCase) GetUserFeed(ctx context.Context, authorID string, viewerUserID *string, filters *domain.PublicationFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
 	return uc.publicationRepo.GetByAuthor(ctx, authorID, viewerUserID, filters, limit, offset)
@@ -29,4 +95,3 @@ func (uc *UseCase) GetUserFeed(ctx context.Context, authorID string, viewerUserI
 func (uc *UseCase) GetSavedFeed(ctx context.Context, userID string, filters *domain.PublicationFilters, limit, offset int) ([]*domain.SavedPublicationWithLikeStatus, int, error) {
 	return uc.publicationRepo.GetSaved(ctx, userID, filters, limit, offset)
 }
-
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -46,7 +47,8 @@ func TestGetFeed_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
-	uc := NewUseCase(publicationRepo)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
 
 	userID := testUserID
 	filters := &domain.FeedFilters{
@@ -74,7 +76,8 @@ func TestGetFeed_Empty(t *testing.T) {
 	defer ctrl.Finish()
 
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
-	uc := NewUseCase(publicationRepo)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
 
 	userID := testUserID
 	filters := &domain.FeedFilters{}
@@ -95,7 +98,8 @@ func TestGetUserFeed_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
-	uc := NewUseCase(publicationRepo)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
 
 	viewerUserID := testUserID
 	filters := &domain.PublicationFilters{
@@ -122,7 +126,8 @@ func TestGetSavedFeed_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
-	uc := NewUseCase(publicationRepo)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
 
 	filters := &domain.PublicationFilters{}
 
@@ -153,7 +158,8 @@ func TestGetFeed_WithFilters(t *testing.T) {
 	defer ctrl.Finish()
 
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
-	uc := NewUseCase(publicationRepo)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
 
 	userID := testUserID
 	dateFrom := time.Now().Add(-24 * time.Hour)
@@ -183,3 +189,163 @@ func TestGetFeed_WithFilters(t *testing.T) {
 	assert.Equal(t, 1, total)
 }
 
+func createRankedCandidate(id, authorID string, age time.Duration, likes int) *domain.PublicationWithLikeStatus {
+	pub := createTestPublicationWithLikeStatus()
+	pub.ID = id
+	pub.AuthorID = authorID
+	pub.PublicationDate = time.Now().Add(-age)
+	pub.LikesCount = likes
+	return pub
+}
+
+func TestGetRankedFeed_MergesSourcesAndDiversifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
+
+	following := []*domain.PublicationWithLikeStatus{
+		createRankedCandidate("pub-1", "author-a", time.Hour, 10),
+		createRankedCandidate("pub-2", "author-a", 2*time.Hour, 8),
+	}
+	tagged := []*domain.PublicationWithLikeStatus{
+		createRankedCandidate("pub-2", "author-a", 2*time.Hour, 8),
+		createRankedCandidate("pub-3", "author-b", 72*time.Hour, 0),
+	}
+
+	publicationRepo.EXPECT().GetAuthorAffinity(gomock.Any(), testUserID).Return(map[string]int{"author-a": 5}, nil)
+	publicationRepo.EXPECT().GetByFollowing(gomock.Any(), testUserID, gomock.Any(), gomock.Any()).Return(following, nil)
+	publicationRepo.EXPECT().GetPopularInUserTags(gomock.Any(), testUserID, gomock.Any(), gomock.Any()).Return(tagged, nil)
+	recommendationRepo.EXPECT().GetPublicationIDs(gomock.Any(), testUserID, gomock.Any()).Return([]string{}, nil)
+	publicationRepo.EXPECT().GetFeedByIDs(gomock.Any(), testUserID, []string{}).Return(nil, nil)
+
+	result, total, err := uc.GetRankedFeed(context.Background(), testUserID, StrategyBalanced, 10, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, result, 3)
+	assert.Equal(t, "pub-1", result[0].ID)
+	assert.Equal(t, "pub-3", result[1].ID)
+	assert.Equal(t, "pub-2", result[2].ID)
+}
+
+func TestGetRankedFeed_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
+
+	following := []*domain.PublicationWithLikeStatus{
+		createRankedCandidate("pub-1", "author-a", time.Hour, 0),
+		createRankedCandidate("pub-2", "author-b", 2*time.Hour, 0),
+	}
+
+	publicationRepo.EXPECT().GetAuthorAffinity(gomock.Any(), testUserID).Return(map[string]int{}, nil)
+	publicationRepo.EXPECT().GetByFollowing(gomock.Any(), testUserID, gomock.Any(), gomock.Any()).Return(following, nil)
+	recommendationRepo.EXPECT().GetPublicationIDs(gomock.Any(), testUserID, gomock.Any()).Return(nil, nil)
+	publicationRepo.EXPECT().GetFeedByIDs(gomock.Any(), testUserID, gomock.Nil()).Return(nil, nil)
+
+	result, total, err := uc.GetRankedFeed(context.Background(), testUserID, StrategySocial, 1, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, result, 1)
+	assert.Equal(t, "pub-2", result[0].ID)
+}
+
+func TestGetRankedFeed_RecommendationsLeaveOutMutedAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
+
+	// The recommender does not know about mutes and still suggests the muted author's post
+	recommended := []*domain.PublicationWithLikeStatus{
+		createRankedCandidate("pub-muted", "author-muted", time.Hour, 5),
+		createRankedCandidate("pub-1", "author-a", time.Hour, 1),
+	}
+	muted := map[string]bool{"author-muted": true}
+
+	publicationRepo.EXPECT().GetAuthorAffinity(gomock.Any(), testUserID).Return(map[string]int{}, nil)
+	publicationRepo.EXPECT().GetByFollowing(gomock.Any(), testUserID, gomock.Any(), gomock.Any()).Return(nil, nil)
+	publicationRepo.EXPECT().GetPopularInUserTags(gomock.Any(), testUserID, gomock.Any(), gomock.Any()).Return(nil, nil)
+	recommendationRepo.EXPECT().GetPublicationIDs(gomock.Any(), testUserID, gomock.Any()).Return([]string{"pub-muted", "pub-1"}, nil)
+	// Stands in for notMutedCondition, which GetByIDs does not apply
+	publicationRepo.EXPECT().GetFeedByIDs(gomock.Any(), testUserID, []string{"pub-muted", "pub-1"}).
+		DoAndReturn(func(_ context.Context, _ string, _ []string) ([]*domain.PublicationWithLikeStatus, error) {
+			var visible []*domain.PublicationWithLikeStatus
+			for _, publication := range recommended {
+				if !muted[publication.AuthorID] {
+					visible = append(visible, publication)
+				}
+			}
+			return visible, nil
+		})
+
+	result, total, err := uc.GetRankedFeed(context.Background(), testUserID, StrategyBalanced, 10, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, result, 1)
+	assert.Equal(t, "pub-1", result[0].ID)
+}
+
+func TestGetRankedFeed_Chronological(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
+
+	userID := testUserID
+	publicationRepo.EXPECT().
+		GetFeed(gomock.Any(), &userID, &domain.FeedFilters{}, 10, 0).
+		Return([]*domain.PublicationWithLikeStatus{createTestPublicationWithLikeStatus()}, 1, nil)
+
+	result, total, err := uc.GetRankedFeed(context.Background(), testUserID, StrategyChronological, 10, 0)
+
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 1, total)
+}
+
+func TestGetRankedFeed_UnknownStrategy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	recommendationRepo := mocks.NewMockRecommendationRepository(ctrl)
+	uc := NewUseCase(publicationRepo, recommendationRepo)
+
+	result, total, err := uc.GetRankedFeed(context.Background(), testUserID, "random", 10, 0)
+
+	assert.True(t, errors.Is(err, ErrUnknownStrategy))
+	assert.Nil(t, result)
+	assert.Equal(t, 0, total)
+}
+
+func TestDiversify_NoSameAuthorInARow(t *testing.T) {
+	candidates := []*Candidate{
+		{Publication: createRankedCandidate("pub-1", "author-a", 0, 0)},
+		{Publication: createRankedCandidate("pub-2", "author-a", 0, 0)},
+		{Publication: createRankedCandidate("pub-3", "author-a", 0, 0)},
+		{Publication: createRankedCandidate("pub-4", "author-b", 0, 0)},
+		{Publication: createRankedCandidate("pub-5", "author-c", 0, 0)},
+	}
+
+	result := diversify(candidates)
+
+	require.Len(t, result, 5)
+	ids := make([]string, 0, len(result))
+	for _, c := range result {
+		ids = append(ids, c.Publication.ID)
+	}
+	assert.Equal(t, []string{"pub-1", "pub-4", "pub-2", "pub-5", "pub-3"}, ids)
+}
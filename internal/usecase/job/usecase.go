@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/access"
+)
+
+// UseCase handles background job administration
+type UseCase struct {
+	jobRepo  domain.JobRepository
+	userRepo domain.UserRepository
+}
+
+// NewUseCase creates a new job use case
+func NewUseCase(jobRepo domain.JobRepository, userRepo domain.UserRepository) *UseCase {
+	return &UseCase{
+		jobRepo:  jobRepo,
+		userRepo: userRepo,
+	}
+}
+
+// List retrieves jobs matching filters, newest first
+func (uc *UseCase) List(ctx context.Context, userID string, filters *domain.JobFilters, limit, offset int) ([]*domain.Job, int, error) {
+	if err := access.RequireSuperUser(ctx, uc.userRepo, userID); err != nil {
+		return nil, 0, err
+	}
+	return uc.jobRepo.List(ctx, filters, limit, offset)
+}
+
+// Get retrieves a job
+func (uc *UseCase) Get(ctx context.Context, userID, jobID string) (*domain.Job, error) {
+	if err := access.RequireSuperUser(ctx, uc.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return uc.jobRepo.GetByID(ctx, jobID)
+}
+
+// Stats counts jobs by queue and status
+func (uc *UseCase) Stats(ctx context.Context, userID string) ([]*domain.JobQueueStats, error) {
+	if err := access.RequireSuperUser(ctx, uc.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return uc.jobRepo.Stats(ctx)
+}
+
+// Retry queues a failed job to run now with a fresh set of attempts
+func (uc *UseCase) Retry(ctx context.Context, userID, jobID string) (*domain.Job, error) {
+	if err := access.RequireSuperUser(ctx, uc.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return uc.jobRepo.Retry(ctx, jobID)
+}
@@ -0,0 +1,76 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	jobRepo  *mocks.MockJobRepository
+	userRepo *mocks.MockUserRepository
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		jobRepo:  mocks.NewMockJobRepository(ctrl),
+		userRepo: mocks.NewMockUserRepository(ctrl),
+	}
+	return NewUseCase(deps.jobRepo, deps.userRepo), deps
+}
+
+func TestList_RequiresSuperUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "alice").Return(&domain.User{ID: "alice", Role: domain.UserRoleExpert}, nil)
+
+	_, _, err := uc.List(context.Background(), "alice", &domain.JobFilters{}, 20, 0)
+
+	assert.ErrorIs(t, err, domain.ErrSuperUserRequired)
+}
+
+func TestList_FiltersFailedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	failed := domain.JobFailed
+	filters := &domain.JobFilters{Status: &failed}
+
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "root").Return(&domain.User{ID: "root", Role: domain.UserRoleSuper}, nil)
+	deps.jobRepo.EXPECT().List(gomock.Any(), filters, 20, 0).
+		Return([]*domain.Job{{ID: "job-1", Status: domain.JobFailed}}, 1, nil)
+
+	jobs, total, err := uc.List(context.Background(), "root", filters, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "job-1", jobs[0].ID)
+}
+
+func TestRetry_QueuesFailedJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "root").Return(&domain.User{ID: "root", Role: domain.UserRoleSuper}, nil).Times(2)
+	gomock.InOrder(
+		deps.jobRepo.EXPECT().Retry(gomock.Any(), "job-1").Return(&domain.Job{ID: "job-1", Status: domain.JobQueued}, nil),
+		deps.jobRepo.EXPECT().Retry(gomock.Any(), "job-2").Return(nil, domain.ErrJobNotFailed),
+	)
+
+	job, err := uc.Retry(context.Background(), "root", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobQueued, job.Status)
+
+	_, err = uc.Retry(context.Background(), "root", "job-2")
+	assert.ErrorIs(t, err, domain.ErrJobNotFailed)
+}
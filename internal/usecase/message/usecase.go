@@ -0,0 +1,308 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// MaxGroupMembers is the largest number of members of a group conversation, creator included
+const MaxGroupMembers = 32
+
+var (
+	// ErrConversationNotFound is returned when the conversation does not exist or the user is not its member
+	ErrConversationNotFound = errors.New("conversation not found")
+	// ErrSelfConversation is returned when a conversation has no members besides its creator
+	ErrSelfConversation = errors.New("cannot start a conversation with yourself")
+	// ErrTooManyMembers is returned when a group conversation exceeds MaxGroupMembers
+	ErrTooManyMembers = errors.New("too many conversation members")
+	// ErrEmptyMessage is returned when a message has neither text nor attachments
+	ErrEmptyMessage = errors.New("message is empty")
+	// ErrNotGroup is returned when leaving a one-to-one conversation
+	ErrNotGroup = errors.New("not a group conversation")
+	// ErrMediaNotOwned is returned when an attachment does not belong to the sender
+	ErrMediaNotOwned = errors.New("media not found or not owned")
+)
+
+// UseCase handles direct message use cases
+type UseCase struct {
+	messageRepo domain.MessageRepository
+	userRepo    domain.UserRepository
+	blockRepo   domain.BlockRepository
+	mediaRepo   domain.MediaRepository
+	publisher   domain.RealtimePublisher
+}
+
+// NewUseCase creates a new message use case; publisher may be nil when no realtime channel is configured
+func NewUseCase(
+	messageRepo domain.MessageRepository,
+	userRepo domain.UserRepository,
+	blockRepo domain.BlockRepository,
+	mediaRepo domain.MediaRepository,
+	publisher domain.RealtimePublisher,
+) *UseCase {
+	return &UseCase{
+		messageRepo: messageRepo,
+		userRepo:    userRepo,
+		blockRepo:   blockRepo,
+		mediaRepo:   mediaRepo,
+		publisher:   publisher,
+	}
+}
+
+// CreateConversationRequest represents start conversation request; a single member
+// without a title starts (or reopens) a one-to-one conversation
+type CreateConversationRequest struct {
+	MemberIDs []string `json:"member_ids" validate:"required,min=1,dive,required"`
+	Title     *string  `json:"title,omitempty" validate:"omitempty,max=100"`
+}
+
+// SendRequest represents send message request
+type SendRequest struct {
+	Content  *string  `json:"content,omitempty" validate:"omitempty,max=4000"`
+	MediaIDs []string `json:"media_ids,omitempty" validate:"max=10"`
+}
+
+// SettingsRequest represents update message settings request
+type SettingsRequest struct {
+	FromFollowingOnly *bool `json:"from_following_only,omitempty"`
+}
+
+// CreateConversation starts a conversation between the creator and members
+func (uc *UseCase) CreateConversation(ctx context.Context, creatorID string, req *CreateConversationRequest) (*domain.Conversation, error) {
+	memberIDs := []string{creatorID}
+	seen := map[string]bool{creatorID: true}
+	for _, id := range req.MemberIDs {
+		if !seen[id] {
+			seen[id] = true
+			memberIDs = append(memberIDs, id)
+		}
+	}
+	if len(memberIDs) == 1 {
+		return nil, ErrSelfConversation
+	}
+	if len(memberIDs) > MaxGroupMembers {
+		return nil, ErrTooManyMembers
+	}
+
+	isGroup := len(memberIDs) > 2 || req.Title != nil
+	if !isGroup {
+		existing, err := uc.messageRepo.FindDirect(ctx, creatorID, memberIDs[1])
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	for _, memberID := range memberIDs[1:] {
+		// Check if user exists
+		if _, err := uc.userRepo.GetByID(ctx, memberID); err != nil {
+			return nil, err
+		}
+		if err := uc.canMessage(ctx, creatorID, memberID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	conversation := &domain.Conversation{
+		ID:        uuid.New().String(),
+		IsGroup:   isGroup,
+		Title:     req.Title,
+		CreatedBy: creatorID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.messageRepo.CreateConversation(ctx, conversation, memberIDs); err != nil {
+		return nil, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	return uc.messageRepo.GetConversation(ctx, conversation.ID, creatorID)
+}
+
+// GetConversations retrieves user's conversations with unread counts
+func (uc *UseCase) GetConversations(ctx context.Context, userID string, limit, offset int) ([]*domain.Conversation, int, error) {
+	return uc.messageRepo.GetConversations(ctx, userID, limit, offset)
+}
+
+// GetConversation retrieves a conversation of the user
+func (uc *UseCase) GetConversation(ctx context.Context, id, userID string) (*domain.Conversation, error) {
+	conversation, err := uc.messageRepo.GetConversation(ctx, id, userID)
+	if err != nil {
+		return nil, ErrConversationNotFound
+	}
+	return conversation, nil
+}
+
+// GetMessages retrieves conversation messages, newest first
+func (uc *UseCase) GetMessages(ctx context.Context, id, userID string, limit, offset int) ([]*domain.Message, int, error) {
+	member, err := uc.messageRepo.IsMember(ctx, id, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !member {
+		return nil, 0, ErrConversationNotFound
+	}
+	return uc.messageRepo.GetMessages(ctx, id, limit, offset)
+}
+
+// Send sends a message and delivers it to the other members in real time
+func (uc *UseCase) Send(ctx context.Context, id, senderID string, req *SendRequest) (*domain.Message, error) {
+	if (req.Content == nil || *req.Content == "") && len(req.MediaIDs) == 0 {
+		return nil, ErrEmptyMessage
+	}
+
+	conversation, err := uc.messageRepo.GetConversation(ctx, id, senderID)
+	if err != nil {
+		return nil, ErrConversationNotFound
+	}
+
+	// A block or a changed setting stops a one-to-one conversation that is already open
+	if !conversation.IsGroup {
+		for _, member := range conversation.Members {
+			if member.ID == senderID {
+				continue
+			}
+			if err := uc.canMessage(ctx, senderID, member.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Validate media ownership
+	for _, mediaID := range req.MediaIDs {
+		owned, err := uc.mediaRepo.CheckOwnership(ctx, mediaID, senderID)
+		if err != nil || !owned {
+			return nil, ErrMediaNotOwned
+		}
+	}
+
+	mediaIDs := req.MediaIDs
+	if mediaIDs == nil {
+		mediaIDs = []string{}
+	}
+	message := &domain.Message{
+		ID:             uuid.New().String(),
+		ConversationID: id,
+		SenderID:       senderID,
+		Content:        req.Content,
+		MediaIDs:       mediaIDs,
+		CreatedAt:      time.Now(),
+		ReadBy:         []string{},
+	}
+	if err := uc.messageRepo.CreateMessage(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	uc.publish(ctx, conversation, senderID, &domain.RealtimeEvent{
+		Type: domain.RealtimeEventMessage,
+		Data: message,
+	})
+	return message, nil
+}
+
+// MarkRead marks the conversation read up to now and tells the other members
+func (uc *UseCase) MarkRead(ctx context.Context, id, userID string) error {
+	conversation, err := uc.messageRepo.GetConversation(ctx, id, userID)
+	if err != nil {
+		return ErrConversationNotFound
+	}
+
+	readAt := time.Now()
+	if err := uc.messageRepo.MarkRead(ctx, id, userID, readAt); err != nil {
+		return err
+	}
+
+	uc.publish(ctx, conversation, userID, &domain.RealtimeEvent{
+		Type: domain.RealtimeEventMessageRead,
+		Data: map[string]interface{}{
+			"conversation_id": id,
+			"user_id":         userID,
+			"read_at":         readAt,
+		},
+	})
+	return nil
+}
+
+// Leave leaves a group conversation
+func (uc *UseCase) Leave(ctx context.Context, id, userID string) error {
+	conversation, err := uc.messageRepo.GetConversation(ctx, id, userID)
+	if err != nil {
+		return ErrConversationNotFound
+	}
+	if !conversation.IsGroup {
+		return ErrNotGroup
+	}
+	return uc.messageRepo.RemoveMember(ctx, id, userID)
+}
+
+// GetSettings retrieves user's message settings
+func (uc *UseCase) GetSettings(ctx context.Context, userID string) (*domain.MessageSettings, error) {
+	return uc.messageRepo.GetSettings(ctx, userID)
+}
+
+// UpdateSettings updates user's message settings
+func (uc *UseCase) UpdateSettings(ctx context.Context, userID string, req *SettingsRequest) (*domain.MessageSettings, error) {
+	settings, err := uc.messageRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FromFollowingOnly != nil {
+		settings.FromFollowingOnly = *req.FromFollowingOnly
+	}
+
+	if err := uc.messageRepo.UpdateSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update message settings: %w", err)
+	}
+	return settings, nil
+}
+
+// canMessage checks that neither user blocked the other and that the recipient
+// accepts messages from the sender
+func (uc *UseCase) canMessage(ctx context.Context, senderID, recipientID string) error {
+	blocked, err := uc.blockRepo.IsBlockedEither(ctx, senderID, recipientID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return domain.ErrBlocked
+	}
+
+	settings, err := uc.messageRepo.GetSettings(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	if !settings.FromFollowingOnly {
+		return nil
+	}
+
+	following, err := uc.userRepo.IsFollowing(ctx, recipientID, senderID)
+	if err != nil {
+		return err
+	}
+	if !following {
+		return domain.ErrMessagingNotAllowed
+	}
+	return nil
+}
+
+// publish delivers event to conversation members except the actor; delivery is best effort
+// and clients without a live connection pick the change up from the REST endpoints
+func (uc *UseCase) publish(ctx context.Context, conversation *domain.Conversation, actorID string, event *domain.RealtimeEvent) {
+	if uc.publisher == nil {
+		return
+	}
+	for _, member := range conversation.Members {
+		if member.ID != actorID {
+			_ = uc.publisher.Publish(ctx, member.ID, event)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	messageRepo *mocks.MockMessageRepository
+	userRepo    *mocks.MockUserRepository
+	blockRepo   *mocks.MockBlockRepository
+	mediaRepo   *mocks.MockMediaRepository
+	publisher   *mocks.MockRealtimePublisher
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		messageRepo: mocks.NewMockMessageRepository(ctrl),
+		userRepo:    mocks.NewMockUserRepository(ctrl),
+		blockRepo:   mocks.NewMockBlockRepository(ctrl),
+		mediaRepo:   mocks.NewMockMediaRepository(ctrl),
+		publisher:   mocks.NewMockRealtimePublisher(ctrl),
+	}
+	uc := NewUseCase(deps.messageRepo, deps.userRepo, deps.blockRepo, deps.mediaRepo, deps.publisher)
+	return uc, deps
+}
+
+func directConversation() *domain.Conversation {
+	return &domain.Conversation{
+		ID: "conv-1",
+		Members: []*domain.User{
+			{ID: "alice"},
+			{ID: "bob"},
+		},
+	}
+}
+
+func TestCreateConversation_ReturnsExistingDirect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	existing := directConversation()
+	deps.messageRepo.EXPECT().FindDirect(gomock.Any(), "alice", "bob").Return(existing, nil)
+
+	conversation, err := uc.CreateConversation(context.Background(), "alice", &CreateConversationRequest{
+		MemberIDs: []string{"bob", "alice", "bob"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, existing, conversation)
+}
+
+func TestCreateConversation_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, _ := newTestUseCase(ctrl)
+
+	_, err := uc.CreateConversation(context.Background(), "alice", &CreateConversationRequest{
+		MemberIDs: []string{"alice"},
+	})
+
+	assert.ErrorIs(t, err, ErrSelfConversation)
+}
+
+func TestCreateConversation_RecipientAcceptsFollowingOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.messageRepo.EXPECT().FindDirect(gomock.Any(), "alice", "bob").Return(nil, nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "bob").Return(&domain.User{ID: "bob"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(false, nil)
+	deps.messageRepo.EXPECT().GetSettings(gomock.Any(), "bob").
+		Return(&domain.MessageSettings{UserID: "bob", FromFollowingOnly: true}, nil)
+	deps.userRepo.EXPECT().IsFollowing(gomock.Any(), "bob", "alice").Return(false, nil)
+
+	_, err := uc.CreateConversation(context.Background(), "alice", &CreateConversationRequest{
+		MemberIDs: []string{"bob"},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrMessagingNotAllowed)
+}
+
+func TestSend_BlockedInDirectConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.messageRepo.EXPECT().GetConversation(gomock.Any(), "conv-1", "alice").Return(directConversation(), nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(true, nil)
+
+	_, err := uc.Send(context.Background(), "conv-1", "alice", &SendRequest{Content: stringPtr("hi")})
+
+	assert.ErrorIs(t, err, domain.ErrBlocked)
+}
+
+func TestSend_DeliversToOtherMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.messageRepo.EXPECT().GetConversation(gomock.Any(), "conv-1", "alice").Return(directConversation(), nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(false, nil)
+	deps.messageRepo.EXPECT().GetSettings(gomock.Any(), "bob").Return(&domain.MessageSettings{UserID: "bob"}, nil)
+	deps.mediaRepo.EXPECT().CheckOwnership(gomock.Any(), "media-1", "alice").Return(true, nil)
+	deps.messageRepo.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).Return(nil)
+	deps.publisher.EXPECT().Publish(gomock.Any(), "bob", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, event *domain.RealtimeEvent) error {
+			assert.Equal(t, domain.RealtimeEventMessage, event.Type)
+			return nil
+		})
+
+	message, err := uc.Send(context.Background(), "conv-1", "alice", &SendRequest{MediaIDs: []string{"media-1"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "alice", message.SenderID)
+	assert.Equal(t, []string{"media-1"}, message.MediaIDs)
+}
+
+func TestSend_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, _ := newTestUseCase(ctrl)
+
+	_, err := uc.Send(context.Background(), "conv-1", "alice", &SendRequest{})
+
+	assert.ErrorIs(t, err, ErrEmptyMessage)
+}
+
+func TestGetMessages_NotMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.messageRepo.EXPECT().IsMember(gomock.Any(), "conv-1", "eve").Return(false, nil)
+
+	_, _, err := uc.GetMessages(context.Background(), "conv-1", "eve", 20, 0)
+
+	assert.ErrorIs(t, err, ErrConversationNotFound)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/block_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/block_repository.go -destination=internal/usecase/mocks/mock_block_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBlockRepository is a mock of BlockRepository interface.
+type MockBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBlockRepositoryMockRecorder is the mock recorder for MockBlockRepository.
+type MockBlockRepositoryMockRecorder struct {
+	mock *MockBlockRepository
+}
+
+// NewMockBlockRepository creates a new mock instance.
+func NewMockBlockRepository(ctrl *gomock.Controller) *MockBlockRepository {
+	mock := &MockBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockRepository) EXPECT() *MockBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockBlockRepository) Block(ctx context.Context, blockerID, blockedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockBlockRepositoryMockRecorder) Block(ctx, blockerID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockBlockRepository)(nil).Block), ctx, blockerID, blockedID)
+}
+
+// GetBlocked mocks base method.
+func (m *MockBlockRepository) GetBlocked(ctx context.Context, userID string, limit, offset int) ([]*domain.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocked", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlocked indicates an expected call of GetBlocked.
+func (mr *MockBlockRepositoryMockRecorder) GetBlocked(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocked", reflect.TypeOf((*MockBlockRepository)(nil).GetBlocked), ctx, userID, limit, offset)
+}
+
+// GetMuted mocks base method.
+func (m *MockBlockRepository) GetMuted(ctx context.Context, userID string, limit, offset int) ([]*domain.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuted", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMuted indicates an expected call of GetMuted.
+func (mr *MockBlockRepositoryMockRecorder) GetMuted(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuted", reflect.TypeOf((*MockBlockRepository)(nil).GetMuted), ctx, userID, limit, offset)
+}
+
+// IsBlockedEither mocks base method.
+func (m *MockBlockRepository) IsBlockedEither(ctx context.Context, userID, otherUserID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlockedEither", ctx, userID, otherUserID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlockedEither indicates an expected call of IsBlockedEither.
+func (mr *MockBlockRepositoryMockRecorder) IsBlockedEither(ctx, userID, otherUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlockedEither", reflect.TypeOf((*MockBlockRepository)(nil).IsBlockedEither), ctx, userID, otherUserID)
+}
+
+// IsMuted mocks base method.
+func (m *MockBlockRepository) IsMuted(ctx context.Context, muterID, mutedID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMuted", ctx, muterID, mutedID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMuted indicates an expected call of IsMuted.
+func (mr *MockBlockRepositoryMockRecorder) IsMuted(ctx, muterID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMuted", reflect.TypeOf((*MockBlockRepository)(nil).IsMuted), ctx, muterID, mutedID)
+}
+
+// Mute mocks base method.
+func (m *MockBlockRepository) Mute(ctx context.Context, muterID, mutedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mute", ctx, muterID, mutedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mute indicates an expected call of Mute.
+func (mr *MockBlockRepositoryMockRecorder) Mute(ctx, muterID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mute", reflect.TypeOf((*MockBlockRepository)(nil).Mute), ctx, muterID, mutedID)
+}
+
+// Unblock mocks base method.
+func (m *MockBlockRepository) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", ctx, blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockBlockRepositoryMockRecorder) Unblock(ctx, blockerID, blockedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockBlockRepository)(nil).Unblock), ctx, blockerID, blockedID)
+}
+
+// Unmute mocks base method.
+func (m *MockBlockRepository) Unmute(ctx context.Context, muterID, mutedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmute", ctx, muterID, mutedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmute indicates an expected call of Unmute.
+func (mr *MockBlockRepositoryMockRecorder) Unmute(ctx, muterID, mutedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmute", reflect.TypeOf((*MockBlockRepository)(nil).Unmute), ctx, muterID, mutedID)
+}
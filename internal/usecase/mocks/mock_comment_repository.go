@@ -85,9 +85,9 @@ func (mr *MockCommentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 }
 
 // GetByPublication mocks base method.
-func (m *MockCommentRepository) GetByPublication(ctx context.Context, publicationID string, limit, offset int) ([]*domain.Comment, int, error) {
+func (m *MockCommentRepository) GetByPublication(ctx context.Context, publicationID string, viewerUserID *string, limit, offset int) ([]*domain.Comment, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByPublication", ctx, publicationID, limit, offset)
+	ret := m.ctrl.Call(m, "GetByPublication", ctx, publicationID, viewerUserID, limit, offset)
 	ret0, _ := ret[0].([]*domain.Comment)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -95,9 +95,9 @@ func (m *MockCommentRepository) GetByPublication(ctx context.Context, publicatio
 }
 
 // GetByPublication indicates an expected call of GetByPublication.
-func (mr *MockCommentRepositoryMockRecorder) GetByPublication(ctx, publicationID, limit, offset any) *gomock.Call {
+func (mr *MockCommentRepositoryMockRecorder) GetByPublication(ctx, publicationID, viewerUserID, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPublication", reflect.TypeOf((*MockCommentRepository)(nil).GetByPublication), ctx, publicationID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPublication", reflect.TypeOf((*MockCommentRepository)(nil).GetByPublication), ctx, publicationID, viewerUserID, limit, offset)
 }
 
 // GetLikesCount mocks base method.
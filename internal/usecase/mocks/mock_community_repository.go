@@ -0,0 +1,262 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/community_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/community_repository.go -destination=internal/usecase/mocks/mock_community_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommunityRepository is a mock of CommunityRepository interface.
+type MockCommunityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommunityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCommunityRepositoryMockRecorder is the mock recorder for MockCommunityRepository.
+type MockCommunityRepositoryMockRecorder struct {
+	mock *MockCommunityRepository
+}
+
+// NewMockCommunityRepository creates a new mock instance.
+func NewMockCommunityRepository(ctrl *gomock.Controller) *MockCommunityRepository {
+	mock := &MockCommunityRepository{ctrl: ctrl}
+	mock.recorder = &MockCommunityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommunityRepository) EXPECT() *MockCommunityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockCommunityRepository) AddMember(ctx context.Context, communityID, userID string, role domain.CommunityRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, communityID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockCommunityRepositoryMockRecorder) AddMember(ctx, communityID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockCommunityRepository)(nil).AddMember), ctx, communityID, userID, role)
+}
+
+// Create mocks base method.
+func (m *MockCommunityRepository) Create(ctx context.Context, community *domain.Community) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, community)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommunityRepositoryMockRecorder) Create(ctx, community any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommunityRepository)(nil).Create), ctx, community)
+}
+
+// CreateInvite mocks base method.
+func (m *MockCommunityRepository) CreateInvite(ctx context.Context, communityID, userID, invitedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", ctx, communityID, userID, invitedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockCommunityRepositoryMockRecorder) CreateInvite(ctx, communityID, userID, invitedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockCommunityRepository)(nil).CreateInvite), ctx, communityID, userID, invitedBy)
+}
+
+// CreateJoinRequest mocks base method.
+func (m *MockCommunityRepository) CreateJoinRequest(ctx context.Context, communityID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJoinRequest", ctx, communityID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJoinRequest indicates an expected call of CreateJoinRequest.
+func (mr *MockCommunityRepositoryMockRecorder) CreateJoinRequest(ctx, communityID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJoinRequest", reflect.TypeOf((*MockCommunityRepository)(nil).CreateJoinRequest), ctx, communityID, userID)
+}
+
+// Delete mocks base method.
+func (m *MockCommunityRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommunityRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommunityRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteJoinRequest mocks base method.
+func (m *MockCommunityRepository) DeleteJoinRequest(ctx context.Context, communityID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJoinRequest", ctx, communityID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJoinRequest indicates an expected call of DeleteJoinRequest.
+func (mr *MockCommunityRepositoryMockRecorder) DeleteJoinRequest(ctx, communityID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJoinRequest", reflect.TypeOf((*MockCommunityRepository)(nil).DeleteJoinRequest), ctx, communityID, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockCommunityRepository) GetByID(ctx context.Context, id string, viewerUserID *string) (*domain.Community, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, viewerUserID)
+	ret0, _ := ret[0].(*domain.Community)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCommunityRepositoryMockRecorder) GetByID(ctx, id, viewerUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCommunityRepository)(nil).GetByID), ctx, id, viewerUserID)
+}
+
+// GetJoinRequests mocks base method.
+func (m *MockCommunityRepository) GetJoinRequests(ctx context.Context, communityID string, limit, offset int) ([]*domain.CommunityJoinRequest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJoinRequests", ctx, communityID, limit, offset)
+	ret0, _ := ret[0].([]*domain.CommunityJoinRequest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJoinRequests indicates an expected call of GetJoinRequests.
+func (mr *MockCommunityRepositoryMockRecorder) GetJoinRequests(ctx, communityID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJoinRequests", reflect.TypeOf((*MockCommunityRepository)(nil).GetJoinRequests), ctx, communityID, limit, offset)
+}
+
+// GetMembers mocks base method.
+func (m *MockCommunityRepository) GetMembers(ctx context.Context, communityID string, limit, offset int) ([]*domain.CommunityMember, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, communityID, limit, offset)
+	ret0, _ := ret[0].([]*domain.CommunityMember)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockCommunityRepositoryMockRecorder) GetMembers(ctx, communityID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockCommunityRepository)(nil).GetMembers), ctx, communityID, limit, offset)
+}
+
+// GetRole mocks base method.
+func (m *MockCommunityRepository) GetRole(ctx context.Context, communityID, userID string) (*domain.CommunityRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, communityID, userID)
+	ret0, _ := ret[0].(*domain.CommunityRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockCommunityRepositoryMockRecorder) GetRole(ctx, communityID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockCommunityRepository)(nil).GetRole), ctx, communityID, userID)
+}
+
+// HasInvite mocks base method.
+func (m *MockCommunityRepository) HasInvite(ctx context.Context, communityID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasInvite", ctx, communityID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasInvite indicates an expected call of HasInvite.
+func (mr *MockCommunityRepositoryMockRecorder) HasInvite(ctx, communityID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasInvite", reflect.TypeOf((*MockCommunityRepository)(nil).HasInvite), ctx, communityID, userID)
+}
+
+// List mocks base method.
+func (m *MockCommunityRepository) List(ctx context.Context, query string, viewerUserID *string, limit, offset int) ([]*domain.Community, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, query, viewerUserID, limit, offset)
+	ret0, _ := ret[0].([]*domain.Community)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockCommunityRepositoryMockRecorder) List(ctx, query, viewerUserID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCommunityRepository)(nil).List), ctx, query, viewerUserID, limit, offset)
+}
+
+// RemoveMember mocks base method.
+func (m *MockCommunityRepository) RemoveMember(ctx context.Context, communityID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, communityID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockCommunityRepositoryMockRecorder) RemoveMember(ctx, communityID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockCommunityRepository)(nil).RemoveMember), ctx, communityID, userID)
+}
+
+// SetRole mocks base method.
+func (m *MockCommunityRepository) SetRole(ctx context.Context, communityID, userID string, role domain.CommunityRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, communityID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockCommunityRepositoryMockRecorder) SetRole(ctx, communityID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockCommunityRepository)(nil).SetRole), ctx, communityID, userID, role)
+}
+
+// Update mocks base method.
+func (m *MockCommunityRepository) Update(ctx context.Context, community *domain.Community) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, community)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommunityRepositoryMockRecorder) Update(ctx, community any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommunityRepository)(nil).Update), ctx, community)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/digest_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/digest_repository.go -destination=internal/usecase/mocks/mock_digest_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDigestRepository is a mock of DigestRepository interface.
+type MockDigestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDigestRepositoryMockRecorder is the mock recorder for MockDigestRepository.
+type MockDigestRepositoryMockRecorder struct {
+	mock *MockDigestRepository
+}
+
+// NewMockDigestRepository creates a new mock instance.
+func NewMockDigestRepository(ctrl *gomock.Controller) *MockDigestRepository {
+	mock := &MockDigestRepository{ctrl: ctrl}
+	mock.recorder = &MockDigestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestRepository) EXPECT() *MockDigestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockDigestRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*domain.DigestRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*domain.DigestRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockDigestRepositoryMockRecorder) ClaimDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockDigestRepository)(nil).ClaimDue), ctx, now, limit)
+}
+
+// GetNewFollowers mocks base method.
+func (m *MockDigestRepository) GetNewFollowers(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewFollowers", ctx, userID, since, limit)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNewFollowers indicates an expected call of GetNewFollowers.
+func (mr *MockDigestRepositoryMockRecorder) GetNewFollowers(ctx, userID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewFollowers", reflect.TypeOf((*MockDigestRepository)(nil).GetNewFollowers), ctx, userID, since, limit)
+}
+
+// Release mocks base method.
+func (m *MockDigestRepository) Release(ctx context.Context, userID string, lastSentAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, userID, lastSentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockDigestRepositoryMockRecorder) Release(ctx, userID, lastSentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockDigestRepository)(nil).Release), ctx, userID, lastSentAt)
+}
+
+// MockMailer is a mock of Mailer interface.
+type MockMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerMockRecorder
+	isgomock struct{}
+}
+
+// MockMailerMockRecorder is the mock recorder for MockMailer.
+type MockMailerMockRecorder struct {
+	mock *MockMailer
+}
+
+// NewMockMailer creates a new mock instance.
+func NewMockMailer(ctrl *gomock.Controller) *MockMailer {
+	mock := &MockMailer{ctrl: ctrl}
+	mock.recorder = &MockMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailer) Send(ctx context.Context, email *domain.Email) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerMockRecorder) Send(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), ctx, email)
+}
@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/message_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/message_repository.go -destination=internal/usecase/mocks/mock_message_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessageRepository is a mock of MessageRepository interface.
+type MockMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageRepositoryMockRecorder is the mock recorder for MockMessageRepository.
+type MockMessageRepositoryMockRecorder struct {
+	mock *MockMessageRepository
+}
+
+// NewMockMessageRepository creates a new mock instance.
+func NewMockMessageRepository(ctrl *gomock.Controller) *MockMessageRepository {
+	mock := &MockMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageRepository) EXPECT() *MockMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateConversation mocks base method.
+func (m *MockMessageRepository) CreateConversation(ctx context.Context, conversation *domain.Conversation, memberIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConversation", ctx, conversation, memberIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateConversation indicates an expected call of CreateConversation.
+func (mr *MockMessageRepositoryMockRecorder) CreateConversation(ctx, conversation, memberIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConversation", reflect.TypeOf((*MockMessageRepository)(nil).CreateConversation), ctx, conversation, memberIDs)
+}
+
+// CreateMessage mocks base method.
+func (m *MockMessageRepository) CreateMessage(ctx context.Context, message *domain.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockMessageRepositoryMockRecorder) CreateMessage(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateMessage), ctx, message)
+}
+
+// FindDirect mocks base method.
+func (m *MockMessageRepository) FindDirect(ctx context.Context, userID, otherUserID string) (*domain.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDirect", ctx, userID, otherUserID)
+	ret0, _ := ret[0].(*domain.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDirect indicates an expected call of FindDirect.
+func (mr *MockMessageRepositoryMockRecorder) FindDirect(ctx, userID, otherUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDirect", reflect.TypeOf((*MockMessageRepository)(nil).FindDirect), ctx, userID, otherUserID)
+}
+
+// GetConversation mocks base method.
+func (m *MockMessageRepository) GetConversation(ctx context.Context, id, userID string) (*domain.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", ctx, id, userID)
+	ret0, _ := ret[0].(*domain.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockMessageRepositoryMockRecorder) GetConversation(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*MockMessageRepository)(nil).GetConversation), ctx, id, userID)
+}
+
+// GetConversations mocks base method.
+func (m *MockMessageRepository) GetConversations(ctx context.Context, userID string, limit, offset int) ([]*domain.Conversation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversations", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*domain.Conversation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConversations indicates an expected call of GetConversations.
+func (mr *MockMessageRepositoryMockRecorder) GetConversations(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversations", reflect.TypeOf((*MockMessageRepository)(nil).GetConversations), ctx, userID, limit, offset)
+}
+
+// GetMessages mocks base method.
+func (m *MockMessageRepository) GetMessages(ctx context.Context, conversationID string, limit, offset int) ([]*domain.Message, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", ctx, conversationID, limit, offset)
+	ret0, _ := ret[0].([]*domain.Message)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockMessageRepositoryMockRecorder) GetMessages(ctx, conversationID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepository)(nil).GetMessages), ctx, conversationID, limit, offset)
+}
+
+// GetSettings mocks base method.
+func (m *MockMessageRepository) GetSettings(ctx context.Context, userID string) (*domain.MessageSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, userID)
+	ret0, _ := ret[0].(*domain.MessageSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockMessageRepositoryMockRecorder) GetSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockMessageRepository)(nil).GetSettings), ctx, userID)
+}
+
+// IsMember mocks base method.
+func (m *MockMessageRepository) IsMember(ctx context.Context, conversationID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMember", ctx, conversationID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMember indicates an expected call of IsMember.
+func (mr *MockMessageRepositoryMockRecorder) IsMember(ctx, conversationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMember", reflect.TypeOf((*MockMessageRepository)(nil).IsMember), ctx, conversationID, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockMessageRepository) MarkRead(ctx context.Context, conversationID, userID string, readAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, conversationID, userID, readAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockMessageRepositoryMockRecorder) MarkRead(ctx, conversationID, userID, readAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockMessageRepository)(nil).MarkRead), ctx, conversationID, userID, readAt)
+}
+
+// RemoveMember mocks base method.
+func (m *MockMessageRepository) RemoveMember(ctx context.Context, conversationID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, conversationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockMessageRepositoryMockRecorder) RemoveMember(ctx, conversationID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockMessageRepository)(nil).RemoveMember), ctx, conversationID, userID)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockMessageRepository) UpdateSettings(ctx context.Context, settings *domain.MessageSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockMessageRepositoryMockRecorder) UpdateSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockMessageRepository)(nil).UpdateSettings), ctx, settings)
+}
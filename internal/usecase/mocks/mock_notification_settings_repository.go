@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/notification_settings_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/notification_settings_repository.go -destination=internal/usecase/mocks/mock_notification_settings_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationSettingsRepository is a mock of NotificationSettingsRepository interface.
+type MockNotificationSettingsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSettingsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationSettingsRepositoryMockRecorder is the mock recorder for MockNotificationSettingsRepository.
+type MockNotificationSettingsRepositoryMockRecorder struct {
+	mock *MockNotificationSettingsRepository
+}
+
+// NewMockNotificationSettingsRepository creates a new mock instance.
+func NewMockNotificationSettingsRepository(ctrl *gomock.Controller) *MockNotificationSettingsRepository {
+	mock := &MockNotificationSettingsRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationSettingsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSettingsRepository) EXPECT() *MockNotificationSettingsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockNotificationSettingsRepository) Get(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*domain.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNotificationSettingsRepositoryMockRecorder) Get(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNotificationSettingsRepository)(nil).Get), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockNotificationSettingsRepository) Update(ctx context.Context, settings *domain.NotificationSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNotificationSettingsRepositoryMockRecorder) Update(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNotificationSettingsRepository)(nil).Update), ctx, settings)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	domain "sense-backend/internal/domain"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,21 @@ func (m *MockPublicationRepository) EXPECT() *MockPublicationRepositoryMockRecor
 	return m.recorder
 }
 
+// CountForReindex mocks base method.
+func (m *MockPublicationRepository) CountForReindex(ctx context.Context, filters *domain.SearchFilters) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountForReindex", ctx, filters)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountForReindex indicates an expected call of CountForReindex.
+func (mr *MockPublicationRepositoryMockRecorder) CountForReindex(ctx, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountForReindex", reflect.TypeOf((*MockPublicationRepository)(nil).CountForReindex), ctx, filters)
+}
+
 // Create mocks base method.
 func (m *MockPublicationRepository) Create(ctx context.Context, publication *domain.Publication, mediaIDs []string) error {
 	m.ctrl.T.Helper()
@@ -69,6 +85,21 @@ func (mr *MockPublicationRepositoryMockRecorder) Delete(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPublicationRepository)(nil).Delete), ctx, id)
 }
 
+// GetAuthorAffinity mocks base method.
+func (m *MockPublicationRepository) GetAuthorAffinity(ctx context.Context, userID string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorAffinity", ctx, userID)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorAffinity indicates an expected call of GetAuthorAffinity.
+func (mr *MockPublicationRepositoryMockRecorder) GetAuthorAffinity(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorAffinity", reflect.TypeOf((*MockPublicationRepository)(nil).GetAuthorAffinity), ctx, userID)
+}
+
 // GetByAuthor mocks base method.
 func (m *MockPublicationRepository) GetByAuthor(ctx context.Context, authorID string, viewerUserID *string, filters *domain.PublicationFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +116,37 @@ func (mr *MockPublicationRepositoryMockRecorder) GetByAuthor(ctx, authorID, view
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockPublicationRepository)(nil).GetByAuthor), ctx, authorID, viewerUserID, filters, limit, offset)
 }
 
+// GetByCommunity mocks base method.
+func (m *MockPublicationRepository) GetByCommunity(ctx context.Context, communityID string, viewerUserID *string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCommunity", ctx, communityID, viewerUserID, limit, offset)
+	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByCommunity indicates an expected call of GetByCommunity.
+func (mr *MockPublicationRepositoryMockRecorder) GetByCommunity(ctx, communityID, viewerUserID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCommunity", reflect.TypeOf((*MockPublicationRepository)(nil).GetByCommunity), ctx, communityID, viewerUserID, limit, offset)
+}
+
+// GetByFollowing mocks base method.
+func (m *MockPublicationRepository) GetByFollowing(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.PublicationWithLikeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFollowing", ctx, userID, since, limit)
+	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByFollowing indicates an expected call of GetByFollowing.
+func (mr *MockPublicationRepositoryMockRecorder) GetByFollowing(ctx, userID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFollowing", reflect.TypeOf((*MockPublicationRepository)(nil).GetByFollowing), ctx, userID, since, limit)
+}
+
 // GetByID mocks base method.
 func (m *MockPublicationRepository) GetByID(ctx context.Context, id string) (*domain.Publication, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +177,37 @@ func (mr *MockPublicationRepositoryMockRecorder) GetByIDWithLikeStatus(ctx, id,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDWithLikeStatus", reflect.TypeOf((*MockPublicationRepository)(nil).GetByIDWithLikeStatus), ctx, id, viewerUserID)
 }
 
+// GetByIDs mocks base method.
+func (m *MockPublicationRepository) GetByIDs(ctx context.Context, ids []string, viewerUserID *string) ([]*domain.PublicationWithLikeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids, viewerUserID)
+	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockPublicationRepositoryMockRecorder) GetByIDs(ctx, ids, viewerUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockPublicationRepository)(nil).GetByIDs), ctx, ids, viewerUserID)
+}
+
+// GetByTag mocks base method.
+func (m *MockPublicationRepository) GetByTag(ctx context.Context, tagID string, viewerUserID *string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTag", ctx, tagID, viewerUserID, limit, offset)
+	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByTag indicates an expected call of GetByTag.
+func (mr *MockPublicationRepositoryMockRecorder) GetByTag(ctx, tagID, viewerUserID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTag", reflect.TypeOf((*MockPublicationRepository)(nil).GetByTag), ctx, tagID, viewerUserID, limit, offset)
+}
+
 // GetFeed mocks base method.
 func (m *MockPublicationRepository) GetFeed(ctx context.Context, userID *string, filters *domain.FeedFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +224,21 @@ func (mr *MockPublicationRepositoryMockRecorder) GetFeed(ctx, userID, filters, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockPublicationRepository)(nil).GetFeed), ctx, userID, filters, limit, offset)
 }
 
+// GetFeedByIDs mocks base method.
+func (m *MockPublicationRepository) GetFeedByIDs(ctx context.Context, userID string, ids []string) ([]*domain.PublicationWithLikeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedByIDs", ctx, userID, ids)
+	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedByIDs indicates an expected call of GetFeedByIDs.
+func (mr *MockPublicationRepositoryMockRecorder) GetFeedByIDs(ctx, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedByIDs", reflect.TypeOf((*MockPublicationRepository)(nil).GetFeedByIDs), ctx, userID, ids)
+}
+
 // GetLikedUsers mocks base method.
 func (m *MockPublicationRepository) GetLikedUsers(ctx context.Context, publicationID string, limit, offset int) ([]*domain.User, int, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +285,21 @@ func (mr *MockPublicationRepositoryMockRecorder) GetMediaIDs(ctx, publicationID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaIDs", reflect.TypeOf((*MockPublicationRepository)(nil).GetMediaIDs), ctx, publicationID)
 }
 
+// GetPopularInUserTags mocks base method.
+func (m *MockPublicationRepository) GetPopularInUserTags(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.PublicationWithLikeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularInUserTags", ctx, userID, since, limit)
+	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularInUserTags indicates an expected call of GetPopularInUserTags.
+func (mr *MockPublicationRepositoryMockRecorder) GetPopularInUserTags(ctx, userID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularInUserTags", reflect.TypeOf((*MockPublicationRepository)(nil).GetPopularInUserTags), ctx, userID, since, limit)
+}
+
 // GetSaved mocks base method.
 func (m *MockPublicationRepository) GetSaved(ctx context.Context, userID string, filters *domain.PublicationFilters, limit, offset int) ([]*domain.SavedPublicationWithLikeStatus, int, error) {
 	m.ctrl.T.Helper()
@@ -193,6 +316,21 @@ func (mr *MockPublicationRepositoryMockRecorder) GetSaved(ctx, userID, filters,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSaved", reflect.TypeOf((*MockPublicationRepository)(nil).GetSaved), ctx, userID, filters, limit, offset)
 }
 
+// GetSearchDocuments mocks base method.
+func (m *MockPublicationRepository) GetSearchDocuments(ctx context.Context, ids []string) ([]*domain.SearchDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSearchDocuments", ctx, ids)
+	ret0, _ := ret[0].([]*domain.SearchDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSearchDocuments indicates an expected call of GetSearchDocuments.
+func (mr *MockPublicationRepositoryMockRecorder) GetSearchDocuments(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSearchDocuments", reflect.TypeOf((*MockPublicationRepository)(nil).GetSearchDocuments), ctx, ids)
+}
+
 // IsLiked mocks base method.
 func (m *MockPublicationRepository) IsLiked(ctx context.Context, userID, publicationID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -238,6 +376,21 @@ func (mr *MockPublicationRepositoryMockRecorder) Like(ctx, userID, publicationID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockPublicationRepository)(nil).Like), ctx, userID, publicationID)
 }
 
+// ListSearchDocuments mocks base method.
+func (m *MockPublicationRepository) ListSearchDocuments(ctx context.Context, filters *domain.SearchFilters, afterID string, limit int) ([]*domain.SearchDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSearchDocuments", ctx, filters, afterID, limit)
+	ret0, _ := ret[0].([]*domain.SearchDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSearchDocuments indicates an expected call of ListSearchDocuments.
+func (mr *MockPublicationRepositoryMockRecorder) ListSearchDocuments(ctx, filters, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearchDocuments", reflect.TypeOf((*MockPublicationRepository)(nil).ListSearchDocuments), ctx, filters, afterID, limit)
+}
+
 // Save mocks base method.
 func (m *MockPublicationRepository) Save(ctx context.Context, userID, publicationID string, note *string) error {
 	m.ctrl.T.Helper()
@@ -252,20 +405,35 @@ func (mr *MockPublicationRepositoryMockRecorder) Save(ctx, userID, publicationID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPublicationRepository)(nil).Save), ctx, userID, publicationID, note)
 }
 
-// Search mocks base method.
-func (m *MockPublicationRepository) Search(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+// SearchFacets mocks base method.
+func (m *MockPublicationRepository) SearchFacets(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, buckets []domain.DateBucket, limit int) (*domain.SearchFacets, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, query, viewerUserID, filters, limit, offset)
-	ret0, _ := ret[0].([]*domain.PublicationWithLikeStatus)
+	ret := m.ctrl.Call(m, "SearchFacets", ctx, query, viewerUserID, filters, buckets, limit)
+	ret0, _ := ret[0].(*domain.SearchFacets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFacets indicates an expected call of SearchFacets.
+func (mr *MockPublicationRepositoryMockRecorder) SearchFacets(ctx, query, viewerUserID, filters, buckets, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFacets", reflect.TypeOf((*MockPublicationRepository)(nil).SearchFacets), ctx, query, viewerUserID, filters, buckets, limit)
+}
+
+// SearchSources mocks base method.
+func (m *MockPublicationRepository) SearchSources(ctx context.Context, query string, viewerUserID *string, limit int) ([]*domain.SourceHit, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSources", ctx, query, viewerUserID, limit)
+	ret0, _ := ret[0].([]*domain.SourceHit)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// Search indicates an expected call of Search.
-func (mr *MockPublicationRepositoryMockRecorder) Search(ctx, query, viewerUserID, filters, limit, offset any) *gomock.Call {
+// SearchSources indicates an expected call of SearchSources.
+func (mr *MockPublicationRepositoryMockRecorder) SearchSources(ctx, query, viewerUserID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPublicationRepository)(nil).Search), ctx, query, viewerUserID, filters, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSources", reflect.TypeOf((*MockPublicationRepository)(nil).SearchSources), ctx, query, viewerUserID, limit)
 }
 
 // Unsave mocks base method.
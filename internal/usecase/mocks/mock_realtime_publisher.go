@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/realtime.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/realtime.go -destination=internal/usecase/mocks/mock_realtime_publisher.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRealtimePublisher is a mock of RealtimePublisher interface.
+type MockRealtimePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimePublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockRealtimePublisherMockRecorder is the mock recorder for MockRealtimePublisher.
+type MockRealtimePublisherMockRecorder struct {
+	mock *MockRealtimePublisher
+}
+
+// NewMockRealtimePublisher creates a new mock instance.
+func NewMockRealtimePublisher(ctrl *gomock.Controller) *MockRealtimePublisher {
+	mock := &MockRealtimePublisher{ctrl: ctrl}
+	mock.recorder = &MockRealtimePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimePublisher) EXPECT() *MockRealtimePublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockRealtimePublisher) Publish(ctx context.Context, userID string, event *domain.RealtimeEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockRealtimePublisherMockRecorder) Publish(ctx, userID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockRealtimePublisher)(nil).Publish), ctx, userID, event)
+}
+
+// PublishToPublication mocks base method.
+func (m *MockRealtimePublisher) PublishToPublication(ctx context.Context, publicationID string, event *domain.RealtimeEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishToPublication", ctx, publicationID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishToPublication indicates an expected call of PublishToPublication.
+func (mr *MockRealtimePublisherMockRecorder) PublishToPublication(ctx, publicationID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishToPublication", reflect.TypeOf((*MockRealtimePublisher)(nil).PublishToPublication), ctx, publicationID, event)
+}
+
+// MockRealtimeSubscription is a mock of RealtimeSubscription interface.
+type MockRealtimeSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeSubscriptionMockRecorder
+	isgomock struct{}
+}
+
+// MockRealtimeSubscriptionMockRecorder is the mock recorder for MockRealtimeSubscription.
+type MockRealtimeSubscriptionMockRecorder struct {
+	mock *MockRealtimeSubscription
+}
+
+// NewMockRealtimeSubscription creates a new mock instance.
+func NewMockRealtimeSubscription(ctrl *gomock.Controller) *MockRealtimeSubscription {
+	mock := &MockRealtimeSubscription{ctrl: ctrl}
+	mock.recorder = &MockRealtimeSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimeSubscription) EXPECT() *MockRealtimeSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRealtimeSubscription) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRealtimeSubscriptionMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRealtimeSubscription)(nil).Close))
+}
+
+// Events mocks base method.
+func (m *MockRealtimeSubscription) Events() <-chan *domain.RealtimeEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events")
+	ret0, _ := ret[0].(<-chan *domain.RealtimeEvent)
+	return ret0
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockRealtimeSubscriptionMockRecorder) Events() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockRealtimeSubscription)(nil).Events))
+}
+
+// Join mocks base method.
+func (m *MockRealtimeSubscription) Join(channel string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Join", channel)
+}
+
+// Join indicates an expected call of Join.
+func (mr *MockRealtimeSubscriptionMockRecorder) Join(channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Join", reflect.TypeOf((*MockRealtimeSubscription)(nil).Join), channel)
+}
+
+// Leave mocks base method.
+func (m *MockRealtimeSubscription) Leave(channel string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Leave", channel)
+}
+
+// Leave indicates an expected call of Leave.
+func (mr *MockRealtimeSubscriptionMockRecorder) Leave(channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leave", reflect.TypeOf((*MockRealtimeSubscription)(nil).Leave), channel)
+}
+
+// MockRealtimeSubscriber is a mock of RealtimeSubscriber interface.
+type MockRealtimeSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockRealtimeSubscriberMockRecorder is the mock recorder for MockRealtimeSubscriber.
+type MockRealtimeSubscriberMockRecorder struct {
+	mock *MockRealtimeSubscriber
+}
+
+// NewMockRealtimeSubscriber creates a new mock instance.
+func NewMockRealtimeSubscriber(ctrl *gomock.Controller) *MockRealtimeSubscriber {
+	mock := &MockRealtimeSubscriber{ctrl: ctrl}
+	mock.recorder = &MockRealtimeSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimeSubscriber) EXPECT() *MockRealtimeSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockRealtimeSubscriber) Subscribe(ctx context.Context, channels []string, lastEventID int64) (domain.RealtimeSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, channels, lastEventID)
+	ret0, _ := ret[0].(domain.RealtimeSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRealtimeSubscriberMockRecorder) Subscribe(ctx, channels, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRealtimeSubscriber)(nil).Subscribe), ctx, channels, lastEventID)
+}
@@ -0,0 +1,448 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/search_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/search_repository.go -destination=internal/usecase/mocks/mock_search_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReindexTaskRepository is a mock of ReindexTaskRepository interface.
+type MockReindexTaskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReindexTaskRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReindexTaskRepositoryMockRecorder is the mock recorder for MockReindexTaskRepository.
+type MockReindexTaskRepositoryMockRecorder struct {
+	mock *MockReindexTaskRepository
+}
+
+// NewMockReindexTaskRepository creates a new mock instance.
+func NewMockReindexTaskRepository(ctrl *gomock.Controller) *MockReindexTaskRepository {
+	mock := &MockReindexTaskRepository{ctrl: ctrl}
+	mock.recorder = &MockReindexTaskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReindexTaskRepository) EXPECT() *MockReindexTaskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReindexTaskRepository) Create(ctx context.Context, task *domain.ReindexTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReindexTaskRepositoryMockRecorder) Create(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReindexTaskRepository)(nil).Create), ctx, task)
+}
+
+// GetByID mocks base method.
+func (m *MockReindexTaskRepository) GetByID(ctx context.Context, id string) (*domain.ReindexTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.ReindexTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReindexTaskRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReindexTaskRepository)(nil).GetByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockReindexTaskRepository) Update(ctx context.Context, task *domain.ReindexTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReindexTaskRepositoryMockRecorder) Update(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReindexTaskRepository)(nil).Update), ctx, task)
+}
+
+// MockSearchIndex is a mock of SearchIndex interface.
+type MockSearchIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchIndexMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchIndexMockRecorder is the mock recorder for MockSearchIndex.
+type MockSearchIndexMockRecorder struct {
+	mock *MockSearchIndex
+}
+
+// NewMockSearchIndex creates a new mock instance.
+func NewMockSearchIndex(ctrl *gomock.Controller) *MockSearchIndex {
+	mock := &MockSearchIndex{ctrl: ctrl}
+	mock.recorder = &MockSearchIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchIndex) EXPECT() *MockSearchIndexMockRecorder {
+	return m.recorder
+}
+
+// Correct mocks base method.
+func (m *MockSearchIndex) Correct(ctx context.Context, words []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", ctx, words)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockSearchIndexMockRecorder) Correct(ctx, words any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockSearchIndex)(nil).Correct), ctx, words)
+}
+
+// Delete mocks base method.
+func (m *MockSearchIndex) Delete(ctx context.Context, ids ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSearchIndexMockRecorder) Delete(ctx any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSearchIndex)(nil).Delete), varargs...)
+}
+
+// Index mocks base method.
+func (m *MockSearchIndex) Index(ctx context.Context, docs ...*domain.SearchDocument) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range docs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Index", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockSearchIndexMockRecorder) Index(ctx any, docs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, docs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockSearchIndex)(nil).Index), varargs...)
+}
+
+// Query mocks base method.
+func (m *MockSearchIndex) Query(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.SearchHit, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query, viewerUserID, filters, limit, offset)
+	ret0, _ := ret[0].([]*domain.SearchHit)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSearchIndexMockRecorder) Query(ctx, query, viewerUserID, filters, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSearchIndex)(nil).Query), ctx, query, viewerUserID, filters, limit, offset)
+}
+
+// Suggest mocks base method.
+func (m *MockSearchIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, prefix, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSearchIndexMockRecorder) Suggest(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSearchIndex)(nil).Suggest), ctx, prefix, limit)
+}
+
+// MockSavedSearchRepository is a mock of SavedSearchRepository interface.
+type MockSavedSearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSavedSearchRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSavedSearchRepositoryMockRecorder is the mock recorder for MockSavedSearchRepository.
+type MockSavedSearchRepositoryMockRecorder struct {
+	mock *MockSavedSearchRepository
+}
+
+// NewMockSavedSearchRepository creates a new mock instance.
+func NewMockSavedSearchRepository(ctrl *gomock.Controller) *MockSavedSearchRepository {
+	mock := &MockSavedSearchRepository{ctrl: ctrl}
+	mock.recorder = &MockSavedSearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSavedSearchRepository) EXPECT() *MockSavedSearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMatches mocks base method.
+func (m *MockSavedSearchRepository) AddMatches(ctx context.Context, searchID string, publicationIDs []string, runAt time.Time) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMatches", ctx, searchID, publicationIDs, runAt)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddMatches indicates an expected call of AddMatches.
+func (mr *MockSavedSearchRepositoryMockRecorder) AddMatches(ctx, searchID, publicationIDs, runAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMatches", reflect.TypeOf((*MockSavedSearchRepository)(nil).AddMatches), ctx, searchID, publicationIDs, runAt)
+}
+
+// CountByUser mocks base method.
+func (m *MockSavedSearchRepository) CountByUser(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUser", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUser indicates an expected call of CountByUser.
+func (mr *MockSavedSearchRepositoryMockRecorder) CountByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUser", reflect.TypeOf((*MockSavedSearchRepository)(nil).CountByUser), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockSavedSearchRepository) Create(ctx context.Context, search *domain.SavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, search)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSavedSearchRepositoryMockRecorder) Create(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSavedSearchRepository)(nil).Create), ctx, search)
+}
+
+// Delete mocks base method.
+func (m *MockSavedSearchRepository) Delete(ctx context.Context, userID, searchID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, searchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSavedSearchRepositoryMockRecorder) Delete(ctx, userID, searchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSavedSearchRepository)(nil).Delete), ctx, userID, searchID)
+}
+
+// GetByID mocks base method.
+func (m *MockSavedSearchRepository) GetByID(ctx context.Context, userID, searchID string) (*domain.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, userID, searchID)
+	ret0, _ := ret[0].(*domain.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSavedSearchRepositoryMockRecorder) GetByID(ctx, userID, searchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSavedSearchRepository)(nil).GetByID), ctx, userID, searchID)
+}
+
+// GetByUser mocks base method.
+func (m *MockSavedSearchRepository) GetByUser(ctx context.Context, userID string) ([]*domain.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockSavedSearchRepositoryMockRecorder) GetByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockSavedSearchRepository)(nil).GetByUser), ctx, userID)
+}
+
+// GetUnseen mocks base method.
+func (m *MockSavedSearchRepository) GetUnseen(ctx context.Context, searchID string, limit, offset int) ([]string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnseen", ctx, searchID, limit, offset)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUnseen indicates an expected call of GetUnseen.
+func (mr *MockSavedSearchRepositoryMockRecorder) GetUnseen(ctx, searchID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnseen", reflect.TypeOf((*MockSavedSearchRepository)(nil).GetUnseen), ctx, searchID, limit, offset)
+}
+
+// List mocks base method.
+func (m *MockSavedSearchRepository) List(ctx context.Context, afterID string, limit int) ([]*domain.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*domain.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSavedSearchRepositoryMockRecorder) List(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSavedSearchRepository)(nil).List), ctx, afterID, limit)
+}
+
+// MarkSeen mocks base method.
+func (m *MockSavedSearchRepository) MarkSeen(ctx context.Context, userID, searchID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSeen", ctx, userID, searchID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSeen indicates an expected call of MarkSeen.
+func (mr *MockSavedSearchRepositoryMockRecorder) MarkSeen(ctx, userID, searchID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSeen", reflect.TypeOf((*MockSavedSearchRepository)(nil).MarkSeen), ctx, userID, searchID, at)
+}
+
+// Update mocks base method.
+func (m *MockSavedSearchRepository) Update(ctx context.Context, search *domain.SavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, search)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSavedSearchRepositoryMockRecorder) Update(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSavedSearchRepository)(nil).Update), ctx, search)
+}
+
+// MockSearchLogRepository is a mock of SearchLogRepository interface.
+type MockSearchLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchLogRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchLogRepositoryMockRecorder is the mock recorder for MockSearchLogRepository.
+type MockSearchLogRepositoryMockRecorder struct {
+	mock *MockSearchLogRepository
+}
+
+// NewMockSearchLogRepository creates a new mock instance.
+func NewMockSearchLogRepository(ctrl *gomock.Controller) *MockSearchLogRepository {
+	mock := &MockSearchLogRepository{ctrl: ctrl}
+	mock.recorder = &MockSearchLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchLogRepository) EXPECT() *MockSearchLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockSearchLogRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockSearchLogRepositoryMockRecorder) DeleteBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockSearchLogRepository)(nil).DeleteBefore), ctx, before)
+}
+
+// LogClick mocks base method.
+func (m *MockSearchLogRepository) LogClick(ctx context.Context, click *domain.SearchClick) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogClick", ctx, click)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogClick indicates an expected call of LogClick.
+func (mr *MockSearchLogRepositoryMockRecorder) LogClick(ctx, click any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogClick", reflect.TypeOf((*MockSearchLogRepository)(nil).LogClick), ctx, click)
+}
+
+// LogQuery mocks base method.
+func (m *MockSearchLogRepository) LogQuery(ctx context.Context, entry *domain.SearchQueryLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogQuery", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogQuery indicates an expected call of LogQuery.
+func (mr *MockSearchLogRepositoryMockRecorder) LogQuery(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogQuery", reflect.TypeOf((*MockSearchLogRepository)(nil).LogQuery), ctx, entry)
+}
+
+// PopularQueries mocks base method.
+func (m *MockSearchLogRepository) PopularQueries(ctx context.Context, prefix string, since time.Time, minSearchers, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopularQueries", ctx, prefix, since, minSearchers, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PopularQueries indicates an expected call of PopularQueries.
+func (mr *MockSearchLogRepositoryMockRecorder) PopularQueries(ctx, prefix, since, minSearchers, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopularQueries", reflect.TypeOf((*MockSearchLogRepository)(nil).PopularQueries), ctx, prefix, since, minSearchers, limit)
+}
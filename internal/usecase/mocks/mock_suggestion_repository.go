@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/suggestion_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/suggestion_repository.go -destination=internal/usecase/mocks/mock_suggestion_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSuggestionRepository is a mock of SuggestionRepository interface.
+type MockSuggestionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuggestionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSuggestionRepositoryMockRecorder is the mock recorder for MockSuggestionRepository.
+type MockSuggestionRepositoryMockRecorder struct {
+	mock *MockSuggestionRepository
+}
+
+// NewMockSuggestionRepository creates a new mock instance.
+func NewMockSuggestionRepository(ctrl *gomock.Controller) *MockSuggestionRepository {
+	mock := &MockSuggestionRepository{ctrl: ctrl}
+	mock.recorder = &MockSuggestionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuggestionRepository) EXPECT() *MockSuggestionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Dismiss mocks base method.
+func (m *MockSuggestionRepository) Dismiss(ctx context.Context, userID, dismissedID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dismiss", ctx, userID, dismissedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Dismiss indicates an expected call of Dismiss.
+func (mr *MockSuggestionRepositoryMockRecorder) Dismiss(ctx, userID, dismissedID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dismiss", reflect.TypeOf((*MockSuggestionRepository)(nil).Dismiss), ctx, userID, dismissedID)
+}
+
+// GetCandidates mocks base method.
+func (m *MockSuggestionRepository) GetCandidates(ctx context.Context, userID string, limit int) ([]*domain.SuggestionCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandidates", ctx, userID, limit)
+	ret0, _ := ret[0].([]*domain.SuggestionCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandidates indicates an expected call of GetCandidates.
+func (mr *MockSuggestionRepositoryMockRecorder) GetCandidates(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandidates", reflect.TypeOf((*MockSuggestionRepository)(nil).GetCandidates), ctx, userID, limit)
+}
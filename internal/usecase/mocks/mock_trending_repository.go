@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/trending_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/trending_repository.go -destination=internal/usecase/mocks/mock_trending_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "sense-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrendingRepository is a mock of TrendingRepository interface.
+type MockTrendingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrendingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTrendingRepositoryMockRecorder is the mock recorder for MockTrendingRepository.
+type MockTrendingRepositoryMockRecorder struct {
+	mock *MockTrendingRepository
+}
+
+// NewMockTrendingRepository creates a new mock instance.
+func NewMockTrendingRepository(ctrl *gomock.Controller) *MockTrendingRepository {
+	mock := &MockTrendingRepository{ctrl: ctrl}
+	mock.recorder = &MockTrendingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrendingRepository) EXPECT() *MockTrendingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPublications mocks base method.
+func (m *MockTrendingRepository) GetPublications(ctx context.Context, viewerUserID *string, filters *domain.TrendingFilters, limit, offset int) ([]*domain.TrendingPublication, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublications", ctx, viewerUserID, filters, limit, offset)
+	ret0, _ := ret[0].([]*domain.TrendingPublication)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPublications indicates an expected call of GetPublications.
+func (mr *MockTrendingRepositoryMockRecorder) GetPublications(ctx, viewerUserID, filters, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublications", reflect.TypeOf((*MockTrendingRepository)(nil).GetPublications), ctx, viewerUserID, filters, limit, offset)
+}
+
+// GetTags mocks base method.
+func (m *MockTrendingRepository) GetTags(ctx context.Context, filters *domain.TrendingFilters, limit int) ([]*domain.TrendingTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, filters, limit)
+	ret0, _ := ret[0].([]*domain.TrendingTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTrendingRepositoryMockRecorder) GetTags(ctx, filters, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTrendingRepository)(nil).GetTags), ctx, filters, limit)
+}
+
+// Refresh mocks base method.
+func (m *MockTrendingRepository) Refresh(ctx context.Context, params *domain.TrendingParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockTrendingRepositoryMockRecorder) Refresh(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockTrendingRepository)(nil).Refresh), ctx, params)
+}
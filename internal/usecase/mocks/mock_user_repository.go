@@ -41,6 +41,49 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ApproveAllFollowRequests mocks base method.
+func (m *MockUserRepository) ApproveAllFollowRequests(ctx context.Context, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveAllFollowRequests", ctx, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveAllFollowRequests indicates an expected call of ApproveAllFollowRequests.
+func (mr *MockUserRepositoryMockRecorder) ApproveAllFollowRequests(ctx, targetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveAllFollowRequests", reflect.TypeOf((*MockUserRepository)(nil).ApproveAllFollowRequests), ctx, targetID)
+}
+
+// ApproveFollowRequest mocks base method.
+func (m *MockUserRepository) ApproveFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveFollowRequest", ctx, requesterID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveFollowRequest indicates an expected call of ApproveFollowRequest.
+func (mr *MockUserRepositoryMockRecorder) ApproveFollowRequest(ctx, requesterID, targetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveFollowRequest", reflect.TypeOf((*MockUserRepository)(nil).ApproveFollowRequest), ctx, requesterID, targetID)
+}
+
+// Autocomplete mocks base method.
+func (m *MockUserRepository) Autocomplete(ctx context.Context, prefix string, viewerUserID *string, limit int) ([]*domain.UserSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Autocomplete", ctx, prefix, viewerUserID, limit)
+	ret0, _ := ret[0].([]*domain.UserSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Autocomplete indicates an expected call of Autocomplete.
+func (mr *MockUserRepositoryMockRecorder) Autocomplete(ctx, prefix, viewerUserID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Autocomplete", reflect.TypeOf((*MockUserRepository)(nil).Autocomplete), ctx, prefix, viewerUserID, limit)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	m.ctrl.T.Helper()
@@ -55,6 +98,35 @@ func (mr *MockUserRepositoryMockRecorder) Create(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), ctx, user)
 }
 
+// CreateFollowRequest mocks base method.
+func (m *MockUserRepository) CreateFollowRequest(ctx context.Context, requesterID, targetID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFollowRequest", ctx, requesterID, targetID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFollowRequest indicates an expected call of CreateFollowRequest.
+func (mr *MockUserRepositoryMockRecorder) CreateFollowRequest(ctx, requesterID, targetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFollowRequest", reflect.TypeOf((*MockUserRepository)(nil).CreateFollowRequest), ctx, requesterID, targetID)
+}
+
+// DeleteFollowRequest mocks base method.
+func (m *MockUserRepository) DeleteFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFollowRequest", ctx, requesterID, targetID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFollowRequest indicates an expected call of DeleteFollowRequest.
+func (mr *MockUserRepositoryMockRecorder) DeleteFollowRequest(ctx, requesterID, targetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFollowRequest", reflect.TypeOf((*MockUserRepository)(nil).DeleteFollowRequest), ctx, requesterID, targetID)
+}
+
 // Follow mocks base method.
 func (m *MockUserRepository) Follow(ctx context.Context, followerID, followingID string) error {
 	m.ctrl.T.Helper()
@@ -129,6 +201,38 @@ func (mr *MockUserRepositoryMockRecorder) GetByUsername(ctx, username any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetByUsername), ctx, username)
 }
 
+// GetFollowRequests mocks base method.
+func (m *MockUserRepository) GetFollowRequests(ctx context.Context, targetID string, limit, offset int) ([]*domain.FollowRequest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowRequests", ctx, targetID, limit, offset)
+	ret0, _ := ret[0].([]*domain.FollowRequest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFollowRequests indicates an expected call of GetFollowRequests.
+func (mr *MockUserRepositoryMockRecorder) GetFollowRequests(ctx, targetID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowRequests", reflect.TypeOf((*MockUserRepository)(nil).GetFollowRequests), ctx, targetID, limit, offset)
+}
+
+// GetFollowers mocks base method.
+func (m *MockUserRepository) GetFollowers(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, userID, viewerUserID, limit, offset)
+	ret0, _ := ret[0].([]*domain.UserCard)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockUserRepositoryMockRecorder) GetFollowers(ctx, userID, viewerUserID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockUserRepository)(nil).GetFollowers), ctx, userID, viewerUserID, limit, offset)
+}
+
 // GetFollowersCount mocks base method.
 func (m *MockUserRepository) GetFollowersCount(ctx context.Context, userID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +248,22 @@ func (mr *MockUserRepositoryMockRecorder) GetFollowersCount(ctx, userID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowersCount", reflect.TypeOf((*MockUserRepository)(nil).GetFollowersCount), ctx, userID)
 }
 
+// GetFollowing mocks base method.
+func (m *MockUserRepository) GetFollowing(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userID, viewerUserID, limit, offset)
+	ret0, _ := ret[0].([]*domain.UserCard)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockUserRepositoryMockRecorder) GetFollowing(ctx, userID, viewerUserID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockUserRepository)(nil).GetFollowing), ctx, userID, viewerUserID, limit, offset)
+}
+
 // GetFollowingCount mocks base method.
 func (m *MockUserRepository) GetFollowingCount(ctx context.Context, userID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +279,22 @@ func (mr *MockUserRepositoryMockRecorder) GetFollowingCount(ctx, userID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowingCount", reflect.TypeOf((*MockUserRepository)(nil).GetFollowingCount), ctx, userID)
 }
 
+// GetMutuals mocks base method.
+func (m *MockUserRepository) GetMutuals(ctx context.Context, userID string, viewerUserID *string, limit, offset int) ([]*domain.UserCard, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutuals", ctx, userID, viewerUserID, limit, offset)
+	ret0, _ := ret[0].([]*domain.UserCard)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMutuals indicates an expected call of GetMutuals.
+func (mr *MockUserRepositoryMockRecorder) GetMutuals(ctx, userID, viewerUserID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutuals", reflect.TypeOf((*MockUserRepository)(nil).GetMutuals), ctx, userID, viewerUserID, limit, offset)
+}
+
 // GetStats mocks base method.
 func (m *MockUserRepository) GetStats(ctx context.Context, userID string) (*domain.UserStatistic, error) {
 	m.ctrl.T.Helper()
@@ -174,6 +310,21 @@ func (mr *MockUserRepositoryMockRecorder) GetStats(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockUserRepository)(nil).GetStats), ctx, userID)
 }
 
+// HasFollowRequest mocks base method.
+func (m *MockUserRepository) HasFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasFollowRequest", ctx, requesterID, targetID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasFollowRequest indicates an expected call of HasFollowRequest.
+func (mr *MockUserRepositoryMockRecorder) HasFollowRequest(ctx, requesterID, targetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasFollowRequest", reflect.TypeOf((*MockUserRepository)(nil).HasFollowRequest), ctx, requesterID, targetID)
+}
+
 // IsFollowing mocks base method.
 func (m *MockUserRepository) IsFollowing(ctx context.Context, followerID, followingID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -190,19 +341,19 @@ func (mr *MockUserRepositoryMockRecorder) IsFollowing(ctx, followerID, following
 }
 
 // Search mocks base method.
-func (m *MockUserRepository) Search(ctx context.Context, query string, role *domain.UserRole, limit, offset int) ([]*domain.User, int, error) {
+func (m *MockUserRepository) Search(ctx context.Context, query string, viewerUserID *string, role *domain.UserRole, limit, offset int) ([]*domain.UserSearchResult, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, query, role, limit, offset)
-	ret0, _ := ret[0].([]*domain.User)
+	ret := m.ctrl.Call(m, "Search", ctx, query, viewerUserID, role, limit, offset)
+	ret0, _ := ret[0].([]*domain.UserSearchResult)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockUserRepositoryMockRecorder) Search(ctx, query, role, limit, offset any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Search(ctx, query, viewerUserID, role, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepository)(nil).Search), ctx, query, role, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepository)(nil).Search), ctx, query, viewerUserID, role, limit, offset)
 }
 
 // Unfollow mocks base method.
@@ -0,0 +1,185 @@
+package notification
+
+import (
+	"strconv"
+	"strings"
+
+	"sense-backend/internal/domain"
+)
+
+// DefaultLocale is used when the configured locale has no messages
+const DefaultLocale = "ru"
+
+// template is a localised notification text for one actor and for a group of actors;
+// {actor} is the latest actor's username, {others} the number of the other actors and
+// {subject} the title of the publication, the name of the community or of the saved search involved;
+// notifications counting new items instead of actors use {count} and only title and message
+type template struct {
+	title       string
+	message     string
+	titleMany   string
+	messageMany string
+}
+
+var templates = map[string]map[domain.NotificationType]template{
+	"ru": {
+		domain.NotificationTypeLike: {
+			title:       "Новая отметка «Нравится»",
+			message:     "Пользователю {actor} понравилась ваша публикация «{subject}»",
+			titleMany:   "Новые отметки «Нравится»",
+			messageMany: "Пользователям {actor} и ещё {others} понравилась ваша публикация «{subject}»",
+		},
+		domain.NotificationTypeComment: {
+			title:       "Новый комментарий",
+			message:     "Новый комментарий от {actor} к вашей публикации «{subject}»",
+			titleMany:   "Новые комментарии",
+			messageMany: "Новые комментарии от {actor} и ещё {others} к вашей публикации «{subject}»",
+		},
+		domain.NotificationTypeReply: {
+			title:       "Ответ на комментарий",
+			message:     "Новый ответ от {actor} на ваш комментарий к публикации «{subject}»",
+			titleMany:   "Ответы на комментарий",
+			messageMany: "Новые ответы от {actor} и ещё {others} на ваш комментарий к публикации «{subject}»",
+		},
+		domain.NotificationTypeCommentLike: {
+			title:       "Новая отметка «Нравится»",
+			message:     "Пользователю {actor} понравился ваш комментарий к публикации «{subject}»",
+			titleMany:   "Новые отметки «Нравится»",
+			messageMany: "Пользователям {actor} и ещё {others} понравился ваш комментарий к публикации «{subject}»",
+		},
+		domain.NotificationTypeFollow: {
+			title:       "Новый подписчик",
+			message:     "У вас новый подписчик: {actor}",
+			titleMany:   "Новые подписчики",
+			messageMany: "У вас новые подписчики: {actor} и ещё {others}",
+		},
+		domain.NotificationTypeFollowRequest: {
+			title:       "Запрос на подписку",
+			message:     "Пользователь {actor} хочет подписаться на вас",
+			titleMany:   "Запросы на подписку",
+			messageMany: "Пользователи {actor} и ещё {others} хотят подписаться на вас",
+		},
+		domain.NotificationTypeFollowApproved: {
+			title:       "Запрос на подписку принят",
+			message:     "Пользователь {actor} принял ваш запрос на подписку",
+			titleMany:   "Запросы на подписку приняты",
+			messageMany: "Пользователи {actor} и ещё {others} приняли ваши запросы на подписку",
+		},
+		domain.NotificationTypeCommunityInvite: {
+			title:       "Приглашение в сообщество",
+			message:     "Пользователь {actor} пригласил вас в сообщество «{subject}»",
+			titleMany:   "Приглашение в сообщество",
+			messageMany: "Пользователи {actor} и ещё {others} пригласили вас в сообщество «{subject}»",
+		},
+		domain.NotificationTypeCommunityJoinApproved: {
+			title:       "Заявка принята",
+			message:     "Вашу заявку на вступление в сообщество «{subject}» принял {actor}",
+			titleMany:   "Заявка принята",
+			messageMany: "Вашу заявку на вступление в сообщество «{subject}» приняли {actor} и ещё {others}",
+		},
+		domain.NotificationTypeSavedSearch: {
+			title:   "Новые публикации по сохранённому поиску",
+			message: "По запросу «{subject}» найдено новых публикаций: {count}",
+		},
+	},
+	"en": {
+		domain.NotificationTypeLike: {
+			title:       "New like on your publication",
+			message:     "{actor} liked your publication \"{subject}\"",
+			titleMany:   "New likes on your publication",
+			messageMany: "{actor} and {others} liked your publication \"{subject}\"",
+		},
+		domain.NotificationTypeComment: {
+			title:       "New comment on your publication",
+			message:     "{actor} commented on your publication \"{subject}\"",
+			titleMany:   "New comments on your publication",
+			messageMany: "{actor} and {others} commented on your publication \"{subject}\"",
+		},
+		domain.NotificationTypeReply: {
+			title:       "New reply to your comment",
+			message:     "{actor} replied to your comment on \"{subject}\"",
+			titleMany:   "New replies to your comment",
+			messageMany: "{actor} and {others} replied to your comment on \"{subject}\"",
+		},
+		domain.NotificationTypeCommentLike: {
+			title:       "New like on your comment",
+			message:     "{actor} liked your comment on \"{subject}\"",
+			titleMany:   "New likes on your comment",
+			messageMany: "{actor} and {others} liked your comment on \"{subject}\"",
+		},
+		domain.NotificationTypeFollow: {
+			title:       "New follower",
+			message:     "{actor} started following you",
+			titleMany:   "New followers",
+			messageMany: "{actor} and {others} started following you",
+		},
+		domain.NotificationTypeFollowRequest: {
+			title:       "New follow request",
+			message:     "{actor} wants to follow you",
+			titleMany:   "New follow requests",
+			messageMany: "{actor} and {others} want to follow you",
+		},
+		domain.NotificationTypeFollowApproved: {
+			title:       "Follow request approved",
+			message:     "{actor} approved your follow request",
+			titleMany:   "Follow requests approved",
+			messageMany: "{actor} and {others} approved your follow requests",
+		},
+		domain.NotificationTypeCommunityInvite: {
+			title:       "Community invitation",
+			message:     "{actor} invited you to the community “{subject}”",
+			titleMany:   "Community invitation",
+			messageMany: "{actor} and {others} invited you to the community “{subject}”",
+		},
+		domain.NotificationTypeCommunityJoinApproved: {
+			title:       "Join request approved",
+			message:     "{actor} approved your request to join the community “{subject}”",
+			titleMany:   "Join request approved",
+			messageMany: "{actor} and {others} approved your request to join the community “{subject}”",
+		},
+		domain.NotificationTypeSavedSearch: {
+			title:   "New publications for your saved search",
+			message: "New publications found for “{subject}”: {count}",
+		},
+	},
+}
+
+// render returns the localised title and message of a notification with actorsCount actors
+func render(locale string, notificationType domain.NotificationType, actor string, actorsCount int, subject string) (string, string) {
+	locale = supportedLocale(locale)
+	t := templates[locale][notificationType]
+
+	if actorsCount <= 1 {
+		replacer := strings.NewReplacer("{actor}", actor, "{subject}", subject)
+		return t.title, replacer.Replace(t.message)
+	}
+
+	replacer := strings.NewReplacer("{actor}", actor, "{others}", others(locale, actorsCount-1), "{subject}", subject)
+	return t.titleMany, replacer.Replace(t.messageMany)
+}
+
+// renderCount returns the localised title and message of a notification about count new items
+func renderCount(locale string, notificationType domain.NotificationType, count int, subject string) (string, string) {
+	t := templates[supportedLocale(locale)][notificationType]
+	replacer := strings.NewReplacer("{count}", strconv.Itoa(count), "{subject}", subject)
+	return t.title, replacer.Replace(t.message)
+}
+
+// supportedLocale falls back to DefaultLocale for locales without messages
+func supportedLocale(locale string) string {
+	if _, ok := templates[locale]; ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// others formats the number of the other actors
+func others(locale string, n int) string {
+	if locale == "en" {
+		if n == 1 {
+			return "1 other"
+		}
+		return strconv.Itoa(n) + " others"
+	}
+	return strconv.Itoa(n)
+}
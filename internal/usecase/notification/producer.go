@@ -0,0 +1,382 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// HandledEvents lists the event types the producer turns into notifications
+var HandledEvents = []domain.EventType{
+	domain.EventPublicationLiked,
+	domain.EventPublicationUnliked,
+	domain.EventCommentCreated,
+	domain.EventCommentLiked,
+	domain.EventCommentUnliked,
+	domain.EventUserFollowed,
+	domain.EventUserUnfollowed,
+	domain.EventFollowRequested,
+	domain.EventFollowApproved,
+	domain.EventCommunityInvited,
+	domain.EventCommunityJoinApproved,
+	domain.EventSavedSearchMatched,
+}
+
+// answerTypes answer the user's own requests, so they arrive whoever the actor is
+var answerTypes = map[domain.NotificationType]bool{
+	domain.NotificationTypeFollowApproved:        true,
+	domain.NotificationTypeCommunityJoinApproved: true,
+}
+
+// ProducerParams configures notification texts and grouping
+type ProducerParams struct {
+	Locale string
+	// AggregateWindow groups notifications of the same type about the same target
+	// created within the window into one item
+	AggregateWindow time.Duration
+}
+
+// DefaultProducerParams are used unless configured otherwise
+var DefaultProducerParams = ProducerParams{
+	Locale:          DefaultLocale,
+	AggregateWindow: 24 * time.Hour,
+}
+
+// Producer turns domain events into notifications for the users they concern
+type Producer struct {
+	notificationRepo domain.NotificationRepository
+	settingsRepo     domain.NotificationSettingsRepository
+	publicationRepo  domain.PublicationRepository
+	commentRepo      domain.CommentRepository
+	communityRepo    domain.CommunityRepository
+	savedSearchRepo  domain.SavedSearchRepository
+	userRepo         domain.UserRepository
+	blockRepo        domain.BlockRepository
+	params           ProducerParams
+}
+
+// NewProducer creates a new notification producer
+func NewProducer(
+	notificationRepo domain.NotificationRepository,
+	settingsRepo domain.NotificationSettingsRepository,
+	publicationRepo domain.PublicationRepository,
+	commentRepo domain.CommentRepository,
+	communityRepo domain.CommunityRepository,
+	savedSearchRepo domain.SavedSearchRepository,
+	userRepo domain.UserRepository,
+	blockRepo domain.BlockRepository,
+	params ProducerParams,
+) *Producer {
+	return &Producer{
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		publicationRepo:  publicationRepo,
+		commentRepo:      commentRepo,
+		communityRepo:    communityRepo,
+		savedSearchRepo:  savedSearchRepo,
+		userRepo:         userRepo,
+		blockRepo:        blockRepo,
+		params:           params,
+	}
+}
+
+// target is the user a notification goes to and the object it is about
+type target struct {
+	userID   string
+	targetID string
+	subject  string
+	data     map[string]interface{}
+}
+
+// Handle creates or withdraws notifications for an event
+func (p *Producer) Handle(ctx context.Context, event *domain.Event) error {
+	switch event.Type {
+	case domain.EventPublicationLiked, domain.EventPublicationUnliked:
+		t, err := p.publicationTarget(ctx, event.TargetID)
+		if err != nil {
+			return err
+		}
+		if event.Type == domain.EventPublicationUnliked {
+			return p.withdraw(ctx, event, domain.NotificationTypeLike, t)
+		}
+		return p.notify(ctx, event, domain.NotificationTypeLike, t)
+
+	case domain.EventCommentLiked, domain.EventCommentUnliked:
+		t, err := p.commentTarget(ctx, event.TargetID)
+		if err != nil {
+			return err
+		}
+		if event.Type == domain.EventCommentUnliked {
+			return p.withdraw(ctx, event, domain.NotificationTypeCommentLike, t)
+		}
+		return p.notify(ctx, event, domain.NotificationTypeCommentLike, t)
+
+	case domain.EventCommentCreated:
+		return p.handleCommentCreated(ctx, event)
+
+	case domain.EventUserFollowed, domain.EventUserUnfollowed:
+		t := &target{
+			userID:   event.TargetID,
+			targetID: event.TargetID,
+			data:     map[string]interface{}{"follower_id": event.ActorID},
+		}
+		if event.Type == domain.EventUserUnfollowed {
+			// Unfollowing also cancels a pending follow request
+			if err := p.withdraw(ctx, event, domain.NotificationTypeFollowRequest, t); err != nil {
+				return err
+			}
+			return p.withdraw(ctx, event, domain.NotificationTypeFollow, t)
+		}
+		return p.notify(ctx, event, domain.NotificationTypeFollow, t)
+
+	case domain.EventFollowRequested:
+		return p.notify(ctx, event, domain.NotificationTypeFollowRequest, &target{
+			userID:   event.TargetID,
+			targetID: event.TargetID,
+			data:     map[string]interface{}{"requester_id": event.ActorID},
+		})
+
+	case domain.EventFollowApproved:
+		// The requester learns about approvals of their requests, grouped
+		return p.notify(ctx, event, domain.NotificationTypeFollowApproved, &target{
+			userID:   event.TargetID,
+			targetID: event.TargetID,
+		})
+
+	case domain.EventCommunityInvited, domain.EventCommunityJoinApproved:
+		t, err := p.communityTarget(ctx, event)
+		if err != nil {
+			return err
+		}
+		if event.Type == domain.EventCommunityJoinApproved {
+			return p.notify(ctx, event, domain.NotificationTypeCommunityJoinApproved, t)
+		}
+		return p.notify(ctx, event, domain.NotificationTypeCommunityInvite, t)
+
+	case domain.EventSavedSearchMatched:
+		return p.handleSavedSearchMatched(ctx, event)
+	}
+
+	return nil
+}
+
+// handleCommentCreated notifies the author of the parent comment about a reply and
+// the publication author about a comment; a user who is both gets only the reply
+func (p *Producer) handleCommentCreated(ctx context.Context, event *domain.Event) error {
+	comment, err := p.commentRepo.GetByID(ctx, event.TargetID)
+	if err != nil {
+		return err
+	}
+	publication, err := p.publicationRepo.GetByID(ctx, comment.PublicationID)
+	if err != nil {
+		return err
+	}
+
+	replyTo := ""
+	if comment.ParentID != nil {
+		parent, err := p.commentRepo.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			return err
+		}
+		replyTo = parent.AuthorID
+		if err := p.notify(ctx, event, domain.NotificationTypeReply, &target{
+			userID:   parent.AuthorID,
+			targetID: parent.ID,
+			subject:  publication.Title,
+			data: map[string]interface{}{
+				"publication_id": publication.ID,
+				"comment_id":     comment.ID,
+				"parent_id":      parent.ID,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if publication.AuthorID == replyTo {
+		return nil
+	}
+	return p.notify(ctx, event, domain.NotificationTypeComment, &target{
+		userID:   publication.AuthorID,
+		targetID: publication.ID,
+		subject:  publication.Title,
+		data: map[string]interface{}{
+			"publication_id": publication.ID,
+			"comment_id":     comment.ID,
+		},
+	})
+}
+
+// handleSavedSearchMatched tells the owner of a saved search how many of its matches they have
+// not seen yet, keeping one unread notification per saved search
+func (p *Producer) handleSavedSearchMatched(ctx context.Context, event *domain.Event) error {
+	search, err := p.savedSearchRepo.GetByID(ctx, event.ActorID, event.TargetID)
+	if err != nil {
+		return err
+	}
+
+	settings, err := p.settingsRepo.Get(ctx, search.UserID)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled(domain.NotificationTypeSavedSearch, domain.NotificationChannelInApp) {
+		return nil
+	}
+
+	notification := &domain.Notification{
+		ID:     uuid.New().String(),
+		UserID: search.UserID,
+		Type:   domain.NotificationTypeSavedSearch,
+		Data: map[string]interface{}{
+			"target_id":       search.ID,
+			"saved_search_id": search.ID,
+			"new_count":       search.NewCount,
+		},
+		CreatedAt: time.Now(),
+	}
+	return p.notificationRepo.Aggregate(ctx, notification, p.params.AggregateWindow, func(n *domain.Notification) {
+		n.Title, n.Message = renderCount(p.params.Locale, n.Type, search.NewCount, search.Name)
+	})
+}
+
+func (p *Producer) publicationTarget(ctx context.Context, publicationID string) (*target, error) {
+	publication, err := p.publicationRepo.GetByID(ctx, publicationID)
+	if err != nil {
+		return nil, err
+	}
+	return &target{
+		userID:   publication.AuthorID,
+		targetID: publication.ID,
+		subject:  publication.Title,
+		data:     map[string]interface{}{"publication_id": publication.ID},
+	}, nil
+}
+
+func (p *Producer) commentTarget(ctx context.Context, commentID string) (*target, error) {
+	comment, err := p.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	publication, err := p.publicationRepo.GetByID(ctx, comment.PublicationID)
+	if err != nil {
+		return nil, err
+	}
+	return &target{
+		userID:   comment.AuthorID,
+		targetID: comment.ID,
+		subject:  publication.Title,
+		data: map[string]interface{}{
+			"publication_id": publication.ID,
+			"comment_id":     comment.ID,
+		},
+	}, nil
+}
+
+// communityTarget addresses the user the community event concerns
+func (p *Producer) communityTarget(ctx context.Context, event *domain.Event) (*target, error) {
+	community, err := p.communityRepo.GetByID(ctx, event.TargetID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &target{
+		userID:   event.UserID,
+		targetID: community.ID,
+		subject:  community.Name,
+		data:     map[string]interface{}{"community_id": community.ID},
+	}, nil
+}
+
+// notify adds the actor to the user's unread notification about the target, creating it when
+// there is none; the actor's own content, users who blocked each other, actors muted by the user
+// and notifications the user turned off in the settings are skipped
+func (p *Producer) notify(ctx context.Context, event *domain.Event, notificationType domain.NotificationType, t *target) error {
+	if t.userID == event.ActorID {
+		return nil
+	}
+
+	blocked, err := p.blockRepo.IsBlockedEither(ctx, t.userID, event.ActorID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return nil
+	}
+
+	// Muted actors never enter the notification, so they are neither counted nor named in it
+	muted, err := p.blockRepo.IsMuted(ctx, t.userID, event.ActorID)
+	if err != nil {
+		return err
+	}
+	if muted {
+		return nil
+	}
+
+	wanted, err := p.wanted(ctx, t.userID, event.ActorID, notificationType)
+	if err != nil {
+		return err
+	}
+	if !wanted {
+		return nil
+	}
+
+	actor, err := p.userRepo.GetByID(ctx, event.ActorID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{"target_id": t.targetID}
+	for key, value := range t.data {
+		data[key] = value
+	}
+	notification := &domain.Notification{
+		ID:        uuid.New().String(),
+		UserID:    t.userID,
+		Type:      notificationType,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+	notification.AddActor(domain.NotificationActor{ID: actor.ID, Username: actor.Username})
+
+	return p.notificationRepo.Aggregate(ctx, notification, p.params.AggregateWindow, p.renderer(t.subject))
+}
+
+// wanted checks the recipient's settings: the type must be on in the app and, when the recipient
+// accepts notifications only from people they follow, they must follow the actor unless the
+// notification answers their own request. Quiet hours and the other channels apply when stored
+// notifications are delivered
+func (p *Producer) wanted(ctx context.Context, userID, actorID string, notificationType domain.NotificationType) (bool, error) {
+	settings, err := p.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.Enabled(notificationType, domain.NotificationChannelInApp) {
+		return false, nil
+	}
+	if settings.FromFollowingOnly && !answerTypes[notificationType] {
+		return p.userRepo.IsFollowing(ctx, userID, actorID)
+	}
+	return true, nil
+}
+
+// withdraw removes the actor of an undone action from the unread notification, so that
+// a like followed by an unlike leaves nothing behind
+func (p *Producer) withdraw(ctx context.Context, event *domain.Event, notificationType domain.NotificationType, t *target) error {
+	if t.userID == event.ActorID {
+		return nil
+	}
+	return p.notificationRepo.RemoveActor(ctx, t.userID, notificationType, t.targetID, event.ActorID, p.renderer(t.subject))
+}
+
+// renderer sets localised title and message of a notification from its actors
+func (p *Producer) renderer(subject string) func(*domain.Notification) {
+	return func(n *domain.Notification) {
+		name := ""
+		if actors := n.Actors(); len(actors) > 0 {
+			name = actors[0].Username
+		}
+		n.Title, n.Message = render(p.params.Locale, n.Type, name, n.ActorsCount(), subject)
+	}
+}
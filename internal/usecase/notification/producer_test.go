@@ -0,0 +1,395 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	notificationRepo *mocks.MockNotificationRepository
+	settingsRepo     *mocks.MockNotificationSettingsRepository
+	publicationRepo  *mocks.MockPublicationRepository
+	commentRepo      *mocks.MockCommentRepository
+	communityRepo    *mocks.MockCommunityRepository
+	savedSearchRepo  *mocks.MockSavedSearchRepository
+	userRepo         *mocks.MockUserRepository
+	blockRepo        *mocks.MockBlockRepository
+}
+
+func newTestProducer(ctrl *gomock.Controller, locale string) (*Producer, *testDeps) {
+	deps := &testDeps{
+		notificationRepo: mocks.NewMockNotificationRepository(ctrl),
+		settingsRepo:     mocks.NewMockNotificationSettingsRepository(ctrl),
+		publicationRepo:  mocks.NewMockPublicationRepository(ctrl),
+		commentRepo:      mocks.NewMockCommentRepository(ctrl),
+		communityRepo:    mocks.NewMockCommunityRepository(ctrl),
+		savedSearchRepo:  mocks.NewMockSavedSearchRepository(ctrl),
+		userRepo:         mocks.NewMockUserRepository(ctrl),
+		blockRepo:        mocks.NewMockBlockRepository(ctrl),
+	}
+	params := DefaultProducerParams
+	params.Locale = locale
+	p := NewProducer(deps.notificationRepo, deps.settingsRepo, deps.publicationRepo, deps.commentRepo, deps.communityRepo, deps.savedSearchRepo, deps.userRepo, deps.blockRepo, params)
+	return p, deps
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestHandle_PublicationLiked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "pub-1").
+		Return(&domain.Publication{ID: "pub-1", AuthorID: "author", Title: "Цитата"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "author", "anna").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "author", "anna").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "author").Return(domain.DefaultNotificationSettings("author"), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "anna").Return(&domain.User{ID: "anna", Username: "anna"}, nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), 24*time.Hour, gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			render(n)
+			assert.Equal(t, "author", n.UserID)
+			assert.Equal(t, domain.NotificationTypeLike, n.Type)
+			assert.Equal(t, "Пользователю anna понравилась ваша публикация «Цитата»", n.Message)
+			assert.Equal(t, "anna", n.Data["actor_id"])
+			assert.Equal(t, "pub-1", n.TargetID())
+			assert.Equal(t, "pub-1", n.Data["publication_id"])
+			assert.Equal(t, 1, n.ActorsCount())
+			return nil
+		})
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventPublicationLiked, "anna", "pub-1"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_LikesAggregated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "pub-1").
+		Return(&domain.Publication{ID: "pub-1", AuthorID: "author", Title: "Цитата"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "author", "boris").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "author", "boris").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "author").Return(domain.DefaultNotificationSettings("author"), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "boris").Return(&domain.User{ID: "boris", Username: "boris"}, nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			// Simulate merging into an unread notification with two earlier likes
+			existing := &domain.Notification{Type: n.Type, Data: map[string]interface{}{"target_id": "pub-1"}}
+			existing.AddActor(domain.NotificationActor{ID: "anna", Username: "anna"})
+			existing.AddActor(domain.NotificationActor{ID: "vera", Username: "vera"})
+			for _, actor := range n.Actors() {
+				assert.True(t, existing.AddActor(actor))
+			}
+			render(existing)
+
+			assert.Equal(t, 3, existing.ActorsCount())
+			assert.Equal(t, "boris", existing.Actors()[0].Username)
+			assert.Equal(t, "Новые отметки «Нравится»", existing.Title)
+			assert.Equal(t, "Пользователям boris и ещё 2 понравилась ваша публикация «Цитата»", existing.Message)
+			return nil
+		})
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventPublicationLiked, "boris", "pub-1"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_SelfLikeSkipped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "pub-1").
+		Return(&domain.Publication{ID: "pub-1", AuthorID: "author"}, nil)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventPublicationLiked, "author", "pub-1"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_UnlikeWithdrawsNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "pub-1").
+		Return(&domain.Publication{ID: "pub-1", AuthorID: "author", Title: "Quote"}, nil)
+	deps.notificationRepo.EXPECT().RemoveActor(gomock.Any(), "author", domain.NotificationTypeLike, "pub-1", "anna", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ domain.NotificationType, _, _ string, render func(*domain.Notification)) error {
+			// The remaining actor is rendered back to the single form
+			n := &domain.Notification{Type: domain.NotificationTypeLike}
+			n.AddActor(domain.NotificationActor{ID: "vera", Username: "vera"})
+			n.AddActor(domain.NotificationActor{ID: "anna", Username: "anna"})
+			require.True(t, n.RemoveActor("anna"))
+			render(n)
+			assert.Equal(t, "Пользователю vera понравилась ваша публикация «Quote»", n.Message)
+			assert.Equal(t, "vera", n.Data["actor_id"])
+			return nil
+		})
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventPublicationUnliked, "anna", "pub-1"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_BlockedActorSkipped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "bob", "anna").Return(true, nil)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventUserFollowed, "anna", "bob"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_MutedActorSkipped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "pub-1").
+		Return(&domain.Publication{ID: "pub-1", AuthorID: "author", Title: "Стоицизм"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "author", "anna").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "author", "anna").Return(true, nil)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventPublicationLiked, "anna", "pub-1"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_ReplyToPublicationAuthorNotifiedOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "en")
+
+	deps.commentRepo.EXPECT().GetByID(gomock.Any(), "c-2").
+		Return(&domain.Comment{ID: "c-2", PublicationID: "pub-1", ParentID: strPtr("c-1"), AuthorID: "anna"}, nil)
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "pub-1").
+		Return(&domain.Publication{ID: "pub-1", AuthorID: "author", Title: "Quote"}, nil)
+	deps.commentRepo.EXPECT().GetByID(gomock.Any(), "c-1").
+		Return(&domain.Comment{ID: "c-1", PublicationID: "pub-1", AuthorID: "author"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "author", "anna").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "author", "anna").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "author").Return(domain.DefaultNotificationSettings("author"), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "anna").Return(&domain.User{ID: "anna", Username: "anna"}, nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			render(n)
+			assert.Equal(t, domain.NotificationTypeReply, n.Type)
+			assert.Equal(t, "c-1", n.TargetID())
+			assert.Equal(t, "New reply to your comment", n.Title)
+			assert.Equal(t, "anna replied to your comment on \"Quote\"", n.Message)
+			return nil
+		}).Times(1)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventCommentCreated, "anna", "c-2"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_TypeTurnedOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	settings := domain.DefaultNotificationSettings("bob")
+	settings.Types[domain.NotificationTypeFollow] = domain.NotificationChannels{InApp: false, Email: true, Push: true}
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "bob", "anna").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "bob", "anna").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "bob").Return(settings, nil)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventUserFollowed, "anna", "bob"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_FromFollowingOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	settings := domain.DefaultNotificationSettings("bob")
+	settings.FromFollowingOnly = true
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "bob", "anna").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "bob", "anna").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "bob").Return(settings, nil)
+	deps.userRepo.EXPECT().IsFollowing(gomock.Any(), "bob", "anna").Return(false, nil)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventUserFollowed, "anna", "bob"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_FollowRequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "en")
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "bob", "anna").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "bob", "anna").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "bob").Return(domain.DefaultNotificationSettings("bob"), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "anna").Return(&domain.User{ID: "anna", Username: "anna"}, nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			render(n)
+			assert.Equal(t, "bob", n.UserID)
+			assert.Equal(t, domain.NotificationTypeFollowRequest, n.Type)
+			assert.Equal(t, "anna", n.Data["requester_id"])
+			assert.Contains(t, n.Message, "anna")
+			return nil
+		})
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventFollowRequested, "anna", "bob"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_FollowApprovedIgnoresFromFollowingOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	// anna asked to follow bob, so she does not follow him yet but still hears the answer
+	settings := domain.DefaultNotificationSettings("anna")
+	settings.FromFollowingOnly = true
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "anna", "bob").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "anna", "bob").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "anna").Return(settings, nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "bob").Return(&domain.User{ID: "bob", Username: "bob"}, nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			render(n)
+			assert.Equal(t, "anna", n.UserID)
+			assert.Equal(t, domain.NotificationTypeFollowApproved, n.Type)
+			assert.Equal(t, "Пользователь bob принял ваш запрос на подписку", n.Message)
+			return nil
+		})
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventFollowApproved, "bob", "anna"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_CommunityInvited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.communityRepo.EXPECT().GetByID(gomock.Any(), "c-1", nil).
+		Return(&domain.Community{ID: "c-1", Name: "Стоики"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "anna", "mod").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "anna", "mod").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "anna").Return(domain.DefaultNotificationSettings("anna"), nil)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "mod").Return(&domain.User{ID: "mod", Username: "mod"}, nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			render(n)
+			assert.Equal(t, "anna", n.UserID)
+			assert.Equal(t, domain.NotificationTypeCommunityInvite, n.Type)
+			assert.Equal(t, "c-1", n.Data["community_id"])
+			assert.Equal(t, "Пользователь mod пригласил вас в сообщество «Стоики»", n.Message)
+			return nil
+		})
+
+	event := domain.NewEvent(domain.EventCommunityInvited, "mod", "c-1")
+	event.UserID = "anna"
+	err := p.Handle(context.Background(), event)
+
+	require.NoError(t, err)
+}
+
+func TestHandle_CommunityJoinApprovedTurnedOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	settings := domain.DefaultNotificationSettings("anna")
+	settings.Types[domain.NotificationTypeCommunityJoinApproved] = domain.NotificationChannels{InApp: false}
+
+	deps.communityRepo.EXPECT().GetByID(gomock.Any(), "c-1", nil).
+		Return(&domain.Community{ID: "c-1", Name: "Стоики"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "anna", "mod").Return(false, nil)
+	deps.blockRepo.EXPECT().IsMuted(gomock.Any(), "anna", "mod").Return(false, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "anna").Return(settings, nil)
+
+	event := domain.NewEvent(domain.EventCommunityJoinApproved, "mod", "c-1")
+	event.UserID = "anna"
+	err := p.Handle(context.Background(), event)
+
+	require.NoError(t, err)
+}
+
+func TestHandle_SavedSearchMatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	deps.savedSearchRepo.EXPECT().GetByID(gomock.Any(), "alice", "s1").
+		Return(&domain.SavedSearch{ID: "s1", UserID: "alice", Name: "Стоицизм", Notify: true, NewCount: 4}, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "alice").Return(domain.DefaultNotificationSettings("alice"), nil)
+	deps.notificationRepo.EXPECT().Aggregate(gomock.Any(), gomock.Any(), 24*time.Hour, gomock.Any()).
+		DoAndReturn(func(_ context.Context, n *domain.Notification, _ time.Duration, render func(*domain.Notification)) error {
+			render(n)
+			assert.Equal(t, "alice", n.UserID)
+			assert.Equal(t, domain.NotificationTypeSavedSearch, n.Type)
+			assert.Equal(t, "s1", n.TargetID())
+			assert.Equal(t, 4, n.Data["new_count"])
+			assert.Equal(t, "По запросу «Стоицизм» найдено новых публикаций: 4", n.Message)
+			return nil
+		})
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventSavedSearchMatched, "alice", "s1"))
+
+	require.NoError(t, err)
+}
+
+func TestHandle_SavedSearchTurnedOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, deps := newTestProducer(ctrl, "ru")
+
+	settings := domain.DefaultNotificationSettings("alice")
+	settings.Types[domain.NotificationTypeSavedSearch] = domain.NotificationChannels{InApp: false}
+
+	deps.savedSearchRepo.EXPECT().GetByID(gomock.Any(), "alice", "s1").
+		Return(&domain.SavedSearch{ID: "s1", UserID: "alice", Name: "Стоицизм", Notify: true, NewCount: 4}, nil)
+	deps.settingsRepo.EXPECT().Get(gomock.Any(), "alice").Return(settings, nil)
+
+	err := p.Handle(context.Background(), domain.NewEvent(domain.EventSavedSearchMatched, "alice", "s1"))
+
+	require.NoError(t, err)
+}
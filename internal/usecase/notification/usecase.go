@@ -2,29 +2,81 @@ package notification
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"sense-backend/internal/domain"
 )
 
+var (
+	// ErrUnknownNotificationType is returned when settings name a type users cannot configure
+	ErrUnknownNotificationType = errors.New("unknown notification type")
+	// ErrInvalidTimeZone is returned when settings name an unknown time zone
+	ErrInvalidTimeZone = errors.New("invalid time zone")
+	// ErrInvalidQuietHours is returned when quiet hours are not a pair of different HH:MM times
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+)
+
 // UseCase handles notification use cases
 type UseCase struct {
 	notificationRepo domain.NotificationRepository
+	settingsRepo     domain.NotificationSettingsRepository
 }
 
 // NewUseCase creates a new notification use case
-func NewUseCase(notificationRepo domain.NotificationRepository) *UseCase {
+func NewUseCase(notificationRepo domain.NotificationRepository, settingsRepo domain.NotificationSettingsRepository) *UseCase {
 	return &UseCase{
 		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
 	}
 }
 
+// ChannelsRequest represents channel toggles of one notification type; omitted channels are kept
+type ChannelsRequest struct {
+	InApp *bool `json:"in_app,omitempty"`
+	Email *bool `json:"email,omitempty"`
+	Push  *bool `json:"push,omitempty"`
+}
+
+// QuietHoursRequest represents quiet hours; empty start and end turn them off
+type QuietHoursRequest struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// SettingsRequest represents update notification settings request; omitted fields are kept
+type SettingsRequest struct {
+	Types             map[domain.NotificationType]*ChannelsRequest `json:"types,omitempty"`
+	TimeZone          *string                                      `json:"time_zone,omitempty" validate:"omitempty,max=64"`
+	QuietHours        *QuietHoursRequest                           `json:"quiet_hours,omitempty"`
+	FromFollowingOnly *bool                                        `json:"from_following_only,omitempty"`
+	Digest            *domain.DigestFrequency                      `json:"digest,omitempty" validate:"omitempty,oneof=off daily weekly"`
+}
+
 // GetByUser retrieves notifications for a user
 func (uc *UseCase) GetByUser(ctx context.Context, userID string, unreadOnly bool, limit, offset int) ([]*domain.Notification, int, error) {
 	return uc.notificationRepo.GetByUser(ctx, userID, unreadOnly, limit, offset)
 }
 
-// MarkAsRead marks a notification as read
-func (uc *UseCase) MarkAsRead(ctx context.Context, notificationID string) error {
-	return uc.notificationRepo.MarkAsRead(ctx, notificationID)
+// MarkAsRead marks a notification of the user as read
+func (uc *UseCase) MarkAsRead(ctx context.Context, userID, notificationID string) error {
+	return uc.notificationRepo.MarkAsRead(ctx, userID, notificationID)
+}
+
+// MarkAllAsRead marks all notifications of the user as read
+func (uc *UseCase) MarkAllAsRead(ctx context.Context, userID string) error {
+	return uc.notificationRepo.MarkAllAsRead(ctx, userID)
+}
+
+// Delete deletes a notification of the user
+func (uc *UseCase) Delete(ctx context.Context, userID, notificationID string) error {
+	return uc.notificationRepo.Delete(ctx, userID, notificationID)
+}
+
+// CountUnread returns the number of unread notifications of the user
+func (uc *UseCase) CountUnread(ctx context.Context, userID string) (int, error) {
+	return uc.notificationRepo.CountUnread(ctx, userID)
 }
 
 // Create creates a new notification
@@ -32,3 +84,86 @@ func (uc *UseCase) Create(ctx context.Context, notification *domain.Notification
 	return uc.notificationRepo.Create(ctx, notification)
 }
 
+
+// GetSettings retrieves user's notification settings
+func (uc *UseCase) GetSettings(ctx context.Context, userID string) (*domain.NotificationSettings, error) {
+	return uc.settingsRepo.Get(ctx, userID)
+}
+
+// UpdateSettings updates user's notification settings
+func (uc *UseCase) UpdateSettings(ctx context.Context, userID string, req *SettingsRequest) (*domain.NotificationSettings, error) {
+	settings, err := uc.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for notificationType, toggles := range req.Types {
+		if !domain.IsConfigurableNotificationType(notificationType) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationType, notificationType)
+		}
+		if toggles == nil {
+			continue
+		}
+		channels := settings.Types[notificationType]
+		if toggles.InApp != nil {
+			channels.InApp = *toggles.InApp
+		}
+		if toggles.Email != nil {
+			channels.Email = *toggles.Email
+		}
+		if toggles.Push != nil {
+			channels.Push = *toggles.Push
+		}
+		settings.Types[notificationType] = channels
+	}
+
+	if req.TimeZone != nil {
+		// An empty name would load as UTC silently, so it is rejected like unknown names
+		if _, err := time.LoadLocation(*req.TimeZone); err != nil || *req.TimeZone == "" {
+			return nil, ErrInvalidTimeZone
+		}
+		settings.TimeZone = *req.TimeZone
+	}
+
+	if req.QuietHours != nil {
+		quietHours, err := parseQuietHours(req.QuietHours)
+		if err != nil {
+			return nil, err
+		}
+		settings.QuietHours = quietHours
+	}
+
+	if req.FromFollowingOnly != nil {
+		settings.FromFollowingOnly = *req.FromFollowingOnly
+	}
+
+	if req.Digest != nil {
+		settings.Digest = *req.Digest
+	}
+
+	if err := uc.settingsRepo.Update(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+	return settings, nil
+}
+
+// parseQuietHours validates quiet hours, returning nil when they are turned off
+func parseQuietHours(req *QuietHoursRequest) (*domain.QuietHours, error) {
+	if req.Start == "" && req.End == "" {
+		return nil, nil
+	}
+
+	start, err := time.Parse("15:04", req.Start)
+	if err != nil {
+		return nil, ErrInvalidQuietHours
+	}
+	end, err := time.Parse("15:04", req.End)
+	if err != nil || start.Equal(end) {
+		return nil, ErrInvalidQuietHours
+	}
+
+	return &domain.QuietHours{
+		Start: start.Format("15:04"),
+		End:   end.Format("15:04"),
+	}, nil
+}
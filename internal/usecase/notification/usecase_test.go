@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMarkAsRead_ScopedToUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := NewUseCase(notificationRepo, mocks.NewMockNotificationSettingsRepository(ctrl))
+
+	notificationRepo.EXPECT().MarkAsRead(gomock.Any(), "user-2", "notif-1").Return(domain.ErrNotificationNotFound)
+
+	err := uc.MarkAsRead(context.Background(), "user-2", "notif-1")
+
+	assert.ErrorIs(t, err, domain.ErrNotificationNotFound)
+}
+
+func TestDelete_ScopedToUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := NewUseCase(notificationRepo, mocks.NewMockNotificationSettingsRepository(ctrl))
+
+	notificationRepo.EXPECT().Delete(gomock.Any(), "user-1", "notif-1").Return(nil)
+
+	err := uc.Delete(context.Background(), "user-1", "notif-1")
+
+	require.NoError(t, err)
+}
+
+func TestCountUnread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := NewUseCase(notificationRepo, mocks.NewMockNotificationSettingsRepository(ctrl))
+
+	notificationRepo.EXPECT().CountUnread(gomock.Any(), "user-1").Return(4, nil)
+
+	count, err := uc.CountUnread(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
+
+func TestUpdateSettings_MergesToggles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	settingsRepo := mocks.NewMockNotificationSettingsRepository(ctrl)
+	uc := NewUseCase(mocks.NewMockNotificationRepository(ctrl), settingsRepo)
+
+	off := false
+	zone := "Europe/Minsk"
+	settingsRepo.EXPECT().Get(gomock.Any(), "user-1").Return(domain.DefaultNotificationSettings("user-1"), nil)
+	settingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	settings, err := uc.UpdateSettings(context.Background(), "user-1", &SettingsRequest{
+		Types:      map[domain.NotificationType]*ChannelsRequest{domain.NotificationTypeLike: {Push: &off}},
+		TimeZone:   &zone,
+		QuietHours: &QuietHoursRequest{Start: "22:00", End: "7:30"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.NotificationChannels{InApp: true, Email: true, Push: false}, settings.Types[domain.NotificationTypeLike])
+	assert.True(t, settings.Enabled(domain.NotificationTypeComment, domain.NotificationChannelPush))
+	assert.Equal(t, &domain.QuietHours{Start: "22:00", End: "07:30"}, settings.QuietHours)
+
+	// 23:30 in Minsk (UTC+3) falls within quiet hours spanning midnight, 12:00 does not
+	assert.True(t, settings.InQuietHours(time.Date(2025, 6, 1, 20, 30, 0, 0, time.UTC)))
+	assert.False(t, settings.InQuietHours(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestUpdateSettings_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	settingsRepo := mocks.NewMockNotificationSettingsRepository(ctrl)
+	uc := NewUseCase(mocks.NewMockNotificationRepository(ctrl), settingsRepo)
+
+	settingsRepo.EXPECT().Get(gomock.Any(), "user-1").Return(domain.DefaultNotificationSettings("user-1"), nil).Times(3)
+
+	on := true
+	_, err := uc.UpdateSettings(context.Background(), "user-1", &SettingsRequest{
+		Types: map[domain.NotificationType]*ChannelsRequest{"unknown": {InApp: &on}},
+	})
+	assert.ErrorIs(t, err, ErrUnknownNotificationType)
+
+	zone := "Mars/Olympus"
+	_, err = uc.UpdateSettings(context.Background(), "user-1", &SettingsRequest{TimeZone: &zone})
+	assert.ErrorIs(t, err, ErrInvalidTimeZone)
+
+	_, err = uc.UpdateSettings(context.Background(), "user-1", &SettingsRequest{QuietHours: &QuietHoursRequest{Start: "22:00", End: "22:00"}})
+	assert.ErrorIs(t, err, ErrInvalidQuietHours)
+}
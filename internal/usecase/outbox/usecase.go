@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// Params configures dispatching
+type Params struct {
+	// BatchSize is the number of messages locked at a time
+	BatchSize int
+	// Retention is how long processed messages are kept
+	Retention time.Duration
+	// CleanupInterval is how often processed messages past retention are deleted
+	CleanupInterval time.Duration
+	// MaxAttempts is the number of failed dispatches after which a message is given up on;
+	// failed messages are kept for inspection
+	MaxAttempts int
+}
+
+// DefaultParams keep processed messages for a day and give up on a message after 10 failures
+var DefaultParams = Params{
+	BatchSize:       100,
+	Retention:       24 * time.Hour,
+	CleanupInterval: time.Hour,
+	MaxAttempts:     10,
+}
+
+// UseCase publishes domain events written to the transactional outbox
+type UseCase struct {
+	outboxRepo domain.OutboxRepository
+	dispatcher domain.EventDispatcher
+	params     Params
+}
+
+// NewUseCase creates a new outbox use case; dispatcher calls the registered event handlers
+func NewUseCase(outboxRepo domain.OutboxRepository, dispatcher domain.EventDispatcher, params Params) *UseCase {
+	return &UseCase{
+		outboxRepo: outboxRepo,
+		dispatcher: dispatcher,
+		params:     params,
+	}
+}
+
+// DispatchPending dispatches due messages batch by batch and returns how many were handled.
+// A message is marked processed only when every handler succeeded; otherwise all of them run
+// again later, up to MaxAttempts times, so handlers must tolerate duplicates: Event.ID is the
+// same on every run. Handler failures are returned joined
+func (uc *UseCase) DispatchPending(ctx context.Context) (int, error) {
+	var (
+		handled int
+		failed  []error
+	)
+	handle := func(ctx context.Context, message *domain.OutboxMessage) error {
+		if err := uc.dispatcher.Dispatch(ctx, message.Event); err != nil {
+			attempt := message.Attempts + 1
+			if attempt >= uc.params.MaxAttempts {
+				failed = append(failed, fmt.Errorf("outbox message %d (attempt %d, giving up): %w", message.ID, attempt, err))
+			} else {
+				failed = append(failed, fmt.Errorf("outbox message %d (attempt %d): %w", message.ID, attempt, err))
+			}
+			return err
+		}
+		return nil
+	}
+
+	for {
+		n, err := uc.outboxRepo.Process(ctx, uc.params.BatchSize, uc.params.MaxAttempts, handle)
+		if err != nil {
+			return handled, fmt.Errorf("failed to process outbox: %w", err)
+		}
+		handled += n
+		// Failed messages are rescheduled, so a full batch means more may be due
+		if n < uc.params.BatchSize || ctx.Err() != nil {
+			return handled, errors.Join(append(failed, ctx.Err())...)
+		}
+	}
+}
+
+// Cleanup deletes messages processed longer than the retention period before now
+func (uc *UseCase) Cleanup(ctx context.Context, now time.Time) (int64, error) {
+	deleted, err := uc.outboxRepo.DeleteProcessed(ctx, now.Add(-uc.params.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up outbox: %w", err)
+	}
+	return deleted, nil
+}
+
+// RunDispatcher dispatches pending messages every interval and cleans up processed ones every
+// CleanupInterval, until ctx is cancelled
+func (uc *UseCase) RunDispatcher(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var cleanedAt time.Time
+	for {
+		if _, err := uc.DispatchPending(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+
+		if now := time.Now(); now.Sub(cleanedAt) >= uc.params.CleanupInterval {
+			if _, err := uc.Cleanup(ctx, now); err != nil && ctx.Err() == nil && onError != nil {
+				onError(err)
+			}
+			cleanedAt = now
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
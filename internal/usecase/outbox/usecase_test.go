@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	outboxRepo *mocks.MockOutboxRepository
+	dispatcher *mocks.MockEventDispatcher
+}
+
+func newTestUseCase(ctrl *gomock.Controller, params Params) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		outboxRepo: mocks.NewMockOutboxRepository(ctrl),
+		dispatcher: mocks.NewMockEventDispatcher(ctrl),
+	}
+	return NewUseCase(deps.outboxRepo, deps.dispatcher, params), deps
+}
+
+// processMessages makes the repository mock hand messages to the handler and record its results
+func processMessages(messages []*domain.OutboxMessage, results map[int64]error) func(context.Context, int, int, func(context.Context, *domain.OutboxMessage) error) (int, error) {
+	return func(ctx context.Context, _, _ int, handle func(context.Context, *domain.OutboxMessage) error) (int, error) {
+		for _, message := range messages {
+			results[message.ID] = handle(ctx, message)
+		}
+		return len(messages), nil
+	}
+}
+
+func TestDispatchPending_DispatchesEachMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl, DefaultParams)
+	liked := domain.NewEvent(domain.EventPublicationLiked, "bob", "pub-1")
+	created := domain.NewEvent(domain.EventPublicationCreated, "alice", "pub-2")
+	results := map[int64]error{}
+
+	deps.outboxRepo.EXPECT().Process(gomock.Any(), DefaultParams.BatchSize, DefaultParams.MaxAttempts, gomock.Any()).
+		DoAndReturn(processMessages([]*domain.OutboxMessage{{ID: 1, Event: liked}, {ID: 2, Event: created}}, results))
+	gomock.InOrder(
+		deps.dispatcher.EXPECT().Dispatch(gomock.Any(), liked).Return(nil),
+		deps.dispatcher.EXPECT().Dispatch(gomock.Any(), created).Return(nil),
+	)
+
+	handled, err := uc.DispatchPending(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, handled)
+	assert.NoError(t, results[1])
+	assert.NoError(t, results[2])
+}
+
+func TestDispatchPending_FailedMessageIsRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl, DefaultParams)
+	event := domain.NewEvent(domain.EventPublicationLiked, "bob", "pub-1")
+	results := map[int64]error{}
+	handlerErr := errors.New("notification store unavailable")
+
+	deps.outboxRepo.EXPECT().Process(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(processMessages([]*domain.OutboxMessage{{ID: 7, Event: event, Attempts: 2}}, results))
+	deps.dispatcher.EXPECT().Dispatch(gomock.Any(), event).Return(handlerErr)
+
+	handled, err := uc.DispatchPending(context.Background())
+
+	assert.Equal(t, 1, handled)
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Contains(t, err.Error(), "outbox message 7 (attempt 3)")
+	// The repository sees the failure and reschedules the message instead of marking it processed
+	assert.ErrorIs(t, results[7], handlerErr)
+}
+
+func TestDispatchPending_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl, DefaultParams)
+	event := domain.NewEvent(domain.EventPublicationLiked, "bob", "pub-1")
+	results := map[int64]error{}
+	handlerErr := errors.New("malformed event")
+
+	deps.outboxRepo.EXPECT().Process(gomock.Any(), DefaultParams.BatchSize, DefaultParams.MaxAttempts, gomock.Any()).
+		DoAndReturn(processMessages([]*domain.OutboxMessage{{ID: 9, Event: event, Attempts: DefaultParams.MaxAttempts - 1}}, results))
+	deps.dispatcher.EXPECT().Dispatch(gomock.Any(), event).Return(handlerErr)
+
+	_, err := uc.DispatchPending(context.Background())
+
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Contains(t, err.Error(), "outbox message 9 (attempt 10, giving up)")
+}
+
+func TestDispatchPending_DrainsFullBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl, Params{BatchSize: 2})
+	event := domain.NewEvent(domain.EventPublicationCreated, "alice", "pub-1")
+	results := map[int64]error{}
+
+	gomock.InOrder(
+		deps.outboxRepo.EXPECT().Process(gomock.Any(), 2, gomock.Any(), gomock.Any()).
+			DoAndReturn(processMessages([]*domain.OutboxMessage{{ID: 1, Event: event}, {ID: 2, Event: event}}, results)),
+		deps.outboxRepo.EXPECT().Process(gomock.Any(), 2, gomock.Any(), gomock.Any()).
+			DoAndReturn(processMessages([]*domain.OutboxMessage{{ID: 3, Event: event}}, results)),
+	)
+	deps.dispatcher.EXPECT().Dispatch(gomock.Any(), event).Return(nil).Times(3)
+
+	handled, err := uc.DispatchPending(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, handled)
+}
+
+func TestCleanup_KeepsRetentionPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl, DefaultParams)
+	now := time.Now()
+
+	deps.outboxRepo.EXPECT().DeleteProcessed(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(5), nil)
+
+	deleted, err := uc.Cleanup(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+}
@@ -9,18 +9,31 @@ import (
 
 // UseCase handles profile use cases
 type UseCase struct {
-	userRepo domain.UserRepository
+	userRepo  domain.UserRepository
+	blockRepo domain.BlockRepository
+	events    domain.EventPublisher
 }
 
-// NewUseCase creates a new profile use case
-func NewUseCase(userRepo domain.UserRepository) *UseCase {
-	return &UseCase{userRepo: userRepo}
+// ErrProfileHidden is returned when the profile owner and the viewer blocked each other
+var ErrProfileHidden = errors.New("profile not found")
+
+// ErrProfilePrivate is returned when connections of a private account are requested by a non-follower
+var ErrProfilePrivate = errors.New("profile is private")
+
+// NewUseCase creates a new profile use case; events may be nil
+func NewUseCase(userRepo domain.UserRepository, blockRepo domain.BlockRepository, events domain.EventPublisher) *UseCase {
+	return &UseCase{
+		userRepo:  userRepo,
+		blockRepo: blockRepo,
+		events:    events,
+	}
 }
 
 // UpdateRequest represents update profile request
 type UpdateRequest struct {
 	Description *string `json:"description,omitempty" validate:"max=500"`
 	IconURL     *string `json:"icon_url,omitempty"`
+	IsPrivate   *bool   `json:"is_private,omitempty"`
 }
 
 // GetProfile retrieves user profile
@@ -40,6 +53,21 @@ func (uc *UseCase) GetProfile(ctx context.Context, userID string) (*domain.User,
 	return user, nil
 }
 
+// GetVisibleProfile retrieves user profile as seen by viewer (empty for anonymous);
+// profiles are hidden between users who blocked each other
+func (uc *UseCase) GetVisibleProfile(ctx context.Context, userID, viewerUserID string) (*domain.User, error) {
+	if viewerUserID != "" && viewerUserID != userID {
+		blocked, err := uc.blockRepo.IsBlockedEither(ctx, viewerUserID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrProfileHidden
+		}
+	}
+	return uc.GetProfile(ctx, userID)
+}
+
 // UpdateProfile updates user profile
 func (uc *UseCase) UpdateProfile(ctx context.Context, userID string, req *UpdateRequest) (*domain.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, userID)
@@ -53,11 +81,23 @@ func (uc *UseCase) UpdateProfile(ctx context.Context, userID string, req *Update
 	if req.IconURL != nil {
 		user.IconURL = req.IconURL
 	}
+	becamePublic := false
+	if req.IsPrivate != nil {
+		becamePublic = user.IsPrivate && !*req.IsPrivate
+		user.IsPrivate = *req.IsPrivate
+	}
 
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	// A public account has no pending requests: everyone waiting becomes a follower
+	if becamePublic {
+		if err := uc.userRepo.ApproveAllFollowRequests(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to approve follow requests: %w", err)
+		}
+	}
+
 	user.PasswordHash = ""
 	return user, nil
 }
@@ -67,27 +107,171 @@ func (uc *UseCase) GetStats(ctx context.Context, userID string) (*domain.UserSta
 	return uc.userRepo.GetStats(ctx, userID)
 }
 
-// Follow follows a user
-func (uc *UseCase) Follow(ctx context.Context, followerID, followingID string) error {
+// Follow follows a user; following a private account creates a pending request instead
+func (uc *UseCase) Follow(ctx context.Context, followerID, followingID string) (domain.FollowStatus, error) {
 	if followerID == followingID {
-		return errors.New("cannot follow yourself")
+		return "", errors.New("cannot follow yourself")
 	}
 	// Check if user exists
-	_, err := uc.userRepo.GetByID(ctx, followingID)
+	target, err := uc.userRepo.GetByID(ctx, followingID)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if !target.IsPrivate {
+		if err := uc.userRepo.Follow(ctx, followerID, followingID); err != nil {
+			return "", err
+		}
+		uc.emit(ctx, domain.EventUserFollowed, followerID, followingID)
+		return domain.FollowStatusFollowing, nil
 	}
-	return uc.userRepo.Follow(ctx, followerID, followingID)
+
+	following, err := uc.userRepo.IsFollowing(ctx, followerID, followingID)
+	if err != nil {
+		return "", err
+	}
+	if following {
+		return domain.FollowStatusFollowing, nil
+	}
+
+	// Repeated requests neither duplicate the request nor notify again
+	requested, err := uc.userRepo.HasFollowRequest(ctx, followerID, followingID)
+	if err != nil {
+		return "", err
+	}
+	if requested {
+		return domain.FollowStatusPending, nil
+	}
+
+	if err := uc.userRepo.CreateFollowRequest(ctx, followerID, followingID); err != nil {
+		return "", err
+	}
+	uc.emit(ctx, domain.EventFollowRequested, followerID, followingID)
+
+	return domain.FollowStatusPending, nil
 }
 
-// Unfollow unfollows a user
+// Unfollow unfollows a user or cancels a pending follow request
 func (uc *UseCase) Unfollow(ctx context.Context, followerID, followingID string) error {
 	// Check if user exists
 	_, err := uc.userRepo.GetByID(ctx, followingID)
 	if err != nil {
 		return err
 	}
-	return uc.userRepo.Unfollow(ctx, followerID, followingID)
+	if _, err := uc.userRepo.DeleteFollowRequest(ctx, followerID, followingID); err != nil {
+		return err
+	}
+	if err := uc.userRepo.Unfollow(ctx, followerID, followingID); err != nil {
+		return err
+	}
+	uc.emit(ctx, domain.EventUserUnfollowed, followerID, followingID)
+	return nil
+}
+
+// GetFollowers retrieves followers of the user as seen by viewer
+func (uc *UseCase) GetFollowers(ctx context.Context, userID, viewerUserID string, limit, offset int) ([]*domain.UserCard, int, error) {
+	if err := uc.ensureConnectionsVisible(ctx, userID, viewerUserID); err != nil {
+		return nil, 0, err
+	}
+	return uc.userRepo.GetFollowers(ctx, userID, optionalViewer(viewerUserID), limit, offset)
+}
+
+// GetFollowing retrieves users followed by the user as seen by viewer
+func (uc *UseCase) GetFollowing(ctx context.Context, userID, viewerUserID string, limit, offset int) ([]*domain.UserCard, int, error) {
+	if err := uc.ensureConnectionsVisible(ctx, userID, viewerUserID); err != nil {
+		return nil, 0, err
+	}
+	return uc.userRepo.GetFollowing(ctx, userID, optionalViewer(viewerUserID), limit, offset)
+}
+
+// GetMutuals retrieves users who follow the user and are followed back, as seen by viewer
+func (uc *UseCase) GetMutuals(ctx context.Context, userID, viewerUserID string, limit, offset int) ([]*domain.UserCard, int, error) {
+	if err := uc.ensureConnectionsVisible(ctx, userID, viewerUserID); err != nil {
+		return nil, 0, err
+	}
+	return uc.userRepo.GetMutuals(ctx, userID, optionalViewer(viewerUserID), limit, offset)
+}
+
+// ensureConnectionsVisible checks that viewer may see who the user follows and is followed by:
+// blocked pairs see nothing, private accounts show them to the owner and approved followers only
+func (uc *UseCase) ensureConnectionsVisible(ctx context.Context, userID, viewerUserID string) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if viewerUserID == userID {
+		return nil
+	}
+
+	if viewerUserID != "" {
+		blocked, err := uc.blockRepo.IsBlockedEither(ctx, viewerUserID, userID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrProfileHidden
+		}
+	}
+
+	if !user.IsPrivate {
+		return nil
+	}
+	if viewerUserID == "" {
+		return ErrProfilePrivate
+	}
+	following, err := uc.userRepo.IsFollowing(ctx, viewerUserID, userID)
+	if err != nil {
+		return err
+	}
+	if !following {
+		return ErrProfilePrivate
+	}
+	return nil
+}
+
+func optionalViewer(viewerUserID string) *string {
+	if viewerUserID == "" {
+		return nil
+	}
+	return &viewerUserID
+}
+
+// HasFollowRequest checks if user has a pending follow request to another user
+func (uc *UseCase) HasFollowRequest(ctx context.Context, requesterID, targetID string) (bool, error) {
+	return uc.userRepo.HasFollowRequest(ctx, requesterID, targetID)
+}
+
+// GetFollowRequests retrieves pending follow requests to the user
+func (uc *UseCase) GetFollowRequests(ctx context.Context, userID string, limit, offset int) ([]*domain.FollowRequest, int, error) {
+	return uc.userRepo.GetFollowRequests(ctx, userID, limit, offset)
+}
+
+// ApproveFollowRequest accepts a pending follow request and notifies the requester
+func (uc *UseCase) ApproveFollowRequest(ctx context.Context, userID, requesterID string) error {
+	if err := uc.userRepo.ApproveFollowRequest(ctx, requesterID, userID); err != nil {
+		return err
+	}
+	uc.emit(ctx, domain.EventFollowApproved, userID, requesterID)
+	return nil
+}
+
+// RejectFollowRequest declines a pending follow request; the requester is not notified
+func (uc *UseCase) RejectFollowRequest(ctx context.Context, userID, requesterID string) error {
+	deleted, err := uc.userRepo.DeleteFollowRequest(ctx, requesterID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domain.ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+// emit publishes a domain event when an event publisher is configured
+func (uc *UseCase) emit(ctx context.Context, eventType domain.EventType, actorID, targetID string) {
+	if uc.events != nil {
+		uc.events.Publish(ctx, domain.NewEvent(eventType, actorID, targetID))
+	}
 }
 
 // IsFollowing checks if user is following another user
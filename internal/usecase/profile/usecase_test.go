@@ -42,7 +42,7 @@ func TestGetProfile_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	user := createTestUser()
 	stats := createTestStats()
@@ -70,7 +70,7 @@ func TestGetProfile_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	userRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
@@ -82,12 +82,46 @@ func TestGetProfile_UserNotFound(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestGetVisibleProfile_HiddenWhenBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	uc := NewUseCase(userRepo, blockRepo, nil)
+
+	blockRepo.EXPECT().
+		IsBlockedEither(gomock.Any(), "viewer-1", "user-123").
+		Return(true, nil)
+
+	result, err := uc.GetVisibleProfile(context.Background(), "user-123", "viewer-1")
+
+	assert.ErrorIs(t, err, ErrProfileHidden)
+	assert.Nil(t, result)
+}
+
+func TestGetVisibleProfile_AnonymousSkipsBlockCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-123").Return(createTestUser(), nil)
+	userRepo.EXPECT().GetStats(gomock.Any(), "user-123").Return(createTestStats(), nil)
+
+	result, err := uc.GetVisibleProfile(context.Background(), "user-123", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", result.ID)
+}
+
 func TestGetProfile_StatsError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	user := createTestUser()
 
@@ -111,7 +145,7 @@ func TestUpdateProfile_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	user := createTestUser()
 	description := "Updated description"
@@ -144,7 +178,7 @@ func TestUpdateProfile_UpdateIcon(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	user := createTestUser()
 	iconURL := "https://example.com/icon.jpg"
@@ -176,7 +210,7 @@ func TestUpdateProfile_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	description := "Updated description"
 	req := &UpdateRequest{
@@ -198,7 +232,7 @@ func TestGetStats_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	stats := createTestStats()
 
@@ -218,7 +252,7 @@ func TestFollow_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	userRepo.EXPECT().
 		GetByID(gomock.Any(), "user-456").
@@ -228,9 +262,10 @@ func TestFollow_Success(t *testing.T) {
 		Follow(gomock.Any(), "user-123", "user-456").
 		Return(nil)
 
-	err := uc.Follow(context.Background(), "user-123", "user-456")
+	status, err := uc.Follow(context.Background(), "user-123", "user-456")
 
 	require.NoError(t, err)
+	assert.Equal(t, domain.FollowStatusFollowing, status)
 }
 
 func TestFollow_Self(t *testing.T) {
@@ -238,9 +273,9 @@ func TestFollow_Self(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
-	err := uc.Follow(context.Background(), "user-123", "user-123")
+	_, err := uc.Follow(context.Background(), "user-123", "user-123")
 
 	assert.Error(t, err)
 	assert.Equal(t, "cannot follow yourself", err.Error())
@@ -251,28 +286,136 @@ func TestFollow_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	userRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
 		Return(nil, assert.AnError)
 
-	err := uc.Follow(context.Background(), "user-123", "nonexistent")
+	_, err := uc.Follow(context.Background(), "user-123", "nonexistent")
 
 	assert.Error(t, err)
 }
 
+func TestFollow_PrivateCreatesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	events := mocks.NewMockEventPublisher(ctrl)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), events)
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "user-456").
+		Return(&domain.User{ID: "user-456", IsPrivate: true}, nil)
+	userRepo.EXPECT().IsFollowing(gomock.Any(), "user-123", "user-456").Return(false, nil)
+	userRepo.EXPECT().HasFollowRequest(gomock.Any(), "user-123", "user-456").Return(false, nil)
+	userRepo.EXPECT().CreateFollowRequest(gomock.Any(), "user-123", "user-456").Return(nil)
+	events.EXPECT().
+		Publish(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *domain.Event) {
+			assert.Equal(t, domain.EventFollowRequested, event.Type)
+			assert.Equal(t, "user-123", event.ActorID)
+			assert.Equal(t, "user-456", event.TargetID)
+		})
+
+	status, err := uc.Follow(context.Background(), "user-123", "user-456")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.FollowStatusPending, status)
+}
+
+func TestFollow_PrivateRepeatedRequestDoesNotNotify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "user-456").
+		Return(&domain.User{ID: "user-456", IsPrivate: true}, nil)
+	userRepo.EXPECT().IsFollowing(gomock.Any(), "user-123", "user-456").Return(false, nil)
+	userRepo.EXPECT().HasFollowRequest(gomock.Any(), "user-123", "user-456").Return(true, nil)
+
+	status, err := uc.Follow(context.Background(), "user-123", "user-456")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.FollowStatusPending, status)
+}
+
+func TestApproveFollowRequest_NotifiesRequester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	events := mocks.NewMockEventPublisher(ctrl)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), events)
+
+	userRepo.EXPECT().ApproveFollowRequest(gomock.Any(), "user-123", "user-456").Return(nil)
+	events.EXPECT().
+		Publish(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *domain.Event) {
+			assert.Equal(t, domain.EventFollowApproved, event.Type)
+			assert.Equal(t, "user-456", event.ActorID)
+			assert.Equal(t, "user-123", event.TargetID)
+		})
+
+	err := uc.ApproveFollowRequest(context.Background(), "user-456", "user-123")
+
+	require.NoError(t, err)
+}
+
+func TestRejectFollowRequest_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
+
+	userRepo.EXPECT().DeleteFollowRequest(gomock.Any(), "user-123", "user-456").Return(false, nil)
+
+	err := uc.RejectFollowRequest(context.Background(), "user-456", "user-123")
+
+	assert.ErrorIs(t, err, domain.ErrFollowRequestNotFound)
+}
+
+func TestUpdateProfile_BecomingPublicApprovesRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
+
+	user := createTestUser()
+	user.IsPrivate = true
+	isPrivate := false
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-123").Return(user, nil)
+	userRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	userRepo.EXPECT().ApproveAllFollowRequests(gomock.Any(), "user-123").Return(nil)
+
+	result, err := uc.UpdateProfile(context.Background(), "user-123", &UpdateRequest{IsPrivate: &isPrivate})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsPrivate)
+}
+
 func TestUnfollow_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	userRepo.EXPECT().
 		GetByID(gomock.Any(), "user-456").
 		Return(&domain.User{ID: "user-456"}, nil)
 
+	userRepo.EXPECT().
+		DeleteFollowRequest(gomock.Any(), "user-123", "user-456").
+		Return(false, nil)
+
 	userRepo.EXPECT().
 		Unfollow(gomock.Any(), "user-123", "user-456").
 		Return(nil)
@@ -287,7 +430,7 @@ func TestUnfollow_UserNotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	userRepo.EXPECT().
 		GetByID(gomock.Any(), "nonexistent").
@@ -303,7 +446,7 @@ func TestIsFollowing_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	userRepo := mocks.NewMockUserRepository(ctrl)
-	uc := NewUseCase(userRepo)
+	uc := NewUseCase(userRepo, mocks.NewMockBlockRepository(ctrl), nil)
 
 	userRepo.EXPECT().
 		IsFollowing(gomock.Any(), "user-123", "user-456").
@@ -314,3 +457,60 @@ func TestIsFollowing_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, result)
 }
+
+func TestGetFollowers_PrivateHiddenFromNonFollower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	uc := NewUseCase(userRepo, blockRepo, nil)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-456").Return(&domain.User{ID: "user-456", IsPrivate: true}, nil)
+	blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "user-123", "user-456").Return(false, nil)
+	userRepo.EXPECT().IsFollowing(gomock.Any(), "user-123", "user-456").Return(false, nil)
+
+	cards, _, err := uc.GetFollowers(context.Background(), "user-456", "user-123", 20, 0)
+
+	assert.ErrorIs(t, err, ErrProfilePrivate)
+	assert.Nil(t, cards)
+}
+
+func TestGetFollowing_PrivateVisibleToFollower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	uc := NewUseCase(userRepo, blockRepo, nil)
+
+	viewer := "user-123"
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-456").Return(&domain.User{ID: "user-456", IsPrivate: true}, nil)
+	blockRepo.EXPECT().IsBlockedEither(gomock.Any(), viewer, "user-456").Return(false, nil)
+	userRepo.EXPECT().IsFollowing(gomock.Any(), viewer, "user-456").Return(true, nil)
+	userRepo.EXPECT().
+		GetFollowing(gomock.Any(), "user-456", &viewer, 20, 0).
+		Return([]*domain.UserCard{{User: domain.User{ID: "user-789"}, FollowsMe: true}}, 1, nil)
+
+	cards, total, err := uc.GetFollowing(context.Background(), "user-456", viewer, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.True(t, cards[0].FollowsMe)
+}
+
+func TestGetMutuals_HiddenWhenBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	blockRepo := mocks.NewMockBlockRepository(ctrl)
+	uc := NewUseCase(userRepo, blockRepo, nil)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-456").Return(&domain.User{ID: "user-456"}, nil)
+	blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "user-123", "user-456").Return(true, nil)
+
+	_, _, err := uc.GetMutuals(context.Background(), "user-456", "user-123", 20, 0)
+
+	assert.ErrorIs(t, err, ErrProfileHidden)
+}
@@ -15,18 +15,22 @@ type UseCase struct {
 	publicationRepo domain.PublicationRepository
 	userRepo        domain.UserRepository
 	mediaRepo       domain.MediaRepository
+	communityRepo   domain.CommunityRepository
 }
 
-// NewUseCase creates a new publication use case
+// NewUseCase creates a new publication use case. Its events are written to the outbox by the
+// repository in the transaction of the change
 func NewUseCase(
 	publicationRepo domain.PublicationRepository,
 	userRepo domain.UserRepository,
 	mediaRepo domain.MediaRepository,
+	communityRepo domain.CommunityRepository,
 ) *UseCase {
 	return &UseCase{
 		publicationRepo: publicationRepo,
 		userRepo:        userRepo,
 		mediaRepo:       mediaRepo,
+		communityRepo:   communityRepo,
 	}
 }
 
@@ -38,6 +42,8 @@ type CreateRequest struct {
 	Source     *string                `json:"source,omitempty" validate:"omitempty,max=200"`
 	Visibility domain.VisibilityType  `json:"visibility" validate:"required"`
 	MediaIDs   []string               `json:"media_ids,omitempty"`
+	// CommunityID posts the publication into a community the author is a member of
+	CommunityID *string `json:"community_id,omitempty"`
 }
 
 // UpdateRequest represents update publication request
@@ -59,6 +65,14 @@ func (uc *UseCase) Create(ctx context.Context, authorID string, req *CreateReque
 		}
 	}
 
+	if req.CommunityID != nil {
+		role, err := uc.communityRepo.GetRole(ctx, *req.CommunityID, authorID)
+		if err != nil || role == nil {
+			return nil, errors.New("not a community member")
+		}
+	}
+
+	now := time.Now()
 	publication := &domain.Publication{
 		ID:              uuid.New().String(),
 		AuthorID:        authorID,
@@ -66,8 +80,10 @@ func (uc *UseCase) Create(ctx context.Context, authorID string, req *CreateReque
 		Title:           req.Title,
 		Content:         req.Content,
 		Source:          req.Source,
-		PublicationDate: time.Now(),
+		PublicationDate: now,
+		UpdatedAt:       now,
 		Visibility:      req.Visibility,
+		CommunityID:     req.CommunityID,
 		LikesCount:      0,
 		CommentsCount:   0,
 		SavedCount:      0,
@@ -108,6 +124,7 @@ func (uc *UseCase) Update(ctx context.Context, id, userID string, req *UpdateReq
 	if req.Visibility != nil {
 		publication.Visibility = *req.Visibility
 	}
+	publication.UpdatedAt = time.Now()
 
 	mediaIDs := req.MediaIDs
 	if mediaIDs == nil {
@@ -136,7 +153,8 @@ func (uc *UseCase) Delete(ctx context.Context, id, userID string) error {
 		return err
 	}
 
-	if publication.AuthorID != userID {
+	// Community moderators may delete any publication posted into their community
+	if publication.AuthorID != userID && !uc.canModerate(ctx, publication, userID) {
 		return errors.New("forbidden: not the author")
 	}
 
@@ -173,3 +191,11 @@ func (uc *UseCase) GetLikedUsers(ctx context.Context, publicationID string, limi
 	return uc.publicationRepo.GetLikedUsers(ctx, publicationID, limit, offset)
 }
 
+// canModerate checks if user moderates the community the publication was posted into
+func (uc *UseCase) canModerate(ctx context.Context, publication *domain.Publication, userID string) bool {
+	if publication.CommunityID == nil {
+		return false
+	}
+	role, err := uc.communityRepo.GetRole(ctx, *publication.CommunityID, userID)
+	return err == nil && role != nil && role.CanModerate()
+}
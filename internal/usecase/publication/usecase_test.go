@@ -45,7 +45,7 @@ func TestCreate_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	req := &CreateRequest{
 		Type:       domain.PublicationTypePost,
@@ -79,7 +79,7 @@ func TestCreate_WithMedia(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	req := &CreateRequest{
 		Type:       domain.PublicationTypePost,
@@ -114,7 +114,7 @@ func TestCreate_MediaNotOwned(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	req := &CreateRequest{
 		Type:       domain.PublicationTypePost,
@@ -135,6 +135,35 @@ func TestCreate_MediaNotOwned(t *testing.T) {
 	assert.Equal(t, "media not found or not owned", err.Error())
 }
 
+func TestCreate_CommunityNotMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	mediaRepo := mocks.NewMockMediaRepository(ctrl)
+	communityRepo := mocks.NewMockCommunityRepository(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, communityRepo)
+
+	req := &CreateRequest{
+		Type:        domain.PublicationTypePost,
+		Title:       "Test Title",
+		Content:     stringPtr("Test content"),
+		Visibility:  domain.VisibilityTypePublic,
+		CommunityID: stringPtr("community-1"),
+	}
+
+	communityRepo.EXPECT().
+		GetRole(gomock.Any(), "community-1", "user-123").
+		Return(nil, nil)
+
+	pub, err := uc.Create(context.Background(), "user-123", req)
+
+	assert.Error(t, err)
+	assert.Nil(t, pub)
+	assert.Equal(t, "not a community member", err.Error())
+}
+
 func TestGet_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -142,7 +171,7 @@ func TestGet_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	pubWithStatus := createTestPublicationWithLikeStatus()
 	viewerUserID := "user-123"
@@ -165,7 +194,7 @@ func TestGet_NotFound(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	viewerUserID := "user-123"
 
@@ -186,7 +215,7 @@ func TestUpdate_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	pub := createTestPublication()
 	newContent := "Updated content"
@@ -224,7 +253,7 @@ func TestUpdate_NotAuthor(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	pub := createTestPublication()
 
@@ -250,7 +279,7 @@ func TestUpdate_MediaOwnership(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	pub := createTestPublication()
 
@@ -280,7 +309,7 @@ func TestDelete_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	pub := createTestPublication()
 
@@ -304,7 +333,7 @@ func TestDelete_NotAuthor(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	pub := createTestPublication()
 
@@ -318,6 +347,37 @@ func TestDelete_NotAuthor(t *testing.T) {
 	assert.Equal(t, "forbidden: not the author", err.Error())
 }
 
+func TestDelete_CommunityModerator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	mediaRepo := mocks.NewMockMediaRepository(ctrl)
+	communityRepo := mocks.NewMockCommunityRepository(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, communityRepo)
+
+	pub := createTestPublication()
+	pub.CommunityID = stringPtr("community-1")
+	moderator := domain.CommunityRoleModerator
+
+	publicationRepo.EXPECT().
+		GetByID(gomock.Any(), "pub-123").
+		Return(pub, nil)
+
+	communityRepo.EXPECT().
+		GetRole(gomock.Any(), "community-1", "mod-user").
+		Return(&moderator, nil)
+
+	publicationRepo.EXPECT().
+		Delete(gomock.Any(), "pub-123").
+		Return(nil)
+
+	err := uc.Delete(context.Background(), "pub-123", "mod-user")
+
+	require.NoError(t, err)
+}
+
 func TestLike_Toggle(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -325,7 +385,7 @@ func TestLike_Toggle(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	publicationRepo.EXPECT().
 		Like(gomock.Any(), "user-123", "pub-123").
@@ -349,7 +409,7 @@ func TestSave_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	note := "My note"
 
@@ -369,7 +429,7 @@ func TestUnsave_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	publicationRepo.EXPECT().
 		Unsave(gomock.Any(), "user-123", "pub-123").
@@ -387,7 +447,7 @@ func TestGetLikedUsers_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	mediaRepo := mocks.NewMockMediaRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, mediaRepo)
+	uc := NewUseCase(publicationRepo, userRepo, mediaRepo, mocks.NewMockCommunityRepository(ctrl))
 
 	users := []*domain.User{
 		{ID: "user-1", Username: "user1"},
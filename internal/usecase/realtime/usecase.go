@@ -0,0 +1,218 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"sense-backend/internal/domain"
+)
+
+// MaxWatchedPublications limits the number of publications one connection receives comments of
+const MaxWatchedPublications = 20
+
+var (
+	// ErrTooManyPublications is returned when a connection watches more than MaxWatchedPublications
+	ErrTooManyPublications = errors.New("too many watched publications")
+	// ErrPublicationNotFound is returned when a watched publication does not exist or is hidden from the user
+	ErrPublicationNotFound = errors.New("publication not found")
+)
+
+// HandledEvents are the domain events relayed to live connections
+var HandledEvents = []domain.EventType{
+	domain.EventCommentCreated,
+}
+
+// Stream is a user's live connection: their notifications, unread count changes and new
+// comments on the publications they watch
+type Stream struct {
+	uc           *UseCase
+	userID       string
+	subscription domain.RealtimeSubscription
+	watched      map[string]struct{}
+	events       chan *domain.RealtimeEvent
+	done         chan struct{}
+	closeOnce    sync.Once
+	// UnreadCount is the number of unread notifications when the stream was opened
+	UnreadCount int
+}
+
+// Events returns the stream's events; the channel is closed when the stream ends
+func (s *Stream) Events() <-chan *domain.RealtimeEvent {
+	return s.events
+}
+
+// relay passes the subscription's events on to the stream, leaving out comments of users
+// blocked by or blocking the stream's user
+func (s *Stream) relay(ctx context.Context) {
+	defer close(s.events)
+	for event := range s.subscription.Events() {
+		if !s.uc.visibleTo(ctx, s.userID, event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Watch starts delivering new comments on the publication if the user can see it
+func (s *Stream) Watch(ctx context.Context, publicationID string) error {
+	if _, ok := s.watched[publicationID]; ok {
+		return nil
+	}
+	if len(s.watched) >= MaxWatchedPublications {
+		return ErrTooManyPublications
+	}
+	if err := s.uc.checkVisible(ctx, s.userID, publicationID); err != nil {
+		return err
+	}
+
+	s.watched[publicationID] = struct{}{}
+	s.subscription.Join(domain.PublicationChannel(publicationID))
+	return nil
+}
+
+// Unwatch stops delivering comments on the publication
+func (s *Stream) Unwatch(publicationID string) {
+	if _, ok := s.watched[publicationID]; !ok {
+		return
+	}
+	delete(s.watched, publicationID)
+	s.subscription.Leave(domain.PublicationChannel(publicationID))
+}
+
+// Close ends the stream
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		if s.done != nil {
+			close(s.done)
+		}
+	})
+	s.subscription.Close()
+}
+
+// UseCase handles realtime delivery use cases
+type UseCase struct {
+	subscriber       domain.RealtimeSubscriber
+	publisher        domain.RealtimePublisher
+	publicationRepo  domain.PublicationRepository
+	commentRepo      domain.CommentRepository
+	notificationRepo domain.NotificationRepository
+	blockRepo        domain.BlockRepository
+}
+
+// NewUseCase creates a new realtime use case
+func NewUseCase(
+	subscriber domain.RealtimeSubscriber,
+	publisher domain.RealtimePublisher,
+	publicationRepo domain.PublicationRepository,
+	commentRepo domain.CommentRepository,
+	notificationRepo domain.NotificationRepository,
+	blockRepo domain.BlockRepository,
+) *UseCase {
+	return &UseCase{
+		subscriber:       subscriber,
+		publisher:        publisher,
+		publicationRepo:  publicationRepo,
+		commentRepo:      commentRepo,
+		notificationRepo: notificationRepo,
+		blockRepo:        blockRepo,
+	}
+}
+
+// Connect opens a live stream for the user watching publicationIDs; with a positive lastEventID
+// the events published after it are replayed first
+func (uc *UseCase) Connect(ctx context.Context, userID string, publicationIDs []string, lastEventID int64) (*Stream, error) {
+	channels := []string{domain.UserChannel(userID)}
+	watched := make(map[string]struct{})
+	for _, id := range publicationIDs {
+		if _, ok := watched[id]; ok {
+			continue
+		}
+		if len(watched) >= MaxWatchedPublications {
+			return nil, ErrTooManyPublications
+		}
+		if err := uc.checkVisible(ctx, userID, id); err != nil {
+			return nil, err
+		}
+		watched[id] = struct{}{}
+		channels = append(channels, domain.PublicationChannel(id))
+	}
+
+	unread, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.subscriber.Subscribe(ctx, channels, lastEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &Stream{
+		uc:           uc,
+		userID:       userID,
+		subscription: subscription,
+		watched:      watched,
+		events:       make(chan *domain.RealtimeEvent),
+		done:         make(chan struct{}),
+		UnreadCount:  unread,
+	}
+	go stream.relay(ctx)
+	return stream, nil
+}
+
+// Handle relays a domain event to the live connections it concerns
+func (uc *UseCase) Handle(ctx context.Context, event *domain.Event) error {
+	if event.Type != domain.EventCommentCreated {
+		return nil
+	}
+
+	comment, err := uc.commentRepo.GetByID(ctx, event.TargetID)
+	if err != nil {
+		return err
+	}
+
+	return uc.publisher.PublishToPublication(ctx, comment.PublicationID, &domain.RealtimeEvent{
+		Type: domain.RealtimeEventComment,
+		Data: comment,
+	})
+}
+
+// visibleTo reports whether the user may receive event: comments published to a publication's
+// watchers are held back from users blocked by or blocking the author. When the check fails
+// the comment is held back too
+func (uc *UseCase) visibleTo(ctx context.Context, userID string, event *domain.RealtimeEvent) bool {
+	if event.Type != domain.RealtimeEventComment {
+		return true
+	}
+
+	// Events arrive as stored JSON from the hub
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return false
+	}
+	var comment struct {
+		AuthorID string `json:"author_id"`
+	}
+	if err := json.Unmarshal(data, &comment); err != nil {
+		return false
+	}
+	if comment.AuthorID == userID {
+		return true
+	}
+
+	blocked, err := uc.blockRepo.IsBlockedEither(ctx, userID, comment.AuthorID)
+	return err == nil && !blocked
+}
+
+func (uc *UseCase) checkVisible(ctx context.Context, userID, publicationID string) error {
+	if _, err := uc.publicationRepo.GetByIDWithLikeStatus(ctx, publicationID, &userID); err != nil {
+		return ErrPublicationNotFound
+	}
+	return nil
+}
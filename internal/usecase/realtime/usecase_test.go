@@ -0,0 +1,152 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	subscriber       *mocks.MockRealtimeSubscriber
+	publisher        *mocks.MockRealtimePublisher
+	publicationRepo  *mocks.MockPublicationRepository
+	commentRepo      *mocks.MockCommentRepository
+	notificationRepo *mocks.MockNotificationRepository
+	blockRepo        *mocks.MockBlockRepository
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		subscriber:       mocks.NewMockRealtimeSubscriber(ctrl),
+		publisher:        mocks.NewMockRealtimePublisher(ctrl),
+		publicationRepo:  mocks.NewMockPublicationRepository(ctrl),
+		commentRepo:      mocks.NewMockCommentRepository(ctrl),
+		notificationRepo: mocks.NewMockNotificationRepository(ctrl),
+		blockRepo:        mocks.NewMockBlockRepository(ctrl),
+	}
+	uc := NewUseCase(deps.subscriber, deps.publisher, deps.publicationRepo, deps.commentRepo, deps.notificationRepo, deps.blockRepo)
+	return uc, deps
+}
+
+func TestConnect_SubscribesToUserAndPublications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	subscription := mocks.NewMockRealtimeSubscription(ctrl)
+
+	deps.publicationRepo.EXPECT().GetByIDWithLikeStatus(gomock.Any(), "pub-1", gomock.Any()).
+		Return(&domain.PublicationWithLikeStatus{}, nil)
+	deps.notificationRepo.EXPECT().CountUnread(gomock.Any(), "alice").Return(3, nil)
+	deps.subscriber.EXPECT().
+		Subscribe(gomock.Any(), []string{"user:alice", "publication:pub-1"}, int64(42)).
+		Return(subscription, nil)
+	events := make(chan *domain.RealtimeEvent)
+	close(events)
+	subscription.EXPECT().Events().Return(events)
+
+	stream, err := uc.Connect(context.Background(), "alice", []string{"pub-1", "pub-1"}, 42)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, stream.UnreadCount)
+	_, open := <-stream.Events()
+	assert.False(t, open)
+}
+
+func TestStream_HidesCommentsOfBlockedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	subscription := mocks.NewMockRealtimeSubscription(ctrl)
+
+	// Comments arrive from the hub as stored JSON
+	fromBob := &domain.RealtimeEvent{ID: 1, Type: domain.RealtimeEventComment, Data: json.RawMessage(`{"id":"c-1","author_id":"bob"}`)}
+	fromCarol := &domain.RealtimeEvent{ID: 2, Type: domain.RealtimeEventComment, Data: json.RawMessage(`{"id":"c-2","author_id":"carol"}`)}
+	unread := &domain.RealtimeEvent{ID: 3, Type: domain.RealtimeEventUnreadCount, Data: json.RawMessage(`{"count":1}`)}
+	events := make(chan *domain.RealtimeEvent, 3)
+	events <- fromBob
+	events <- fromCarol
+	events <- unread
+	close(events)
+
+	deps.notificationRepo.EXPECT().CountUnread(gomock.Any(), "alice").Return(0, nil)
+	deps.subscriber.EXPECT().Subscribe(gomock.Any(), []string{"user:alice"}, int64(0)).Return(subscription, nil)
+	subscription.EXPECT().Events().Return(events)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(true, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "carol").Return(false, nil)
+
+	stream, err := uc.Connect(context.Background(), "alice", nil, 0)
+	require.NoError(t, err)
+
+	var delivered []*domain.RealtimeEvent
+	for event := range stream.Events() {
+		delivered = append(delivered, event)
+	}
+	assert.Equal(t, []*domain.RealtimeEvent{fromCarol, unread}, delivered)
+}
+
+func TestConnect_HiddenPublication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.publicationRepo.EXPECT().GetByIDWithLikeStatus(gomock.Any(), "pub-1", gomock.Any()).
+		Return(nil, errors.New("publication not found"))
+
+	stream, err := uc.Connect(context.Background(), "alice", []string{"pub-1"}, 0)
+
+	assert.ErrorIs(t, err, ErrPublicationNotFound)
+	assert.Nil(t, stream)
+}
+
+func TestStreamWatch_Limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	subscription := mocks.NewMockRealtimeSubscription(ctrl)
+
+	deps.publicationRepo.EXPECT().GetByIDWithLikeStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&domain.PublicationWithLikeStatus{}, nil).Times(MaxWatchedPublications)
+	subscription.EXPECT().Join(gomock.Any()).Times(MaxWatchedPublications)
+	subscription.EXPECT().Leave("publication:pub-0")
+
+	stream := &Stream{uc: uc, userID: "alice", subscription: subscription, watched: map[string]struct{}{}}
+	for i := 0; i < MaxWatchedPublications; i++ {
+		require.NoError(t, stream.Watch(context.Background(), fmt.Sprintf("pub-%d", i)))
+	}
+
+	err := stream.Watch(context.Background(), "pub-extra")
+	assert.ErrorIs(t, err, ErrTooManyPublications)
+
+	stream.Unwatch("pub-0")
+	stream.Unwatch("pub-0")
+}
+
+func TestHandle_CommentCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	comment := &domain.Comment{ID: "c-1", PublicationID: "pub-1", AuthorID: "bob"}
+	deps.commentRepo.EXPECT().GetByID(gomock.Any(), "c-1").Return(comment, nil)
+	deps.publisher.EXPECT().
+		PublishToPublication(gomock.Any(), "pub-1", &domain.RealtimeEvent{Type: domain.RealtimeEventComment, Data: comment}).
+		Return(nil)
+
+	err := uc.Handle(context.Background(), domain.NewEvent(domain.EventCommentCreated, "bob", "c-1"))
+
+	require.NoError(t, err)
+}
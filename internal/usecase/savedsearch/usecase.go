@@ -0,0 +1,277 @@
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// JobRun is the scheduled background job type looking for new matches of all saved searches
+const JobRun = "search.saved_searches"
+
+// MaxSavedSearchesPerUser caps the number of saved searches of one user
+const MaxSavedSearchesPerUser = 20
+
+// runBatchSize is the number of saved searches loaded at a time by a run
+const runBatchSize = 100
+
+// ErrTooManySavedSearches is returned when the user already has MaxSavedSearchesPerUser saved searches
+var ErrTooManySavedSearches = errors.New("too many saved searches")
+
+// Params configures runs of saved searches
+type Params struct {
+	// MaxMatches caps the new matches recorded for one saved search per run
+	MaxMatches int
+	// Overlap is how far before its last run a saved search looks again, catching publications
+	// that reached the search index late; matches found before are not counted twice
+	Overlap time.Duration
+}
+
+// DefaultParams are used unless configured otherwise
+var DefaultParams = Params{
+	MaxMatches: 100,
+	Overlap:    time.Hour,
+}
+
+// Filters narrows the publications a saved search matches; omitted filters match all
+type Filters struct {
+	Type       *domain.PublicationType `json:"type,omitempty" validate:"omitempty,oneof=quote post article"`
+	Visibility *domain.VisibilityType  `json:"visibility,omitempty" validate:"omitempty,oneof=public community private"`
+	AuthorID   *string                 `json:"author_id,omitempty" validate:"omitempty,uuid"`
+	Tags       []string                `json:"tags,omitempty" validate:"omitempty,max=10,dive,required,max=50"`
+}
+
+// CreateRequest represents create saved search request; notify defaults to true
+type CreateRequest struct {
+	Name    string  `json:"name" validate:"required,max=100"`
+	Query   string  `json:"query" validate:"required,max=500"`
+	Filters Filters `json:"filters"`
+	Notify  *bool   `json:"notify,omitempty"`
+}
+
+// UpdateRequest represents update saved search request; omitted fields are kept
+type UpdateRequest struct {
+	Name    *string  `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Query   *string  `json:"query,omitempty" validate:"omitempty,min=1,max=500"`
+	Filters *Filters `json:"filters,omitempty"`
+	Notify  *bool    `json:"notify,omitempty"`
+}
+
+// UseCase handles saved search use cases
+type UseCase struct {
+	savedSearchRepo domain.SavedSearchRepository
+	publicationRepo domain.PublicationRepository
+	index           domain.SearchIndex
+	events          domain.EventPublisher
+	params          Params
+}
+
+// NewUseCase creates a new saved search use case
+func NewUseCase(
+	savedSearchRepo domain.SavedSearchRepository,
+	publicationRepo domain.PublicationRepository,
+	index domain.SearchIndex,
+	events domain.EventPublisher,
+	params Params,
+) *UseCase {
+	return &UseCase{
+		savedSearchRepo: savedSearchRepo,
+		publicationRepo: publicationRepo,
+		index:           index,
+		events:          events,
+		params:          params,
+	}
+}
+
+// Create saves a search; only publications found after it is saved count as new
+func (uc *UseCase) Create(ctx context.Context, userID string, req *CreateRequest) (*domain.SavedSearch, error) {
+	count, err := uc.savedSearchRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxSavedSearchesPerUser {
+		return nil, ErrTooManySavedSearches
+	}
+
+	now := time.Now()
+	search := &domain.SavedSearch{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      req.Name,
+		Query:     req.Query,
+		Filters:   req.Filters.searchFilters(),
+		Notify:    req.Notify == nil || *req.Notify,
+		SeenAt:    now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.savedSearchRepo.Create(ctx, search); err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	return search, nil
+}
+
+// List retrieves the user's saved searches with the numbers of their unseen matches
+func (uc *UseCase) List(ctx context.Context, userID string) ([]*domain.SavedSearch, error) {
+	return uc.savedSearchRepo.GetByUser(ctx, userID)
+}
+
+// Get retrieves the user's saved search
+func (uc *UseCase) Get(ctx context.Context, userID, searchID string) (*domain.SavedSearch, error) {
+	return uc.savedSearchRepo.GetByID(ctx, userID, searchID)
+}
+
+// Update updates the user's saved search; matches found so far are kept
+func (uc *UseCase) Update(ctx context.Context, userID, searchID string, req *UpdateRequest) (*domain.SavedSearch, error) {
+	search, err := uc.savedSearchRepo.GetByID(ctx, userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		search.Name = *req.Name
+	}
+	if req.Query != nil {
+		search.Query = *req.Query
+	}
+	if req.Filters != nil {
+		search.Filters = req.Filters.searchFilters()
+	}
+	if req.Notify != nil {
+		search.Notify = *req.Notify
+	}
+	search.UpdatedAt = time.Now()
+
+	if err := uc.savedSearchRepo.Update(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// Delete deletes the user's saved search with its matches
+func (uc *UseCase) Delete(ctx context.Context, userID, searchID string) error {
+	return uc.savedSearchRepo.Delete(ctx, userID, searchID)
+}
+
+// GetNew retrieves publications found by the user's saved search since they last saw its
+// matches, latest found first; publications no longer visible to the user are skipped
+func (uc *UseCase) GetNew(ctx context.Context, userID, searchID string, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
+	if _, err := uc.savedSearchRepo.GetByID(ctx, userID, searchID); err != nil {
+		return nil, 0, err
+	}
+
+	ids, total, err := uc.savedSearchRepo.GetUnseen(ctx, searchID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return []*domain.PublicationWithLikeStatus{}, total, nil
+	}
+
+	publications, err := uc.publicationRepo.GetByIDs(ctx, ids, &userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*domain.PublicationWithLikeStatus, len(publications))
+	for _, publication := range publications {
+		byID[publication.ID] = publication
+	}
+
+	result := make([]*domain.PublicationWithLikeStatus, 0, len(ids))
+	for _, id := range ids {
+		if publication, ok := byID[id]; ok {
+			result = append(result, publication)
+		}
+	}
+	return result, total, nil
+}
+
+// MarkSeen marks all matches of the user's saved search found so far as seen
+func (uc *UseCase) MarkSeen(ctx context.Context, userID, searchID string) error {
+	return uc.savedSearchRepo.MarkSeen(ctx, userID, searchID, time.Now())
+}
+
+// Run looks for new matches of every saved search; a failing search does not stop the others
+// and fails the job, so that it is retried
+func (uc *UseCase) Run(ctx context.Context, job *domain.Job) error {
+	var errs []error
+	afterID := ""
+	for {
+		searches, err := uc.savedSearchRepo.List(ctx, afterID, runBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, search := range searches {
+			if err := uc.run(ctx, search, time.Now()); err != nil {
+				errs = append(errs, fmt.Errorf("saved search %s: %w", search.ID, err))
+			}
+		}
+		if len(searches) < runBatchSize {
+			return errors.Join(errs...)
+		}
+		afterID = searches[len(searches)-1].ID
+	}
+}
+
+// run records publications matching search published since its last run and notifies its
+// owner about the new ones. The owner's own publications are not matches
+func (uc *UseCase) run(ctx context.Context, search *domain.SavedSearch, now time.Time) error {
+	since := search.CreatedAt
+	if search.LastRunAt != nil {
+		since = search.LastRunAt.Add(-uc.params.Overlap)
+	}
+	filters := search.Filters
+	if filters.DateFrom == nil || filters.DateFrom.Before(since) {
+		filters.DateFrom = &since
+	}
+
+	hits, _, err := uc.index.Query(ctx, search.Query, &search.UserID, &filters, uc.params.MaxMatches, 0)
+	if err != nil {
+		return err
+	}
+
+	matches := []string{}
+	if len(hits) > 0 {
+		ids := make([]string, len(hits))
+		for i, hit := range hits {
+			ids[i] = hit.ID
+		}
+		// The index does not know every visibility rule
+		publications, err := uc.publicationRepo.GetByIDs(ctx, ids, &search.UserID)
+		if err != nil {
+			return err
+		}
+		for _, publication := range publications {
+			if publication.AuthorID != search.UserID {
+				matches = append(matches, publication.ID)
+			}
+		}
+	}
+
+	added, _, err := uc.savedSearchRepo.AddMatches(ctx, search.ID, matches, now)
+	if err != nil {
+		return err
+	}
+	if added == 0 || !search.Notify || uc.events == nil {
+		return nil
+	}
+
+	uc.events.Publish(ctx, domain.NewEvent(domain.EventSavedSearchMatched, search.UserID, search.ID))
+	return nil
+}
+
+// searchFilters converts request filters into search filters
+func (f *Filters) searchFilters() domain.SearchFilters {
+	return domain.SearchFilters{
+		Type:       f.Type,
+		Visibility: f.Visibility,
+		AuthorID:   f.AuthorID,
+		Tags:       f.Tags,
+	}
+}
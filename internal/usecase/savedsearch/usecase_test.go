@@ -0,0 +1,178 @@
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	savedSearchRepo *mocks.MockSavedSearchRepository
+	publicationRepo *mocks.MockPublicationRepository
+	index           *mocks.MockSearchIndex
+	events          *mocks.MockEventPublisher
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		savedSearchRepo: mocks.NewMockSavedSearchRepository(ctrl),
+		publicationRepo: mocks.NewMockPublicationRepository(ctrl),
+		index:           mocks.NewMockSearchIndex(ctrl),
+		events:          mocks.NewMockEventPublisher(ctrl),
+	}
+	uc := NewUseCase(deps.savedSearchRepo, deps.publicationRepo, deps.index, deps.events, DefaultParams)
+	return uc, deps
+}
+
+func publication(id, authorID string) *domain.PublicationWithLikeStatus {
+	return &domain.PublicationWithLikeStatus{Publication: domain.Publication{ID: id, AuthorID: authorID}}
+}
+
+func TestCreate_DefaultsAndLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	postType := domain.PublicationTypePost
+
+	deps.savedSearchRepo.EXPECT().CountByUser(gomock.Any(), "alice").Return(0, nil)
+	deps.savedSearchRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	search, err := uc.Create(context.Background(), "alice", &CreateRequest{
+		Name:    "Стоицизм",
+		Query:   "стоицизм OR сенека",
+		Filters: Filters{Type: &postType, Tags: []string{"философия"}},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, search.Notify)
+	assert.Equal(t, &postType, search.Filters.Type)
+	assert.Equal(t, []string{"философия"}, search.Filters.Tags)
+	assert.Nil(t, search.LastRunAt)
+
+	deps.savedSearchRepo.EXPECT().CountByUser(gomock.Any(), "alice").Return(MaxSavedSearchesPerUser, nil)
+
+	_, err = uc.Create(context.Background(), "alice", &CreateRequest{Name: "x", Query: "x"})
+	assert.ErrorIs(t, err, ErrTooManySavedSearches)
+}
+
+func TestUpdate_KeepsOmittedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	existing := &domain.SavedSearch{ID: "s1", UserID: "alice", Name: "Стоицизм", Query: "стоицизм", Notify: true}
+	notify := false
+
+	deps.savedSearchRepo.EXPECT().GetByID(gomock.Any(), "alice", "s1").Return(existing, nil)
+	deps.savedSearchRepo.EXPECT().Update(gomock.Any(), existing).Return(nil)
+
+	search, err := uc.Update(context.Background(), "alice", "s1", &UpdateRequest{Notify: &notify})
+
+	require.NoError(t, err)
+	assert.Equal(t, "стоицизм", search.Query)
+	assert.False(t, search.Notify)
+
+	deps.savedSearchRepo.EXPECT().GetByID(gomock.Any(), "bob", "s1").Return(nil, domain.ErrSavedSearchNotFound)
+
+	_, err = uc.Update(context.Background(), "bob", "s1", &UpdateRequest{Notify: &notify})
+	assert.ErrorIs(t, err, domain.ErrSavedSearchNotFound)
+}
+
+func TestGetNew_KeepsOrderAndSkipsHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	userID := "alice"
+
+	deps.savedSearchRepo.EXPECT().GetByID(gomock.Any(), userID, "s1").Return(&domain.SavedSearch{ID: "s1", UserID: userID}, nil)
+	deps.savedSearchRepo.EXPECT().GetUnseen(gomock.Any(), "s1", 20, 0).Return([]string{"p2", "p-hidden", "p1"}, 3, nil)
+	deps.publicationRepo.EXPECT().GetByIDs(gomock.Any(), []string{"p2", "p-hidden", "p1"}, &userID).
+		Return([]*domain.PublicationWithLikeStatus{publication("p1", "bob"), publication("p2", "bob")}, nil)
+
+	publications, total, err := uc.GetNew(context.Background(), userID, "s1", 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, publications, 2)
+	assert.Equal(t, "p2", publications[0].ID)
+	assert.Equal(t, "p1", publications[1].ID)
+}
+
+func TestRun_RecordsMatchesAndNotifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	userID := "alice"
+	lastRun := time.Now().Add(-15 * time.Minute)
+	search := &domain.SavedSearch{ID: "s1", UserID: userID, Name: "Стоицизм", Query: "стоицизм", Notify: true, LastRunAt: &lastRun}
+
+	deps.savedSearchRepo.EXPECT().List(gomock.Any(), "", runBatchSize).Return([]*domain.SavedSearch{search}, nil)
+	deps.index.EXPECT().Query(gomock.Any(), "стоицизм", &userID, gomock.Any(), DefaultParams.MaxMatches, 0).
+		DoAndReturn(func(_ context.Context, _ string, _ *string, filters *domain.SearchFilters, _, _ int) ([]*domain.SearchHit, int, error) {
+			// Publications since the last run, with an overlap for late indexing
+			require.NotNil(t, filters.DateFrom)
+			assert.True(t, filters.DateFrom.Equal(lastRun.Add(-DefaultParams.Overlap)))
+			return []*domain.SearchHit{{ID: "p1"}, {ID: "p-own"}}, 2, nil
+		})
+	deps.publicationRepo.EXPECT().GetByIDs(gomock.Any(), []string{"p1", "p-own"}, &userID).
+		Return([]*domain.PublicationWithLikeStatus{publication("p1", "bob"), publication("p-own", userID)}, nil)
+	deps.savedSearchRepo.EXPECT().AddMatches(gomock.Any(), "s1", []string{"p1"}, gomock.Any()).Return(1, 4, nil)
+	deps.events.EXPECT().Publish(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *domain.Event) {
+			assert.Equal(t, domain.EventSavedSearchMatched, event.Type)
+			assert.Equal(t, userID, event.ActorID)
+			assert.Equal(t, "s1", event.TargetID)
+		})
+
+	require.NoError(t, uc.Run(context.Background(), &domain.Job{Type: JobRun}))
+}
+
+func TestRun_NoNotificationWithoutNewMatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	quiet := &domain.SavedSearch{ID: "s1", UserID: "alice", Query: "стоицизм", Notify: false, CreatedAt: time.Now()}
+	known := &domain.SavedSearch{ID: "s2", UserID: "alice", Query: "сенека", Notify: true, CreatedAt: time.Now()}
+
+	deps.savedSearchRepo.EXPECT().List(gomock.Any(), "", runBatchSize).Return([]*domain.SavedSearch{quiet, known}, nil)
+	deps.index.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), 0).
+		Return([]*domain.SearchHit{{ID: "p1"}}, 1, nil).Times(2)
+	deps.publicationRepo.EXPECT().GetByIDs(gomock.Any(), []string{"p1"}, gomock.Any()).
+		Return([]*domain.PublicationWithLikeStatus{publication("p1", "bob")}, nil).Times(2)
+	// Notifications are off for the first search; the second found the publication before
+	deps.savedSearchRepo.EXPECT().AddMatches(gomock.Any(), "s1", []string{"p1"}, gomock.Any()).Return(1, 1, nil)
+	deps.savedSearchRepo.EXPECT().AddMatches(gomock.Any(), "s2", []string{"p1"}, gomock.Any()).Return(0, 1, nil)
+
+	require.NoError(t, uc.Run(context.Background(), &domain.Job{Type: JobRun}))
+}
+
+func TestRun_FailingSearchDoesNotStopOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	searchErr := errors.New("index unavailable")
+	broken := &domain.SavedSearch{ID: "s1", UserID: "alice", Query: "стоицизм", CreatedAt: time.Now()}
+	empty := &domain.SavedSearch{ID: "s2", UserID: "alice", Query: "сенека", CreatedAt: time.Now()}
+
+	deps.savedSearchRepo.EXPECT().List(gomock.Any(), "", runBatchSize).Return([]*domain.SavedSearch{broken, empty}, nil)
+	deps.index.EXPECT().Query(gomock.Any(), "стоицизм", gomock.Any(), gomock.Any(), gomock.Any(), 0).Return(nil, 0, searchErr)
+	deps.index.EXPECT().Query(gomock.Any(), "сенека", gomock.Any(), gomock.Any(), gomock.Any(), 0).Return(nil, 0, nil)
+	deps.savedSearchRepo.EXPECT().AddMatches(gomock.Any(), "s2", []string{}, gomock.Any()).Return(0, 0, nil)
+
+	err := uc.Run(context.Background(), &domain.Job{Type: JobRun})
+
+	assert.ErrorIs(t, err, searchErr)
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// Unified search limits
+const (
+	// DefaultGroupLimit is the number of top hits returned per entity type
+	DefaultGroupLimit = 5
+	// MaxGroupLimit caps the number of top hits per entity type
+	MaxGroupLimit = 20
+	// facetLimit caps the number of tag and author facet values
+	facetLimit = 10
+)
+
+// Group is the top hits of one entity type with the number of all hits
+type Group[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+// AllResults are the top hits of every entity type for one query with facets of the publications
+type AllResults struct {
+	Publications Group[*domain.PublicationSearchResult] `json:"publications"`
+	Facets       *domain.SearchFacets                   `json:"facets"`
+	Users        Group[*domain.UserSearchResult]        `json:"users"`
+	Tags         Group[*domain.Tag]                     `json:"tags"`
+	Sources      Group[*domain.SourceHit]               `json:"sources"`
+}
+
+// DateBuckets returns the publication date facets relative to now: the last day, week, month
+// and year, which overlap, and everything older than a year
+func DateBuckets(now time.Time) []domain.DateBucket {
+	day := now.Add(-24 * time.Hour)
+	week := now.AddDate(0, 0, -7)
+	month := now.AddDate(0, -1, 0)
+	year := now.AddDate(-1, 0, 0)
+	return []domain.DateBucket{
+		{Name: "day", DateFrom: &day},
+		{Name: "week", DateFrom: &week},
+		{Name: "month", DateFrom: &month},
+		{Name: "year", DateFrom: &year},
+		{Name: "older", DateTo: &year},
+	}
+}
+
+// SearchAll searches publications, users, tags and sources at once, returning up to limit top
+// hits of each; filters narrow the publications and their facets only
+func (uc *UseCase) SearchAll(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit int) (*AllResults, error) {
+	if limit <= 0 {
+		limit = DefaultGroupLimit
+	}
+	limit = min(limit, MaxGroupLimit)
+
+	var (
+		results AllResults
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+	)
+	run := func(group string, search func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := search(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("search %s: %w", group, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run("publications", func() (err error) {
+		results.Publications.Items, results.Publications.Total, err = uc.SearchPublications(ctx, query, viewerUserID, filters, limit, 0)
+		return err
+	})
+	run("facets", func() (err error) {
+		results.Facets, err = uc.publicationRepo.SearchFacets(ctx, query, viewerUserID, filters, DateBuckets(time.Now()), facetLimit)
+		return err
+	})
+	run("users", func() (err error) {
+		results.Users.Items, results.Users.Total, err = uc.userRepo.Search(ctx, query, viewerUserID, nil, limit, 0)
+		return err
+	})
+	run("tags", func() (err error) {
+		results.Tags.Items, results.Tags.Total, err = uc.tagRepo.GetPopular(ctx, limit, &query)
+		return err
+	})
+	run("sources", func() (err error) {
+		results.Sources.Items, results.Sources.Total, err = uc.publicationRepo.SearchSources(ctx, query, viewerUserID, limit)
+		return err
+	})
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	results.Publications.Items = nonNil(results.Publications.Items)
+	results.Users.Items = nonNil(results.Users.Items)
+	results.Tags.Items = nonNil(results.Tags.Items)
+	results.Sources.Items = nonNil(results.Sources.Items)
+	return &results, nil
+}
+
+// nonNil returns an empty slice for nil, so that empty groups are encoded as []
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,174 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/access"
+
+	"github.com/google/uuid"
+)
+
+// JobReindex is the background job type rebuilding the search index for a reindex task
+const JobReindex = "search.reindex"
+
+// reindexBatchSize is the number of publications loaded and indexed at a time
+const reindexBatchSize = 500
+
+// maxReindexErrors caps the errors kept on a task
+const maxReindexErrors = 20
+
+// WarmupFilters selects the publications to reindex; omitted filters match all
+type WarmupFilters struct {
+	Type       *domain.PublicationType `json:"type,omitempty" validate:"omitempty,oneof=quote post article"`
+	Visibility *domain.VisibilityType  `json:"visibility,omitempty" validate:"omitempty,oneof=public community private"`
+	AuthorID   *string                 `json:"author_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// WarmupRequest represents search reindex request
+type WarmupRequest struct {
+	Filters WarmupFilters `json:"filters"`
+}
+
+// ReindexPayload is the payload of a JobReindex job
+type ReindexPayload struct {
+	TaskID string `json:"task_id"`
+}
+
+// StartReindex creates a reindex task and queues its job
+func (uc *UseCase) StartReindex(ctx context.Context, userID string, req *WarmupRequest) (*domain.ReindexTask, error) {
+	if err := access.RequireSuperUser(ctx, uc.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	task := &domain.ReindexTask{
+		ID:          uuid.New().String(),
+		RequestedBy: userID,
+		Filters: domain.SearchFilters{
+			Type:       req.Filters.Type,
+			Visibility: req.Filters.Visibility,
+			AuthorID:   req.Filters.AuthorID,
+		},
+		Status:    domain.ReindexQueued,
+		Errors:    []string{},
+		CreatedAt: time.Now(),
+	}
+	if err := uc.reindexRepo.Create(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to create reindex task: %w", err)
+	}
+
+	if _, err := uc.jobs.Enqueue(ctx, JobReindex, &ReindexPayload{TaskID: task.ID}, time.Time{}); err != nil {
+		// The task is kept as failed so that its ID is not left queued forever
+		_ = uc.finishReindex(ctx, task, domain.ReindexFailed, err)
+		return nil, fmt.Errorf("failed to queue reindex: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetReindexTask retrieves the status and progress of a reindex task
+func (uc *UseCase) GetReindexTask(ctx context.Context, userID, taskID string) (*domain.ReindexTask, error) {
+	if err := access.RequireSuperUser(ctx, uc.userRepo, userID); err != nil {
+		return nil, err
+	}
+	return uc.reindexRepo.GetByID(ctx, taskID)
+}
+
+// Reindex runs a JobReindex job: it rebuilds the task's publications batch by batch, saving
+// progress after each, so a retried run resumes where the failed one stopped. The task fails
+// with the last attempt of the job
+func (uc *UseCase) Reindex(ctx context.Context, job *domain.Job) error {
+	var payload ReindexPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("decode reindex payload: %w", err)
+	}
+
+	task, err := uc.reindexRepo.GetByID(ctx, payload.TaskID)
+	if errors.Is(err, domain.ErrReindexTaskNotFound) {
+		// Nothing left to track
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if task.Status == domain.ReindexSucceeded || task.Status == domain.ReindexFailed {
+		return nil
+	}
+
+	if err := uc.runReindex(ctx, task); err != nil {
+		if job.Attempts < job.MaxAttempts {
+			return errors.Join(err, uc.recordReindexError(ctx, task, err))
+		}
+		return errors.Join(err, uc.finishReindex(ctx, task, domain.ReindexFailed, err))
+	}
+
+	return uc.finishReindex(ctx, task, domain.ReindexSucceeded, nil)
+}
+
+func (uc *UseCase) runReindex(ctx context.Context, task *domain.ReindexTask) error {
+	if task.StartedAt == nil {
+		total, err := uc.publicationRepo.CountForReindex(ctx, &task.Filters)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		task.Total = total
+		task.StartedAt = &now
+	}
+	task.Status = domain.ReindexRunning
+	if err := uc.reindexRepo.Update(ctx, task); err != nil {
+		return err
+	}
+
+	for {
+		cursor := ""
+		if task.Cursor != nil {
+			cursor = *task.Cursor
+		}
+		docs, err := uc.publicationRepo.ListSearchDocuments(ctx, &task.Filters, cursor, reindexBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		if err := uc.index.Index(ctx, docs...); err != nil {
+			return err
+		}
+
+		task.Processed += len(docs)
+		task.Cursor = &docs[len(docs)-1].ID
+		// Publications created since the start are reindexed too
+		task.Total = max(task.Total, task.Processed)
+		if err := uc.reindexRepo.Update(ctx, task); err != nil {
+			return err
+		}
+		if len(docs) < reindexBatchSize {
+			return nil
+		}
+	}
+}
+
+// recordReindexError keeps the error of a run on the task
+func (uc *UseCase) recordReindexError(ctx context.Context, task *domain.ReindexTask, runErr error) error {
+	task.Errors = append(task.Errors, runErr.Error())
+	if len(task.Errors) > maxReindexErrors {
+		task.Errors = task.Errors[len(task.Errors)-maxReindexErrors:]
+	}
+	return uc.reindexRepo.Update(ctx, task)
+}
+
+// finishReindex marks the task as finished with status, keeping runErr if any
+func (uc *UseCase) finishReindex(ctx context.Context, task *domain.ReindexTask, status domain.ReindexStatus, runErr error) error {
+	now := time.Now()
+	task.Status = status
+	task.FinishedAt = &now
+	if runErr != nil {
+		return uc.recordReindexError(ctx, task, runErr)
+	}
+	return uc.reindexRepo.Update(ctx, task)
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Query suggestion settings
+const (
+	// MaxSuggestLimit caps the number of suggestions for a query being typed
+	MaxSuggestLimit = 10
+	// popularQueriesWindow is how far back the searches behind popular queries are looked for
+	popularQueriesWindow = 30 * 24 * time.Hour
+	// minQuerySearchers is the number of users who must have searched for a query before it is
+	// suggested to others, so that nobody's single search is shown around
+	minQuerySearchers = 3
+)
+
+// JobPruneQueryLog is the scheduled background job type deleting old search queries and clicks
+const JobPruneQueryLog = "search.prune_query_log"
+
+// QueryLogRetention is how long search queries and clicks are kept
+const QueryLogRetention = 90 * 24 * time.Hour
+
+// PublicationSearch is a page of publication search results with hints for the client
+type PublicationSearch struct {
+	Items []*domain.PublicationSearchResult `json:"items"`
+	Total int                               `json:"total"`
+	// QueryID identifies the logged query in clicks on the results; the first page is logged only
+	QueryID string `json:"query_id,omitempty"`
+	// DidYouMean is the query with misspelled words corrected, offered when nothing is found
+	DidYouMean *string `json:"did_you_mean,omitempty"`
+}
+
+// ClickRequest represents a click on a search result
+type ClickRequest struct {
+	QueryID       string `json:"query_id" validate:"required,uuid"`
+	PublicationID string `json:"publication_id" validate:"required,uuid"`
+	Position      int    `json:"position" validate:"min=0"`
+}
+
+// Search searches publications for the search page: the first page of every query is logged
+// for suggestions, and a corrected query is offered when nothing is found. Logging failures do
+// not fail the search
+func (uc *UseCase) Search(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) (*PublicationSearch, error) {
+	items, total, err := uc.SearchPublications(ctx, query, viewerUserID, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	result := &PublicationSearch{Items: items, Total: total}
+
+	if offset == 0 {
+		entry := &domain.SearchQueryLog{
+			ID:           uuid.New().String(),
+			UserID:       viewerUserID,
+			Query:        query,
+			Normalized:   normalizeQuery(query),
+			ResultsCount: total,
+			CreatedAt:    time.Now(),
+		}
+		if err := uc.queryLog.LogQuery(ctx, entry); err == nil {
+			result.QueryID = entry.ID
+		}
+	}
+
+	if total == 0 {
+		result.DidYouMean, err = uc.didYouMean(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Suggest completes a query being typed with popular queries, then tag names, then words of
+// publication titles completing its last word
+func (uc *UseCase) Suggest(ctx context.Context, prefix string, limit int) ([]*domain.SearchSuggestion, error) {
+	prefix = normalizeQuery(prefix)
+	if prefix == "" {
+		return []*domain.SearchSuggestion{}, nil
+	}
+	limit = min(limit, MaxSuggestLimit)
+
+	suggestions := make([]*domain.SearchSuggestion, 0, limit)
+	seen := make(map[string]bool)
+	add := func(text string, source domain.SuggestionSource) {
+		if len(suggestions) < limit && !seen[text] {
+			seen[text] = true
+			suggestions = append(suggestions, &domain.SearchSuggestion{Text: text, Source: source})
+		}
+	}
+
+	queries, err := uc.queryLog.PopularQueries(ctx, prefix, time.Now().Add(-popularQueriesWindow), minQuerySearchers, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, query := range queries {
+		add(query, domain.SuggestionSourceQuery)
+	}
+
+	tags, _, err := uc.tagRepo.GetPopular(ctx, limit, &prefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if name := strings.ToLower(tag.Name); strings.HasPrefix(name, prefix) {
+			add(name, domain.SuggestionSourceTag)
+		}
+	}
+
+	head, last := "", prefix
+	if i := strings.LastIndexByte(prefix, ' '); i >= 0 {
+		head, last = prefix[:i+1], prefix[i+1:]
+	}
+	words, err := uc.index.Suggest(ctx, last, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, word := range words {
+		add(head+word, domain.SuggestionSourceTitle)
+	}
+
+	return suggestions, nil
+}
+
+// LogClick records that the viewer opened a result of their search; repeated clicks on the same
+// result are recorded once
+func (uc *UseCase) LogClick(ctx context.Context, viewerUserID *string, req *ClickRequest) error {
+	return uc.queryLog.LogClick(ctx, &domain.SearchClick{
+		QueryID:       req.QueryID,
+		PublicationID: req.PublicationID,
+		UserID:        viewerUserID,
+		Position:      req.Position,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// PruneQueryLog deletes search queries and clicks older than QueryLogRetention
+func (uc *UseCase) PruneQueryLog(ctx context.Context, job *domain.Job) error {
+	_, err := uc.queryLog.DeleteBefore(ctx, time.Now().Add(-QueryLogRetention))
+	return err
+}
+
+// didYouMean replaces the words of query unknown to the index with their corrections, returning
+// nil when nothing is corrected. Operators, excluded words and quoted phrases are kept as typed
+func (uc *UseCase) didYouMean(ctx context.Context, query string) (*string, error) {
+	fields := strings.Fields(query)
+	var words []string
+	for _, field := range fields {
+		if isPlainWord(field) {
+			words = append(words, field)
+		}
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	corrections, err := uc.index.Correct(ctx, words)
+	if err != nil || len(corrections) == 0 {
+		return nil, err
+	}
+	for i, field := range fields {
+		if correction, ok := corrections[field]; ok {
+			fields[i] = correction
+		}
+	}
+	corrected := strings.Join(fields, " ")
+	return &corrected, nil
+}
+
+// isPlainWord tells whether a query field is a word of letters and digits rather than an
+// operator or a part of a phrase
+func isPlainWord(field string) bool {
+	if field == "OR" {
+		return false
+	}
+	for _, r := range field {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeQuery lowercases query and collapses its whitespace
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
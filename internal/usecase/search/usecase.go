@@ -2,14 +2,30 @@ package search
 
 import (
 	"context"
+	"strings"
+
 	"sense-backend/internal/domain"
 )
 
+// MaxAutocompleteLimit caps the number of users offered by autocomplete
+const MaxAutocompleteLimit = 10
+
+// HandledEvents are the domain events keeping the search index up to date
+var HandledEvents = []domain.EventType{
+	domain.EventPublicationCreated,
+	domain.EventPublicationUpdated,
+	domain.EventPublicationDeleted,
+}
+
 // UseCase handles search use cases
 type UseCase struct {
 	publicationRepo domain.PublicationRepository
 	userRepo        domain.UserRepository
 	tagRepo         domain.TagRepository
+	index           domain.SearchIndex
+	queryLog        domain.SearchLogRepository
+	reindexRepo     domain.ReindexTaskRepository
+	jobs            domain.JobQueue
 }
 
 // NewUseCase creates a new search use case
@@ -17,22 +33,91 @@ func NewUseCase(
 	publicationRepo domain.PublicationRepository,
 	userRepo domain.UserRepository,
 	tagRepo domain.TagRepository,
+	index domain.SearchIndex,
+	queryLog domain.SearchLogRepository,
+	reindexRepo domain.ReindexTaskRepository,
+	jobs domain.JobQueue,
 ) *UseCase {
 	return &UseCase{
 		publicationRepo: publicationRepo,
 		userRepo:        userRepo,
 		tagRepo:         tagRepo,
+		index:           index,
+		queryLog:        queryLog,
+		reindexRepo:     reindexRepo,
+		jobs:            jobs,
 	}
 }
 
-// SearchPublications searches publications with like status for viewer
-func (uc *UseCase) SearchPublications(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.PublicationWithLikeStatus, int, error) {
-	return uc.publicationRepo.Search(ctx, query, viewerUserID, filters, limit, offset)
+// SearchPublications searches publications by relevance with like status and highlights for viewer
+func (uc *UseCase) SearchPublications(ctx context.Context, query string, viewerUserID *string, filters *domain.SearchFilters, limit, offset int) ([]*domain.PublicationSearchResult, int, error) {
+	hits, total, err := uc.index.Query(ctx, query, viewerUserID, filters, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(hits) == 0 {
+		return []*domain.PublicationSearchResult{}, total, nil
+	}
+
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	publications, err := uc.publicationRepo.GetByIDs(ctx, ids, viewerUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]*domain.PublicationWithLikeStatus, len(publications))
+	for _, publication := range publications {
+		byID[publication.ID] = publication
+	}
+
+	results := make([]*domain.PublicationSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		publication, ok := byID[hit.ID]
+		if !ok {
+			// Deleted since it was indexed, or hidden from the viewer by a rule the index does not know
+			continue
+		}
+		results = append(results, &domain.PublicationSearchResult{
+			PublicationWithLikeStatus: *publication,
+			Rank:                      hit.Rank,
+			Highlights:                hit.Highlights,
+		})
+	}
+	return results, total, nil
+}
+
+// SearchUsers searches users visible to viewer, tolerating typos in usernames
+func (uc *UseCase) SearchUsers(ctx context.Context, query string, viewerUserID *string, role *domain.UserRole, limit, offset int) ([]*domain.UserSearchResult, int, error) {
+	return uc.userRepo.Search(ctx, query, viewerUserID, role, limit, offset)
+}
+
+// AutocompleteUsers suggests users visible to viewer for a typed username prefix; a leading @
+// of a mention is ignored
+func (uc *UseCase) AutocompleteUsers(ctx context.Context, prefix string, viewerUserID *string, limit int) ([]*domain.UserSuggestion, error) {
+	prefix = strings.TrimPrefix(strings.TrimSpace(prefix), "@")
+	if prefix == "" {
+		return []*domain.UserSuggestion{}, nil
+	}
+	return uc.userRepo.Autocomplete(ctx, prefix, viewerUserID, min(limit, MaxAutocompleteLimit))
 }
 
-// SearchUsers searches users
-func (uc *UseCase) SearchUsers(ctx context.Context, query string, role *domain.UserRole, limit, offset int) ([]*domain.User, int, error) {
-	return uc.userRepo.Search(ctx, query, role, limit, offset)
+// Handle keeps the search index up to date with a created, updated or deleted publication
+func (uc *UseCase) Handle(ctx context.Context, event *domain.Event) error {
+	if event.Type == domain.EventPublicationDeleted {
+		return uc.index.Delete(ctx, event.TargetID)
+	}
+
+	docs, err := uc.publicationRepo.GetSearchDocuments(ctx, []string{event.TargetID})
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		// Deleted before the event was handled
+		return uc.index.Delete(ctx, event.TargetID)
+	}
+	return uc.index.Index(ctx, docs...)
 }
 
 // GetTags retrieves popular tags
@@ -2,6 +2,8 @@ package search
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -68,37 +70,42 @@ func TestSearchPublications_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	tagRepo := mocks.NewMockTagRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, index, nil, nil, nil)
 
 	const testUserID = "user-123"
 
 	query := "test query"
 	viewerUserID := testUserID
 	filters := &domain.SearchFilters{}
+	content := "<mark>Test</mark> publication"
 
-	publications := []*domain.PublicationWithLikeStatus{
-		createTestPublicationWithLikeStatus(),
-	}
-
+	index.EXPECT().
+		Query(gomock.Any(), query, &viewerUserID, filters, 10, 0).
+		Return([]*domain.SearchHit{{ID: "pub-123", Rank: 0.5, Highlights: domain.SearchHighlights{Title: "<mark>Test</mark> Title", Content: &content}}}, 1, nil)
 	publicationRepo.EXPECT().
-		Search(gomock.Any(), query, &viewerUserID, filters, 10, 0).
-		Return(publications, 1, nil)
+		GetByIDs(gomock.Any(), []string{"pub-123"}, &viewerUserID).
+		Return([]*domain.PublicationWithLikeStatus{createTestPublicationWithLikeStatus()}, nil)
 
 	result, total, err := uc.SearchPublications(context.Background(), query, &viewerUserID, filters, 10, 0)
 
 	require.NoError(t, err)
-	assert.Len(t, result, 1)
+	require.Len(t, result, 1)
 	assert.Equal(t, 1, total)
+	assert.Equal(t, "Test Title", result[0].Title)
+	assert.Equal(t, 0.5, result[0].Rank)
+	assert.Equal(t, "<mark>Test</mark> Title", result[0].Highlights.Title)
 }
 
-func TestSearchPublications_WithFilters(t *testing.T) {
+func TestSearchPublications_KeepsIndexOrderAndSkipsHidden(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	tagRepo := mocks.NewMockTagRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, index, nil, nil, nil)
 
 	const testUserID = "user-123"
 
@@ -114,19 +121,74 @@ func TestSearchPublications_WithFilters(t *testing.T) {
 		AuthorID:   &authorID,
 	}
 
-	publications := []*domain.PublicationWithLikeStatus{
-		createTestPublicationWithLikeStatus(),
-	}
+	first := createTestPublicationWithLikeStatus()
+	first.ID = "pub-1"
+	second := createTestPublicationWithLikeStatus()
+	second.ID = "pub-2"
 
+	index.EXPECT().
+		Query(gomock.Any(), query, &viewerUserID, filters, 20, 10).
+		Return([]*domain.SearchHit{{ID: "pub-2", Rank: 0.9}, {ID: "pub-hidden", Rank: 0.7}, {ID: "pub-1", Rank: 0.5}}, 13, nil)
 	publicationRepo.EXPECT().
-		Search(gomock.Any(), query, &viewerUserID, filters, 20, 10).
-		Return(publications, 1, nil)
+		GetByIDs(gomock.Any(), []string{"pub-2", "pub-hidden", "pub-1"}, &viewerUserID).
+		Return([]*domain.PublicationWithLikeStatus{first, second}, nil)
 
 	result, total, err := uc.SearchPublications(context.Background(), query, &viewerUserID, filters, 20, 10)
 
 	require.NoError(t, err)
-	assert.Len(t, result, 1)
-	assert.Equal(t, 1, total)
+	require.Len(t, result, 2)
+	assert.Equal(t, 13, total)
+	assert.Equal(t, "pub-2", result[0].ID)
+	assert.Equal(t, "pub-1", result[1].ID)
+}
+
+func TestSearchPublications_NoHits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(mocks.NewMockPublicationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockTagRepository(ctrl), index, nil, nil, nil)
+
+	index.EXPECT().Query(gomock.Any(), testQuery, nil, nil, 10, 0).Return(nil, 0, nil)
+
+	result, total, err := uc.SearchPublications(context.Background(), testQuery, nil, nil, 10, 0)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+	assert.Equal(t, 0, total)
+}
+
+func TestHandle_IndexesUpdatedPublication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(publicationRepo, mocks.NewMockUserRepository(ctrl), mocks.NewMockTagRepository(ctrl), index, nil, nil, nil)
+	doc := &domain.SearchDocument{ID: "pub-123", Title: "Test Title"}
+
+	publicationRepo.EXPECT().GetSearchDocuments(gomock.Any(), []string{"pub-123"}).Return([]*domain.SearchDocument{doc}, nil)
+	index.EXPECT().Index(gomock.Any(), doc).Return(nil)
+
+	require.NoError(t, uc.Handle(context.Background(), domain.NewEvent(domain.EventPublicationUpdated, "user-123", "pub-123")))
+}
+
+func TestHandle_DeletesMissingPublication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(publicationRepo, mocks.NewMockUserRepository(ctrl), mocks.NewMockTagRepository(ctrl), index, nil, nil, nil)
+
+	// Created, then deleted before the event was handled
+	publicationRepo.EXPECT().GetSearchDocuments(gomock.Any(), []string{"pub-123"}).Return(nil, nil)
+	index.EXPECT().Delete(gomock.Any(), "pub-123").Return(nil)
+	require.NoError(t, uc.Handle(context.Background(), domain.NewEvent(domain.EventPublicationCreated, "user-123", "pub-123")))
+
+	index.EXPECT().Delete(gomock.Any(), "pub-456").Return(nil)
+	require.NoError(t, uc.Handle(context.Background(), domain.NewEvent(domain.EventPublicationDeleted, "user-123", "pub-456")))
 }
 
 func TestSearchUsers_Success(t *testing.T) {
@@ -136,19 +198,19 @@ func TestSearchUsers_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	tagRepo := mocks.NewMockTagRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, nil, nil, nil, nil)
 
 	query := testQuery
 
-	users := []*domain.User{
-		createTestUser(),
+	users := []*domain.UserSearchResult{
+		{UserCard: domain.UserCard{User: *createTestUser()}, Match: domain.UserMatchFuzzy},
 	}
 
 	userRepo.EXPECT().
-		Search(gomock.Any(), query, nil, 10, 0).
+		Search(gomock.Any(), query, nil, nil, 10, 0).
 		Return(users, 1, nil)
 
-	result, total, err := uc.SearchUsers(context.Background(), query, nil, 10, 0)
+	result, total, err := uc.SearchUsers(context.Background(), query, nil, nil, 10, 0)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 1)
@@ -162,26 +224,137 @@ func TestSearchUsers_WithRole(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	tagRepo := mocks.NewMockTagRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, nil, nil, nil, nil)
 
 	query := testQuery
 	role := domain.UserRoleCreator
 
-	users := []*domain.User{
-		createTestUser(),
+	users := []*domain.UserSearchResult{
+		{UserCard: domain.UserCard{User: *createTestUser()}, Match: domain.UserMatchFuzzy},
 	}
 
 	userRepo.EXPECT().
-		Search(gomock.Any(), query, &role, 10, 0).
+		Search(gomock.Any(), query, nil, &role, 10, 0).
 		Return(users, 1, nil)
 
-	result, total, err := uc.SearchUsers(context.Background(), query, &role, 10, 0)
+	result, total, err := uc.SearchUsers(context.Background(), query, nil, &role, 10, 0)
 
 	require.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, 1, total)
 }
 
+func TestAutocompleteUsers_StripsMentionAndCapsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(mocks.NewMockPublicationRepository(ctrl), userRepo, mocks.NewMockTagRepository(ctrl), nil, nil, nil, nil)
+	viewerID := "user-123"
+
+	userRepo.EXPECT().
+		Autocomplete(gomock.Any(), "ann", &viewerID, MaxAutocompleteLimit).
+		Return([]*domain.UserSuggestion{{ID: "user-456", Username: "anna", IsFollowedByMe: true}}, nil)
+
+	result, err := uc.AutocompleteUsers(context.Background(), " @ann", &viewerID, 50)
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "anna", result[0].Username)
+}
+
+func TestAutocompleteUsers_EmptyPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUseCase(mocks.NewMockPublicationRepository(ctrl), mocks.NewMockUserRepository(ctrl), mocks.NewMockTagRepository(ctrl), nil, nil, nil, nil)
+
+	result, err := uc.AutocompleteUsers(context.Background(), "@", nil, 10)
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestSearchAll_GroupsHitsAndFacets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tagRepo := mocks.NewMockTagRepository(ctrl)
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, index, nil, nil, nil)
+
+	viewerID := "user-123"
+	filters := &domain.SearchFilters{Tags: []string{"testtag"}}
+	facets := &domain.SearchFacets{Types: []*domain.FacetCount{{Value: "post", Count: 1}}}
+
+	index.EXPECT().
+		Query(gomock.Any(), testQuery, &viewerID, filters, MaxGroupLimit, 0).
+		Return([]*domain.SearchHit{{ID: "pub-123"}}, 1, nil)
+	publicationRepo.EXPECT().
+		GetByIDs(gomock.Any(), []string{"pub-123"}, &viewerID).
+		Return([]*domain.PublicationWithLikeStatus{createTestPublicationWithLikeStatus()}, nil)
+	publicationRepo.EXPECT().
+		SearchFacets(gomock.Any(), testQuery, &viewerID, filters, gomock.Len(5), facetLimit).
+		Return(facets, nil)
+	userRepo.EXPECT().
+		Search(gomock.Any(), testQuery, &viewerID, nil, MaxGroupLimit, 0).
+		Return(nil, 0, nil)
+	tagRepo.EXPECT().
+		GetPopular(gomock.Any(), MaxGroupLimit, gomock.Any()).
+		Return([]*domain.Tag{createTestTag()}, 3, nil)
+	publicationRepo.EXPECT().
+		SearchSources(gomock.Any(), testQuery, &viewerID, MaxGroupLimit).
+		Return([]*domain.SourceHit{{Source: "Test source", PublicationsCount: 2}}, 1, nil)
+
+	result, err := uc.SearchAll(context.Background(), testQuery, &viewerID, filters, 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Publications.Total)
+	assert.Same(t, facets, result.Facets)
+	assert.NotNil(t, result.Users.Items)
+	assert.Empty(t, result.Users.Items)
+	assert.Equal(t, 3, result.Tags.Total)
+	assert.Equal(t, "Test source", result.Sources.Items[0].Source)
+}
+
+func TestSearchAll_ReportsFailedGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tagRepo := mocks.NewMockTagRepository(ctrl)
+	index := mocks.NewMockSearchIndex(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, index, nil, nil, nil)
+
+	index.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), DefaultGroupLimit, 0).Return(nil, 0, nil)
+	publicationRepo.EXPECT().SearchFacets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.SearchFacets{}, nil)
+	userRepo.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, errors.New("boom"))
+	tagRepo.EXPECT().GetPopular(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, nil)
+	publicationRepo.EXPECT().SearchSources(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, nil)
+
+	_, err := uc.SearchAll(context.Background(), testQuery, nil, nil, 0)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "search users")
+}
+
+func TestDateBuckets(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	buckets := DateBuckets(now)
+
+	require.Len(t, buckets, 5)
+	assert.Equal(t, "day", buckets[0].Name)
+	assert.Equal(t, now.Add(-24*time.Hour), *buckets[0].DateFrom)
+	assert.Equal(t, time.Date(2025, 2, 15, 12, 0, 0, 0, time.UTC), *buckets[2].DateFrom)
+	assert.Equal(t, "older", buckets[4].Name)
+	assert.Nil(t, buckets[4].DateFrom)
+	assert.Equal(t, *buckets[3].DateFrom, *buckets[4].DateTo)
+}
+
 func TestGetTags_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -189,7 +362,7 @@ func TestGetTags_Success(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	tagRepo := mocks.NewMockTagRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, nil, nil, nil, nil)
 
 	tags := []*domain.Tag{
 		createTestTag(),
@@ -213,7 +386,7 @@ func TestGetTags_WithSearch(t *testing.T) {
 	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
 	userRepo := mocks.NewMockUserRepository(ctrl)
 	tagRepo := mocks.NewMockTagRepository(ctrl)
-	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo, nil, nil, nil, nil)
 
 	search := testQuery
 	tags := []*domain.Tag{
@@ -230,3 +403,203 @@ func TestGetTags_WithSearch(t *testing.T) {
 	assert.Len(t, result, 1)
 	assert.Equal(t, 1, total)
 }
+
+type reindexDeps struct {
+	publicationRepo *mocks.MockPublicationRepository
+	userRepo        *mocks.MockUserRepository
+	index           *mocks.MockSearchIndex
+	reindexRepo     *mocks.MockReindexTaskRepository
+	jobs            *mocks.MockJobQueue
+}
+
+func newReindexUseCase(ctrl *gomock.Controller) (*UseCase, *reindexDeps) {
+	deps := &reindexDeps{
+		publicationRepo: mocks.NewMockPublicationRepository(ctrl),
+		userRepo:        mocks.NewMockUserRepository(ctrl),
+		index:           mocks.NewMockSearchIndex(ctrl),
+		reindexRepo:     mocks.NewMockReindexTaskRepository(ctrl),
+		jobs:            mocks.NewMockJobQueue(ctrl),
+	}
+	uc := NewUseCase(deps.publicationRepo, deps.userRepo, mocks.NewMockTagRepository(ctrl), deps.index, nil, deps.reindexRepo, deps.jobs)
+	return uc, deps
+}
+
+func reindexJob(t *testing.T, taskID string, attempts int) *domain.Job {
+	payload, err := json.Marshal(&ReindexPayload{TaskID: taskID})
+	require.NoError(t, err)
+	return &domain.Job{Type: JobReindex, Payload: payload, Attempts: attempts, MaxAttempts: 3}
+}
+
+func TestStartReindex_RequiresSuperUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newReindexUseCase(ctrl)
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "user-123").Return(createTestUser(), nil)
+
+	_, err := uc.StartReindex(context.Background(), "user-123", &WarmupRequest{})
+
+	assert.ErrorIs(t, err, domain.ErrSuperUserRequired)
+}
+
+func TestStartReindex_QueuesJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newReindexUseCase(ctrl)
+	pubType := domain.PublicationTypePost
+
+	deps.userRepo.EXPECT().GetByID(gomock.Any(), "root").Return(&domain.User{ID: "root", Role: domain.UserRoleSuper}, nil)
+	deps.reindexRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	deps.jobs.EXPECT().Enqueue(gomock.Any(), JobReindex, gomock.Any(), time.Time{}).
+		DoAndReturn(func(_ context.Context, _ string, payload interface{}, _ time.Time) (*domain.Job, error) {
+			assert.NotEmpty(t, payload.(*ReindexPayload).TaskID)
+			return &domain.Job{ID: "job-1"}, nil
+		})
+
+	task, err := uc.StartReindex(context.Background(), "root", &WarmupRequest{Filters: WarmupFilters{Type: &pubType}})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReindexQueued, task.Status)
+	assert.Equal(t, &pubType, task.Filters.Type)
+	assert.Equal(t, "root", task.RequestedBy)
+}
+
+func TestReindex_ResumesFromCursorAndSucceeds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newReindexUseCase(ctrl)
+	started := time.Now().Add(-time.Minute)
+	cursor := "pub-100"
+	task := &domain.ReindexTask{
+		ID: "task-1", Status: domain.ReindexRunning, Total: 102, Processed: 100,
+		Cursor: &cursor, StartedAt: &started, Errors: []string{"connection reset"},
+	}
+
+	deps.reindexRepo.EXPECT().GetByID(gomock.Any(), "task-1").Return(task, nil)
+	deps.reindexRepo.EXPECT().Update(gomock.Any(), task).Return(nil).Times(3)
+	docs := []*domain.SearchDocument{{ID: "pub-101"}, {ID: "pub-102"}}
+	deps.publicationRepo.EXPECT().ListSearchDocuments(gomock.Any(), &task.Filters, "pub-100", reindexBatchSize).Return(docs, nil)
+	deps.index.EXPECT().Index(gomock.Any(), docs[0], docs[1]).Return(nil)
+
+	require.NoError(t, uc.Reindex(context.Background(), reindexJob(t, "task-1", 2)))
+
+	assert.Equal(t, domain.ReindexSucceeded, task.Status)
+	assert.Equal(t, 102, task.Processed)
+	assert.Equal(t, "pub-102", *task.Cursor)
+	assert.NotNil(t, task.FinishedAt)
+}
+
+func TestReindex_FailsOnLastAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newReindexUseCase(ctrl)
+	dbErr := errors.New("statement timeout")
+
+	for attempt, status := range map[int]domain.ReindexStatus{1: domain.ReindexRunning, 3: domain.ReindexFailed} {
+		task := &domain.ReindexTask{ID: "task-1", Status: domain.ReindexQueued, Errors: []string{}}
+		deps.reindexRepo.EXPECT().GetByID(gomock.Any(), "task-1").Return(task, nil)
+		deps.publicationRepo.EXPECT().CountForReindex(gomock.Any(), &task.Filters).Return(40, nil)
+		deps.reindexRepo.EXPECT().Update(gomock.Any(), task).Return(nil).Times(2)
+		deps.publicationRepo.EXPECT().ListSearchDocuments(gomock.Any(), gomock.Any(), "", reindexBatchSize).Return(nil, dbErr)
+
+		err := uc.Reindex(context.Background(), reindexJob(t, "task-1", attempt))
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.Equal(t, status, task.Status)
+		assert.Equal(t, 40, task.Total)
+		assert.Equal(t, []string{"statement timeout"}, task.Errors)
+	}
+}
+
+type suggestDeps struct {
+	tagRepo  *mocks.MockTagRepository
+	index    *mocks.MockSearchIndex
+	queryLog *mocks.MockSearchLogRepository
+}
+
+func newSuggestUseCase(ctrl *gomock.Controller) (*UseCase, *suggestDeps) {
+	deps := &suggestDeps{
+		tagRepo:  mocks.NewMockTagRepository(ctrl),
+		index:    mocks.NewMockSearchIndex(ctrl),
+		queryLog: mocks.NewMockSearchLogRepository(ctrl),
+	}
+	uc := NewUseCase(mocks.NewMockPublicationRepository(ctrl), mocks.NewMockUserRepository(ctrl), deps.tagRepo, deps.index, deps.queryLog, nil, nil)
+	return uc, deps
+}
+
+func TestSearch_LogsQueryAndOffersCorrection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newSuggestUseCase(ctrl)
+	query := `Stoicsm  "marcus aurelius" OR senca -epictetus`
+
+	deps.index.EXPECT().Query(gomock.Any(), query, nil, nil, 20, 0).Return(nil, 0, nil)
+	deps.queryLog.EXPECT().LogQuery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, entry *domain.SearchQueryLog) error {
+		assert.Equal(t, `stoicsm "marcus aurelius" or senca -epictetus`, entry.Normalized)
+		assert.Equal(t, 0, entry.ResultsCount)
+		assert.Nil(t, entry.UserID)
+		return nil
+	})
+	// Operators, phrases and excluded words are not corrected
+	deps.index.EXPECT().Correct(gomock.Any(), []string{"Stoicsm", "senca"}).
+		Return(map[string]string{"Stoicsm": "stoicism", "senca": "seneca"}, nil)
+
+	result, err := uc.Search(context.Background(), query, nil, nil, 20, 0)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.QueryID)
+	require.NotNil(t, result.DidYouMean)
+	assert.Equal(t, `stoicism "marcus aurelius" OR seneca -epictetus`, *result.DidYouMean)
+}
+
+func TestSearch_NextPageIsNotLoggedAndLogFailureIsIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newSuggestUseCase(ctrl)
+
+	deps.index.EXPECT().Query(gomock.Any(), testQuery, nil, nil, 20, 20).Return(nil, 25, nil)
+
+	result, err := uc.Search(context.Background(), testQuery, nil, nil, 20, 20)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.QueryID)
+	assert.Nil(t, result.DidYouMean)
+
+	deps.index.EXPECT().Query(gomock.Any(), testQuery, nil, nil, 20, 0).Return(nil, 0, nil)
+	deps.queryLog.EXPECT().LogQuery(gomock.Any(), gomock.Any()).Return(errors.New("log unavailable"))
+	deps.index.EXPECT().Correct(gomock.Any(), []string{testQuery}).Return(map[string]string{}, nil)
+
+	result, err = uc.Search(context.Background(), testQuery, nil, nil, 20, 0)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.QueryID)
+	assert.Nil(t, result.DidYouMean)
+}
+
+func TestSuggest_MergesSourcesWithoutDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newSuggestUseCase(ctrl)
+	prefix := "стоицизм и с"
+
+	deps.queryLog.EXPECT().PopularQueries(gomock.Any(), prefix, gomock.Any(), minQuerySearchers, 4).
+		Return([]string{"стоицизм и сенека"}, nil)
+	deps.tagRepo.EXPECT().GetPopular(gomock.Any(), 4, &prefix).Return([]*domain.Tag{{Name: "Стоицизм и Современность"}}, 1, nil)
+	deps.index.EXPECT().Suggest(gomock.Any(), "с", 4).Return([]string{"сенека", "свобода", "смерть"}, nil)
+
+	suggestions, err := uc.Suggest(context.Background(), "  Стоицизм  и С", 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.SearchSuggestion{
+		{Text: "стоицизм и сенека", Source: domain.SuggestionSourceQuery},
+		{Text: "стоицизм и современность", Source: domain.SuggestionSourceTag},
+		{Text: "стоицизм и свобода", Source: domain.SuggestionSourceTitle},
+		{Text: "стоицизм и смерть", Source: domain.SuggestionSourceTitle},
+	}, suggestions)
+}
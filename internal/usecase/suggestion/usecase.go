@@ -0,0 +1,127 @@
+package suggestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sense-backend/internal/domain"
+)
+
+// ErrSelfDismiss is returned when a user tries to dismiss themselves
+var ErrSelfDismiss = errors.New("cannot dismiss yourself")
+
+// Weights set how much each social graph signal contributes to a suggestion score
+type Weights struct {
+	MutualFollows float64
+	CoLikes       float64
+	SharedTags    float64
+}
+
+// DefaultWeights favour people the user's network follows over shared tastes
+var DefaultWeights = Weights{
+	MutualFollows: 3,
+	CoLikes:       2,
+	SharedTags:    1,
+}
+
+// candidatePoolFactor is how many more candidates are preselected than returned, so that
+// weighting can reorder them
+const candidatePoolFactor = 3
+
+// UseCase handles follow suggestion use cases
+type UseCase struct {
+	suggestionRepo domain.SuggestionRepository
+	userRepo       domain.UserRepository
+	weights        Weights
+}
+
+// NewUseCase creates a new follow suggestion use case
+func NewUseCase(suggestionRepo domain.SuggestionRepository, userRepo domain.UserRepository, weights Weights) *UseCase {
+	return &UseCase{
+		suggestionRepo: suggestionRepo,
+		userRepo:       userRepo,
+		weights:        weights,
+	}
+}
+
+// GetSuggestions retrieves up to limit users the user may want to follow, best first
+func (uc *UseCase) GetSuggestions(ctx context.Context, userID string, limit int) ([]*domain.FollowSuggestion, error) {
+	candidates, err := uc.suggestionRepo.GetCandidates(ctx, userID, limit*candidatePoolFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*domain.FollowSuggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		score := uc.score(candidate)
+		if score <= 0 {
+			continue
+		}
+		suggestions = append(suggestions, &domain.FollowSuggestion{
+			User:          candidate.User,
+			Score:         score,
+			Reason:        uc.reason(candidate),
+			MutualFollows: candidate.MutualFollows,
+			CoLikes:       candidate.CoLikes,
+			SharedTags:    candidate.SharedTags,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// Dismiss hides a user from the user's suggestions
+func (uc *UseCase) Dismiss(ctx context.Context, userID, dismissedID string) error {
+	if userID == dismissedID {
+		return ErrSelfDismiss
+	}
+	// Check if user exists
+	if _, err := uc.userRepo.GetByID(ctx, dismissedID); err != nil {
+		return err
+	}
+	return uc.suggestionRepo.Dismiss(ctx, userID, dismissedID)
+}
+
+func (uc *UseCase) score(c *domain.SuggestionCandidate) float64 {
+	return uc.weights.MutualFollows*float64(c.MutualFollows) +
+		uc.weights.CoLikes*float64(c.CoLikes) +
+		uc.weights.SharedTags*float64(c.SharedTags)
+}
+
+// reason explains the signal that contributes most to the score
+func (uc *UseCase) reason(c *domain.SuggestionCandidate) string {
+	mutual := uc.weights.MutualFollows * float64(c.MutualFollows)
+	coLikes := uc.weights.CoLikes * float64(c.CoLikes)
+	tags := uc.weights.SharedTags * float64(c.SharedTags)
+
+	switch {
+	case mutual > 0 && mutual >= coLikes && mutual >= tags:
+		return mutualReason(c)
+	case coLikes > 0 && coLikes >= tags:
+		return fmt.Sprintf("Нравятся те же публикации, что и вам: %d", c.CoLikes)
+	case c.TopTag != nil:
+		return fmt.Sprintf("Публикует по интересной вам теме #%s", *c.TopTag)
+	default:
+		return "Публикует по интересным вам темам"
+	}
+}
+
+func mutualReason(c *domain.SuggestionCandidate) string {
+	if len(c.MutualNames) == 0 {
+		return fmt.Sprintf("Подписаны ваши подписки: %d", c.MutualFollows)
+	}
+	reason := "Подписаны " + strings.Join(c.MutualNames, ", ")
+	if rest := c.MutualFollows - len(c.MutualNames); rest > 0 {
+		reason += fmt.Sprintf(" и ещё %d", rest)
+	}
+	return reason
+}
@@ -0,0 +1,89 @@
+package suggestion
+
+import (
+	"context"
+	"testing"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestGetSuggestions_RanksByWeightsWithReasons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	suggestionRepo := mocks.NewMockSuggestionRepository(ctrl)
+	uc := NewUseCase(suggestionRepo, mocks.NewMockUserRepository(ctrl), DefaultWeights)
+
+	suggestionRepo.EXPECT().
+		GetCandidates(gomock.Any(), "user-1", 2*candidatePoolFactor).
+		Return([]*domain.SuggestionCandidate{
+			{User: &domain.User{ID: "tags"}, SharedTags: 4, TopTag: strPtr("go")},
+			{User: &domain.User{ID: "friends"}, MutualFollows: 3, MutualNames: []string{"alice", "bob"}},
+			{User: &domain.User{ID: "likes"}, CoLikes: 1},
+		}, nil)
+
+	suggestions, err := uc.GetSuggestions(context.Background(), "user-1", 2)
+
+	require.NoError(t, err)
+	require.Len(t, suggestions, 2)
+	assert.Equal(t, "friends", suggestions[0].User.ID)
+	assert.Equal(t, "Подписаны alice, bob и ещё 1", suggestions[0].Reason)
+	assert.Equal(t, "tags", suggestions[1].User.ID)
+	assert.Equal(t, "Публикует по интересной вам теме #go", suggestions[1].Reason)
+}
+
+func TestGetSuggestions_SkipsCandidatesWithoutSignals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	suggestionRepo := mocks.NewMockSuggestionRepository(ctrl)
+	uc := NewUseCase(suggestionRepo, mocks.NewMockUserRepository(ctrl), Weights{CoLikes: 1})
+
+	suggestionRepo.EXPECT().
+		GetCandidates(gomock.Any(), "user-1", 10*candidatePoolFactor).
+		Return([]*domain.SuggestionCandidate{
+			{User: &domain.User{ID: "friends"}, MutualFollows: 3},
+			{User: &domain.User{ID: "likes"}, CoLikes: 2},
+		}, nil)
+
+	suggestions, err := uc.GetSuggestions(context.Background(), "user-1", 10)
+
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "likes", suggestions[0].User.ID)
+	assert.Equal(t, "Нравятся те же публикации, что и вам: 2", suggestions[0].Reason)
+}
+
+func TestDismiss_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUseCase(mocks.NewMockSuggestionRepository(ctrl), mocks.NewMockUserRepository(ctrl), DefaultWeights)
+
+	err := uc.Dismiss(context.Background(), "user-1", "user-1")
+
+	assert.ErrorIs(t, err, ErrSelfDismiss)
+}
+
+func TestDismiss_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	suggestionRepo := mocks.NewMockSuggestionRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	uc := NewUseCase(suggestionRepo, userRepo, DefaultWeights)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-2").Return(&domain.User{ID: "user-2"}, nil)
+	suggestionRepo.EXPECT().Dismiss(gomock.Any(), "user-1", "user-2").Return(nil)
+
+	require.NoError(t, uc.Dismiss(context.Background(), "user-1", "user-2"))
+}
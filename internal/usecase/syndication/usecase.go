@@ -0,0 +1,220 @@
+package syndication
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 -- used for ETag fingerprints, not for security
+	"encoding/hex"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// FeedSize is the number of latest publications included in a syndication feed
+const FeedSize = 50
+
+// Kind is the subject a feed is built for
+type Kind string
+
+const (
+	KindUser Kind = "user"
+	KindTag  Kind = "tag"
+)
+
+// Item is a feed entry with its content rendered as HTML
+type Item struct {
+	Publication *domain.PublicationWithLikeStatus
+	AuthorName  string
+	ContentHTML string
+}
+
+// Channel is a format-independent syndication feed
+type Channel struct {
+	Kind        Kind
+	Subject     string // user ID or tag name
+	Title       string
+	Description string
+	Updated     time.Time
+	Items       []*Item
+}
+
+// ETag returns a strong validator for one representation of the channel;
+// it changes whenever the set of items or any item changes
+func (c *Channel) ETag(format string) string {
+	h := sha1.New() // #nosec G401 -- fingerprint only
+	_, _ = fmt.Fprintf(h, "%s:%s:%s:%d", format, c.Kind, c.Subject, c.Updated.UnixNano())
+	for _, item := range c.Items {
+		_, _ = fmt.Fprintf(h, "|%s:%d", item.Publication.ID, item.Publication.UpdatedAt.UnixNano())
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// UseCase handles syndication feed use cases
+type UseCase struct {
+	publicationRepo domain.PublicationRepository
+	userRepo        domain.UserRepository
+	tagRepo         domain.TagRepository
+}
+
+// NewUseCase creates a new syndication use case
+func NewUseCase(
+	publicationRepo domain.PublicationRepository,
+	userRepo domain.UserRepository,
+	tagRepo domain.TagRepository,
+) *UseCase {
+	return &UseCase{
+		publicationRepo: publicationRepo,
+		userRepo:        userRepo,
+		tagRepo:         tagRepo,
+	}
+}
+
+// GetUserFeed builds the feed of public publications of a user
+func (uc *UseCase) GetUserFeed(ctx context.Context, userID string) (*Channel, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	public := domain.VisibilityTypePublic
+	filters := &domain.PublicationFilters{Visibility: &public}
+	publications, _, err := uc.publicationRepo.GetByAuthor(ctx, userID, nil, filters, FeedSize, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Публикации пользователя %s", user.Username)
+	if user.Description != nil && *user.Description != "" {
+		description = *user.Description
+	}
+
+	channel := &Channel{
+		Kind:        KindUser,
+		Subject:     user.ID,
+		Title:       user.Username,
+		Description: description,
+		Updated:     user.RegisteredAt,
+	}
+	authors := map[string]string{user.ID: user.Username}
+	if err := uc.fillItems(ctx, channel, publications, authors); err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// GetTagFeed builds the feed of public publications with a tag
+func (uc *UseCase) GetTagFeed(ctx context.Context, tagName string) (*Channel, error) {
+	tag, err := uc.tagRepo.GetByName(ctx, tagName)
+	if err != nil {
+		return nil, fmt.Errorf("tag not found: %w", err)
+	}
+
+	// A nil viewer restricts the query to public publications
+	publications, _, err := uc.publicationRepo.GetByTag(ctx, tag.ID, nil, FeedSize, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Публикации с тегом #%s", tag.Name)
+	if tag.Description != nil && *tag.Description != "" {
+		description = *tag.Description
+	}
+
+	channel := &Channel{
+		Kind:        KindTag,
+		Subject:     tag.Name,
+		Title:       "#" + tag.Name,
+		Description: description,
+		Updated:     tag.CreatedAt,
+	}
+	if err := uc.fillItems(ctx, channel, publications, map[string]string{}); err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// fillItems converts publications to feed items, resolving author names and the feed update time
+func (uc *UseCase) fillItems(ctx context.Context, channel *Channel, publications []*domain.PublicationWithLikeStatus, authors map[string]string) error {
+	for _, pub := range publications {
+		// Guard against non-public rows even if the repository query changes
+		if pub.Visibility != domain.VisibilityTypePublic || pub.CommunityID != nil {
+			continue
+		}
+
+		name, ok := authors[pub.AuthorID]
+		if !ok {
+			author, err := uc.userRepo.GetByID(ctx, pub.AuthorID)
+			if err != nil {
+				return err
+			}
+			name = author.Username
+			authors[pub.AuthorID] = name
+		}
+
+		channel.Items = append(channel.Items, &Item{
+			Publication: pub,
+			AuthorName:  name,
+			ContentHTML: RenderHTML(&pub.Publication),
+		})
+		if pub.UpdatedAt.After(channel.Updated) {
+			channel.Updated = pub.UpdatedAt
+		}
+	}
+
+	return nil
+}
+
+// RenderHTML renders publication text as HTML: blank lines separate paragraphs,
+// single newlines become line breaks, quotes are wrapped in a blockquote with their source
+func RenderHTML(pub *domain.Publication) string {
+	text := ""
+	if pub.Content != nil {
+		text = *pub.Content
+	}
+
+	var b strings.Builder
+	paragraphs := renderParagraphs(text)
+	if pub.Type == domain.PublicationTypeQuote {
+		b.WriteString("<blockquote>")
+		b.WriteString(paragraphs)
+		b.WriteString("</blockquote>")
+		if pub.Source != nil && *pub.Source != "" {
+			b.WriteString("<p>— <cite>")
+			b.WriteString(html.EscapeString(*pub.Source))
+			b.WriteString("</cite></p>")
+		}
+		return b.String()
+	}
+
+	b.WriteString(paragraphs)
+	if pub.Source != nil && *pub.Source != "" {
+		b.WriteString("<p>Источник: ")
+		b.WriteString(html.EscapeString(*pub.Source))
+		b.WriteString("</p>")
+	}
+	return b.String()
+}
+
+func renderParagraphs(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var b strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(strings.TrimSpace(line))
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.Join(lines, "<br>"))
+		b.WriteString("</p>")
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package syndication
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestGetUserFeed_PublicOnlyAndUpdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tagRepo := mocks.NewMockTagRepository(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+
+	registered := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	edited := time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: "user-1", Username: "alice", RegisteredAt: registered}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-1").Return(user, nil)
+	publicationRepo.EXPECT().
+		GetByAuthor(gomock.Any(), "user-1", nil, gomock.Any(), FeedSize, 0).
+		DoAndReturn(func(_ context.Context, _ string, _ *string, filters *domain.PublicationFilters, _, _ int) ([]*domain.PublicationWithLikeStatus, int, error) {
+			require.NotNil(t, filters.Visibility)
+			assert.Equal(t, domain.VisibilityTypePublic, *filters.Visibility)
+			return []*domain.PublicationWithLikeStatus{
+				{Publication: domain.Publication{
+					ID: "pub-1", AuthorID: "user-1", Title: "Edited",
+					PublicationDate: registered.Add(time.Hour), UpdatedAt: edited,
+					Visibility: domain.VisibilityTypePublic,
+				}},
+				{Publication: domain.Publication{
+					ID: "pub-2", AuthorID: "user-1", Title: "Hidden",
+					PublicationDate: registered, UpdatedAt: edited.Add(time.Hour),
+					Visibility: domain.VisibilityTypeCommunity,
+				}},
+			}, 2, nil
+		})
+
+	channel, err := uc.GetUserFeed(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	require.Len(t, channel.Items, 1)
+	assert.Equal(t, "pub-1", channel.Items[0].Publication.ID)
+	assert.Equal(t, "alice", channel.Items[0].AuthorName)
+	assert.Equal(t, edited, channel.Updated)
+	assert.Equal(t, KindUser, channel.Kind)
+}
+
+func TestGetUserFeed_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tagRepo := mocks.NewMockTagRepository(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "missing").Return(nil, errors.New("user not found"))
+
+	channel, err := uc.GetUserFeed(context.Background(), "missing")
+
+	assert.Error(t, err)
+	assert.Nil(t, channel)
+}
+
+func TestGetTagFeed_ResolvesAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	publicationRepo := mocks.NewMockPublicationRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	tagRepo := mocks.NewMockTagRepository(ctrl)
+	uc := NewUseCase(publicationRepo, userRepo, tagRepo)
+
+	now := time.Now()
+	tagRepo.EXPECT().GetByName(gomock.Any(), "go").Return(&domain.Tag{ID: "tag-1", Name: "go", CreatedAt: now.Add(-time.Hour)}, nil)
+	publicationRepo.EXPECT().
+		GetByTag(gomock.Any(), "tag-1", nil, FeedSize, 0).
+		Return([]*domain.PublicationWithLikeStatus{
+			{Publication: domain.Publication{ID: "pub-1", AuthorID: "user-1", UpdatedAt: now, Visibility: domain.VisibilityTypePublic}},
+			{Publication: domain.Publication{ID: "pub-2", AuthorID: "user-1", UpdatedAt: now, Visibility: domain.VisibilityTypePublic}},
+		}, 2, nil)
+	// The author is looked up once for both publications
+	userRepo.EXPECT().GetByID(gomock.Any(), "user-1").Return(&domain.User{ID: "user-1", Username: "bob"}, nil).Times(1)
+
+	channel, err := uc.GetTagFeed(context.Background(), "go")
+
+	require.NoError(t, err)
+	require.Len(t, channel.Items, 2)
+	assert.Equal(t, "bob", channel.Items[1].AuthorName)
+	assert.Equal(t, "#go", channel.Title)
+	assert.Equal(t, now, channel.Updated)
+}
+
+func TestChannelETag_ChangesOnUpdate(t *testing.T) {
+	pub := &domain.PublicationWithLikeStatus{Publication: domain.Publication{ID: "pub-1", UpdatedAt: time.Unix(100, 0)}}
+	channel := &Channel{Kind: KindUser, Subject: "user-1", Items: []*Item{{Publication: pub}}}
+
+	before := channel.ETag("rss")
+	assert.NotEqual(t, before, channel.ETag("atom"))
+
+	pub.UpdatedAt = time.Unix(200, 0)
+	assert.NotEqual(t, before, channel.ETag("rss"))
+}
+
+func TestChannelETag_ChangesWhenOlderItemLeaves(t *testing.T) {
+	newest := &domain.PublicationWithLikeStatus{Publication: domain.Publication{ID: "pub-2", UpdatedAt: time.Unix(200, 0)}}
+	older := &domain.PublicationWithLikeStatus{Publication: domain.Publication{ID: "pub-1", UpdatedAt: time.Unix(100, 0)}}
+	channel := &Channel{Kind: KindUser, Subject: "user-1", Updated: time.Unix(200, 0), Items: []*Item{{Publication: newest}, {Publication: older}}}
+	before := channel.ETag("rss")
+
+	// Updated stays the same, yet the feed has changed
+	channel.Items = channel.Items[:1]
+	assert.NotEqual(t, before, channel.ETag("rss"))
+}
+
+func TestRenderHTML(t *testing.T) {
+	post := &domain.Publication{
+		Type:    domain.PublicationTypePost,
+		Content: strPtr("Hello <b>world</b>\nsecond line\n\nNext paragraph"),
+	}
+	assert.Equal(t, "<p>Hello &lt;b&gt;world&lt;/b&gt;<br>second line</p><p>Next paragraph</p>", RenderHTML(post))
+
+	quote := &domain.Publication{
+		Type:    domain.PublicationTypeQuote,
+		Content: strPtr("To be or not to be"),
+		Source:  strPtr("Hamlet"),
+	}
+	assert.Equal(t, "<blockquote><p>To be or not to be</p></blockquote><p>— <cite>Hamlet</cite></p>", RenderHTML(quote))
+}
@@ -0,0 +1,55 @@
+package trending
+
+import (
+	"context"
+	"time"
+
+	"sense-backend/internal/domain"
+)
+
+// JobRefresh is the scheduled background job type recomputing trending scores
+const JobRefresh = "trending.refresh"
+
+// DefaultParams are the trending weights used unless configured otherwise
+var DefaultParams = domain.TrendingParams{
+	Window:        7 * 24 * time.Hour,
+	HalfLife:      24 * time.Hour,
+	LikeWeight:    1,
+	SaveWeight:    2,
+	CommentWeight: 3,
+	ViewWeight:    0.1,
+}
+
+// UseCase handles trending use cases
+type UseCase struct {
+	trendingRepo domain.TrendingRepository
+	params       domain.TrendingParams
+}
+
+// NewUseCase creates a new trending use case
+func NewUseCase(trendingRepo domain.TrendingRepository, params domain.TrendingParams) *UseCase {
+	return &UseCase{
+		trendingRepo: trendingRepo,
+		params:       params,
+	}
+}
+
+// Refresh recomputes trending scores
+func (uc *UseCase) Refresh(ctx context.Context) error {
+	return uc.trendingRepo.Refresh(ctx, &uc.params)
+}
+
+// Run is the JobRefresh handler
+func (uc *UseCase) Run(ctx context.Context, job *domain.Job) error {
+	return uc.Refresh(ctx)
+}
+
+// GetPublications retrieves trending publications with like status for viewer
+func (uc *UseCase) GetPublications(ctx context.Context, viewerUserID *string, filters *domain.TrendingFilters, limit, offset int) ([]*domain.TrendingPublication, int, error) {
+	return uc.trendingRepo.GetPublications(ctx, viewerUserID, filters, limit, offset)
+}
+
+// GetTags retrieves trending tags
+func (uc *UseCase) GetTags(ctx context.Context, filters *domain.TrendingFilters, limit int) ([]*domain.TrendingTag, error) {
+	return uc.trendingRepo.GetTags(ctx, filters, limit)
+}
@@ -0,0 +1,104 @@
+package trending
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRefresh_UsesConfiguredParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trendingRepo := mocks.NewMockTrendingRepository(ctrl)
+	params := DefaultParams
+	params.HalfLife = 6 * time.Hour
+	uc := NewUseCase(trendingRepo, params)
+
+	trendingRepo.EXPECT().
+		Refresh(gomock.Any(), &params).
+		Return(nil)
+
+	err := uc.Refresh(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestRun_RefreshesScores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trendingRepo := mocks.NewMockTrendingRepository(ctrl)
+	uc := NewUseCase(trendingRepo, DefaultParams)
+
+	trendingRepo.EXPECT().
+		Refresh(gomock.Any(), gomock.Any()).
+		Return(errors.New("db down"))
+
+	// The error fails the job, so that the runner retries it
+	err := uc.Run(context.Background(), &domain.Job{Type: JobRefresh})
+
+	assert.EqualError(t, err, "db down")
+}
+
+func TestGetPublications_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trendingRepo := mocks.NewMockTrendingRepository(ctrl)
+	uc := NewUseCase(trendingRepo, DefaultParams)
+
+	language := "ru"
+	pubType := domain.PublicationTypeQuote
+	filters := &domain.TrendingFilters{Type: &pubType, Language: &language}
+	publications := []*domain.TrendingPublication{
+		{
+			PublicationWithLikeStatus: domain.PublicationWithLikeStatus{
+				Publication: domain.Publication{ID: "pub-1", Type: domain.PublicationTypeQuote},
+			},
+			TrendingScore: 4.2,
+		},
+	}
+
+	trendingRepo.EXPECT().
+		GetPublications(gomock.Any(), nil, filters, 20, 0).
+		Return(publications, 1, nil)
+
+	result, total, err := uc.GetPublications(context.Background(), nil, filters, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, result, 1)
+	assert.InDelta(t, 4.2, result[0].TrendingScore, 1e-9)
+}
+
+func TestGetTags_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trendingRepo := mocks.NewMockTrendingRepository(ctrl)
+	uc := NewUseCase(trendingRepo, DefaultParams)
+
+	filters := &domain.TrendingFilters{}
+	tags := []*domain.TrendingTag{
+		{Tag: domain.Tag{ID: "tag-1", Name: "stoicism"}, TrendingScore: 3, TrendingPublicationsCount: 2},
+	}
+
+	trendingRepo.EXPECT().
+		GetTags(gomock.Any(), filters, 10).
+		Return(tags, nil)
+
+	result, err := uc.GetTags(context.Background(), filters, 10)
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "stoicism", result[0].Name)
+}
@@ -0,0 +1,295 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Delivery request headers
+const (
+	HeaderEvent     = "X-Sense-Event"
+	HeaderDelivery  = "X-Sense-Delivery"
+	HeaderTimestamp = "X-Sense-Timestamp"
+	// HeaderSignature is "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>"
+	// keyed with the webhook secret
+	HeaderSignature = "X-Sense-Signature"
+)
+
+// maxLoggedResponse is the number of response body bytes kept in the delivery log
+const maxLoggedResponse = 1024
+
+// eventNamespace derives event IDs from outbox message IDs
+var eventNamespace = uuid.MustParse("5b0f7c1e-4a8d-4f3b-9c2e-7d6a1e0b8f45")
+
+// Payload is the JSON body of a delivery
+type Payload struct {
+	// ID identifies the event; retries and replays keep it so receivers can deduplicate
+	ID         string           `json:"id"`
+	Type       domain.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	ActorID    string           `json:"actor_id"`
+	TargetID   string           `json:"target_id"`
+	// Data is the publication or comment the event is about; absent for follows
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Handle queues deliveries of a domain event to the webhooks of the actor and of the owner of
+// the publication, comment or profile the event is about
+func (uc *UseCase) Handle(ctx context.Context, event *domain.Event) error {
+	if !domain.IsWebhookEventType(event.Type) {
+		return nil
+	}
+
+	owners, data, err := uc.resolve(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	userIDs := []string{event.ActorID}
+	for _, ownerID := range owners {
+		if ownerID == event.ActorID {
+			continue
+		}
+		// Blocked users' actions reach neither notifications nor webhooks
+		blocked, err := uc.blockRepo.IsBlockedEither(ctx, ownerID, event.ActorID)
+		if err != nil {
+			return err
+		}
+		if !blocked {
+			userIDs = append(userIDs, ownerID)
+		}
+	}
+
+	webhooks, err := uc.webhookRepo.GetActiveForEvent(ctx, userIDs, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	// Outbox retries run Handle again; the same event ID makes them queue no new deliveries
+	eventID := uuid.New()
+	if event.ID != "" {
+		eventID = uuid.NewSHA1(eventNamespace, []byte(event.ID))
+	}
+	payload, err := json.Marshal(&Payload{
+		ID:         eventID.String(),
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		ActorID:    event.ActorID,
+		TargetID:   event.TargetID,
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, webhook := range webhooks {
+		if err := uc.webhookRepo.CreateDelivery(ctx, &domain.WebhookDelivery{
+			ID:            uuid.New().String(),
+			WebhookID:     webhook.ID,
+			EventID:       eventID.String(),
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		}); err != nil {
+			return fmt.Errorf("queue delivery to webhook %s: %w", webhook.ID, err)
+		}
+	}
+
+	return uc.dispatch(ctx)
+}
+
+// resolve returns the users the event concerns besides the actor and the object it is about
+func (uc *UseCase) resolve(ctx context.Context, event *domain.Event) ([]string, interface{}, error) {
+	switch event.Type {
+	case domain.EventPublicationCreated, domain.EventPublicationLiked:
+		publication, err := uc.publicationRepo.GetByID(ctx, event.TargetID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []string{publication.AuthorID}, publication, nil
+
+	case domain.EventCommentCreated:
+		comment, err := uc.commentRepo.GetByID(ctx, event.TargetID)
+		if err != nil {
+			return nil, nil, err
+		}
+		publication, err := uc.publicationRepo.GetByID(ctx, comment.PublicationID)
+		if err != nil {
+			return nil, nil, err
+		}
+		owners := []string{publication.AuthorID}
+		if comment.ParentID != nil {
+			parent, err := uc.commentRepo.GetByID(ctx, *comment.ParentID)
+			if err != nil {
+				return nil, nil, err
+			}
+			owners = append(owners, parent.AuthorID)
+		}
+		return owners, comment, nil
+
+	case domain.EventCommentLiked:
+		comment, err := uc.commentRepo.GetByID(ctx, event.TargetID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []string{comment.AuthorID}, comment, nil
+
+	default:
+		// Follows are about the followed user
+		return []string{event.TargetID}, nil, nil
+	}
+}
+
+// DeliverDue sends the deliveries due at now and returns how many were attempted
+func (uc *UseCase) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	attempted := 0
+	for {
+		deliveries, err := uc.webhookRepo.ClaimDueDeliveries(ctx, now, uc.params.Lease, uc.params.BatchSize)
+		if err != nil {
+			return attempted, fmt.Errorf("failed to claim deliveries: %w", err)
+		}
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []error
+			sem  = make(chan struct{}, max(uc.params.Concurrency, 1))
+		)
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(delivery *domain.WebhookDelivery) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if err := uc.deliver(ctx, delivery); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("delivery %s: %w", delivery.ID, err))
+					mu.Unlock()
+				}
+			}(delivery)
+		}
+		wg.Wait()
+
+		attempted += len(deliveries)
+		if len(errs) > 0 {
+			return attempted, errors.Join(errs...)
+		}
+		if len(deliveries) < uc.params.BatchSize || ctx.Err() != nil {
+			return attempted, ctx.Err()
+		}
+	}
+}
+
+// Run is the JobDeliver handler. Failed attempts are retried by the deliveries' own backoff,
+// not by the job
+func (uc *UseCase) Run(ctx context.Context, job *domain.Job) error {
+	_, err := uc.DeliverDue(ctx, time.Now())
+	return err
+}
+
+// deliver makes one attempt and records its outcome; the returned error is about recording,
+// not about the receiver
+func (uc *UseCase) deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	webhook, err := uc.webhookRepo.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	timestamp := strconv.FormatInt(started.Unix(), 10)
+	headers := map[string]string{
+		HeaderEvent:     string(delivery.EventType),
+		HeaderDelivery:  delivery.ID,
+		HeaderTimestamp: timestamp,
+		HeaderSignature: Sign(webhook.Secret, timestamp, delivery.Payload),
+	}
+	resp, sendErr := uc.sender.Send(ctx, webhook.URL, headers, delivery.Payload)
+	if sendErr != nil && ctx.Err() != nil {
+		// Shutting down; the lease expires and the delivery is retried
+		return nil
+	}
+
+	finished := time.Now()
+	duration := int(finished.Sub(started).Milliseconds())
+	delivery.Attempts++
+	delivery.LastAttemptAt = &finished
+	delivery.DurationMs = &duration
+	delivery.ResponseStatus, delivery.ResponseBody, delivery.Error = nil, nil, nil
+
+	succeeded := false
+	if sendErr != nil {
+		message := sendErr.Error()
+		delivery.Error = &message
+	} else {
+		body := resp.Body
+		if len(body) > maxLoggedResponse {
+			body = body[:maxLoggedResponse]
+		}
+		// Postgres text holds neither invalid UTF-8, such as a rune cut above, nor NUL bytes
+		body = strings.ReplaceAll(strings.ToValidUTF8(body, ""), "\x00", "")
+		delivery.ResponseStatus = &resp.StatusCode
+		delivery.ResponseBody = &body
+		succeeded = resp.StatusCode >= 200 && resp.StatusCode < 300
+		if !succeeded {
+			message := fmt.Sprintf("unexpected status %d", resp.StatusCode)
+			delivery.Error = &message
+		}
+	}
+
+	switch {
+	case succeeded:
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= uc.params.MaxAttempts:
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+	default:
+		next := finished.Add(domain.RetryBackoff(uc.params.BackoffBase, uc.params.BackoffMax, delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := uc.webhookRepo.RecordAttempt(ctx, delivery); err != nil {
+		return err
+	}
+	if succeeded {
+		return uc.webhookRepo.RecordSuccess(ctx, webhook.ID)
+	}
+	_, err = uc.webhookRepo.RecordFailure(ctx, webhook.ID, uc.params.DisableAfter)
+	return err
+}
+
+// dispatch enqueues a JobDeliver run, so that queued deliveries go out before the next scheduled one
+func (uc *UseCase) dispatch(ctx context.Context) error {
+	if _, err := uc.jobs.Enqueue(ctx, JobDeliver, nil, time.Time{}); err != nil {
+		return fmt.Errorf("failed to dispatch deliveries: %w", err)
+	}
+	return nil
+}
+
+// Sign returns the signature header value of a delivery body sent at timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
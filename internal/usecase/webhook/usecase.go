@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"sense-backend/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// JobDeliver is the background job type sending due deliveries. It is enqueued as soon as
+// deliveries are queued and scheduled every minute for retries
+const JobDeliver = "webhooks.deliver"
+
+// MaxWebhooksPerUser limits the number of webhooks one user can register
+const MaxWebhooksPerUser = 10
+
+var (
+	// ErrInvalidURL is returned when a webhook URL is not an absolute http or https URL
+	ErrInvalidURL = errors.New("invalid webhook url")
+	// ErrUnknownEventType is returned when a webhook subscribes to an event type it cannot receive
+	ErrUnknownEventType = errors.New("unknown event type")
+	// ErrTooManyWebhooks is returned when the user already has MaxWebhooksPerUser webhooks
+	ErrTooManyWebhooks = errors.New("too many webhooks")
+)
+
+// HandledEvents are the domain events delivered to webhooks
+var HandledEvents = domain.WebhookEventTypes
+
+// Params configures delivery
+type Params struct {
+	// MaxAttempts is the number of attempts after which a delivery is given up
+	MaxAttempts int
+	// BackoffBase is the delay before the first retry; every next retry waits twice as long
+	BackoffBase time.Duration
+	// BackoffMax caps the delay between retries
+	BackoffMax time.Duration
+	// DisableAfter is the number of consecutive failed attempts after which a webhook is disabled
+	DisableAfter int
+	// Lease is how long a claimed delivery is hidden from other senders
+	Lease time.Duration
+	// BatchSize is the number of deliveries claimed at a time
+	BatchSize int
+	// Concurrency is the number of deliveries sent in parallel
+	Concurrency int
+}
+
+// DefaultParams retry for about a day and disable a webhook after 20 failures in a row
+var DefaultParams = Params{
+	MaxAttempts:  8,
+	BackoffBase:  30 * time.Second,
+	BackoffMax:   6 * time.Hour,
+	DisableAfter: 20,
+	Lease:        time.Minute,
+	BatchSize:    50,
+	Concurrency:  4,
+}
+
+// CreateRequest represents create webhook request
+type CreateRequest struct {
+	URL         string             `json:"url" validate:"required,url,max=2048"`
+	Description *string            `json:"description,omitempty" validate:"omitempty,max=255"`
+	EventTypes  []domain.EventType `json:"event_types" validate:"required,min=1"`
+}
+
+// UpdateRequest represents update webhook request; omitted fields are kept. Turning a disabled
+// webhook back on resets its failure count
+type UpdateRequest struct {
+	URL         *string            `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Description *string            `json:"description,omitempty" validate:"omitempty,max=255"`
+	EventTypes  []domain.EventType `json:"event_types,omitempty" validate:"omitempty,min=1"`
+	IsActive    *bool              `json:"is_active,omitempty"`
+}
+
+// UseCase handles webhook use cases
+type UseCase struct {
+	webhookRepo     domain.WebhookRepository
+	publicationRepo domain.PublicationRepository
+	commentRepo     domain.CommentRepository
+	blockRepo       domain.BlockRepository
+	sender          domain.WebhookSender
+	jobs            domain.JobQueue
+	params          Params
+}
+
+// NewUseCase creates a new webhook use case
+func NewUseCase(
+	webhookRepo domain.WebhookRepository,
+	publicationRepo domain.PublicationRepository,
+	commentRepo domain.CommentRepository,
+	blockRepo domain.BlockRepository,
+	sender domain.WebhookSender,
+	jobs domain.JobQueue,
+	params Params,
+) *UseCase {
+	return &UseCase{
+		webhookRepo:     webhookRepo,
+		publicationRepo: publicationRepo,
+		commentRepo:     commentRepo,
+		blockRepo:       blockRepo,
+		sender:          sender,
+		jobs:            jobs,
+		params:          params,
+	}
+}
+
+// Create registers a webhook; the returned webhook carries the signing secret, which is not shown again
+func (uc *UseCase) Create(ctx context.Context, userID string, req *CreateRequest) (*domain.Webhook, error) {
+	if err := validateURL(req.URL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.webhookRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxWebhooksPerUser {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	webhook := &domain.Webhook{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// List retrieves the user's webhooks without their secrets
+func (uc *UseCase) List(ctx context.Context, userID string) ([]*domain.Webhook, error) {
+	webhooks, err := uc.webhookRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+	return webhooks, nil
+}
+
+// Get retrieves the user's webhook without its secret
+func (uc *UseCase) Get(ctx context.Context, userID, webhookID string) (*domain.Webhook, error) {
+	webhook, err := uc.webhookRepo.GetByID(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Update updates the user's webhook
+func (uc *UseCase) Update(ctx context.Context, userID, webhookID string, req *UpdateRequest) (*domain.Webhook, error) {
+	webhook, err := uc.webhookRepo.GetByID(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Description != nil {
+		webhook.Description = req.Description
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeEventTypes(req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		webhook.EventTypes = eventTypes
+	}
+	if req.IsActive != nil {
+		if *req.IsActive && !webhook.IsActive {
+			webhook.FailureCount = 0
+			webhook.DisabledAt = nil
+		}
+		webhook.IsActive = *req.IsActive
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := uc.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	// Deliveries held while the webhook was off go out now, or on the next scheduled run
+	if webhook.IsActive {
+		_ = uc.dispatch(ctx)
+	}
+
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Delete deletes the user's webhook with its delivery log
+func (uc *UseCase) Delete(ctx context.Context, userID, webhookID string) error {
+	return uc.webhookRepo.Delete(ctx, userID, webhookID)
+}
+
+// GetDeliveries retrieves the delivery log of the user's webhook
+func (uc *UseCase) GetDeliveries(ctx context.Context, userID, webhookID string, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+	if _, err := uc.webhookRepo.GetByID(ctx, userID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	return uc.webhookRepo.GetDeliveries(ctx, webhookID, limit, offset)
+}
+
+// Replay queues a new delivery of the same payload; receivers see the original event ID
+// and a new delivery ID
+func (uc *UseCase) Replay(ctx context.Context, userID, webhookID, deliveryID string) (*domain.WebhookDelivery, error) {
+	original, err := uc.webhookRepo.GetDelivery(ctx, userID, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		ID:            uuid.New().String(),
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &original.ID,
+		CreatedAt:     now,
+	}
+	if err := uc.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to replay delivery: %w", err)
+	}
+
+	// The delivery is queued; failing to send it now leaves it to the next scheduled run
+	_ = uc.dispatch(ctx)
+	return delivery, nil
+}
+
+// validateURL accepts absolute http and https URLs without credentials
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.User != nil {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// normalizeEventTypes rejects unknown types and drops duplicates
+func normalizeEventTypes(types []domain.EventType) ([]domain.EventType, error) {
+	seen := make(map[domain.EventType]struct{}, len(types))
+	normalized := make([]domain.EventType, 0, len(types))
+	for _, t := range types {
+		if !domain.IsWebhookEventType(t) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, t)
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		normalized = append(normalized, t)
+	}
+	return normalized, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
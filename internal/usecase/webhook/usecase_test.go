@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"sense-backend/internal/domain"
+	"sense-backend/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	webhookRepo     *mocks.MockWebhookRepository
+	publicationRepo *mocks.MockPublicationRepository
+	commentRepo     *mocks.MockCommentRepository
+	blockRepo       *mocks.MockBlockRepository
+	sender          *mocks.MockWebhookSender
+	jobs            *mocks.MockJobQueue
+}
+
+func newTestUseCase(ctrl *gomock.Controller) (*UseCase, *testDeps) {
+	deps := &testDeps{
+		webhookRepo:     mocks.NewMockWebhookRepository(ctrl),
+		publicationRepo: mocks.NewMockPublicationRepository(ctrl),
+		commentRepo:     mocks.NewMockCommentRepository(ctrl),
+		blockRepo:       mocks.NewMockBlockRepository(ctrl),
+		sender:          mocks.NewMockWebhookSender(ctrl),
+		jobs:            mocks.NewMockJobQueue(ctrl),
+	}
+	uc := NewUseCase(deps.webhookRepo, deps.publicationRepo, deps.commentRepo, deps.blockRepo, deps.sender, deps.jobs, DefaultParams)
+	return uc, deps
+}
+
+func TestCreate_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	_, err := uc.Create(context.Background(), "alice", &CreateRequest{
+		URL:        "ftp://example.com/hook",
+		EventTypes: []domain.EventType{domain.EventPublicationCreated},
+	})
+	assert.ErrorIs(t, err, ErrInvalidURL)
+
+	_, err = uc.Create(context.Background(), "alice", &CreateRequest{
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.EventType{"publication.exploded"},
+	})
+	assert.ErrorIs(t, err, ErrUnknownEventType)
+
+	deps.webhookRepo.EXPECT().CountByUser(gomock.Any(), "alice").Return(MaxWebhooksPerUser, nil)
+	_, err = uc.Create(context.Background(), "alice", &CreateRequest{
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.EventType{domain.EventPublicationCreated},
+	})
+	assert.ErrorIs(t, err, ErrTooManyWebhooks)
+}
+
+func TestCreate_ReturnsSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+
+	deps.webhookRepo.EXPECT().CountByUser(gomock.Any(), "alice").Return(0, nil)
+	deps.webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	webhook, err := uc.Create(context.Background(), "alice", &CreateRequest{
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.EventType{domain.EventPublicationCreated, domain.EventPublicationCreated},
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, webhook.Secret, 64)
+	assert.Equal(t, []domain.EventType{domain.EventPublicationCreated}, webhook.EventTypes)
+	assert.True(t, webhook.IsActive)
+}
+
+func TestHandle_QueuesForActorAndOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	event := domain.NewEvent(domain.EventCommentCreated, "bob", "c-1")
+
+	deps.commentRepo.EXPECT().GetByID(gomock.Any(), "c-1").
+		Return(&domain.Comment{ID: "c-1", PublicationID: "p-1", AuthorID: "bob"}, nil)
+	deps.publicationRepo.EXPECT().GetByID(gomock.Any(), "p-1").
+		Return(&domain.Publication{ID: "p-1", AuthorID: "alice"}, nil)
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(false, nil)
+	deps.webhookRepo.EXPECT().GetActiveForEvent(gomock.Any(), []string{"bob", "alice"}, domain.EventCommentCreated).
+		Return([]*domain.Webhook{{ID: "wh-1"}}, nil)
+
+	var queued *domain.WebhookDelivery
+	deps.webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, delivery *domain.WebhookDelivery) error {
+			queued = delivery
+			return nil
+		})
+	deps.jobs.EXPECT().Enqueue(gomock.Any(), JobDeliver, nil, time.Time{}).Return(&domain.Job{}, nil)
+
+	require.NoError(t, uc.Handle(context.Background(), event))
+
+	require.NotNil(t, queued)
+	assert.Equal(t, "wh-1", queued.WebhookID)
+	assert.Equal(t, domain.WebhookDeliveryPending, queued.Status)
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(queued.Payload, &payload))
+	assert.Equal(t, "comment.created", payload["type"])
+	assert.Equal(t, "c-1", payload["data"].(map[string]interface{})["id"])
+}
+
+func TestHandle_RetriedEventKeepsID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	event := domain.NewEvent(domain.EventUserFollowed, "bob", "alice")
+	event.ID = "42"
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(false, nil).Times(2)
+	deps.webhookRepo.EXPECT().GetActiveForEvent(gomock.Any(), []string{"bob", "alice"}, domain.EventUserFollowed).
+		Return([]*domain.Webhook{{ID: "wh-1"}}, nil).Times(2)
+
+	var queued []*domain.WebhookDelivery
+	deps.webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, delivery *domain.WebhookDelivery) error {
+			queued = append(queued, delivery)
+			return nil
+		}).Times(2)
+	deps.jobs.EXPECT().Enqueue(gomock.Any(), JobDeliver, nil, time.Time{}).Return(&domain.Job{}, nil).Times(2)
+
+	// The outbox runs every handler again when another one fails
+	require.NoError(t, uc.Handle(context.Background(), event))
+	require.NoError(t, uc.Handle(context.Background(), event))
+
+	require.Len(t, queued, 2)
+	assert.Equal(t, queued[0].EventID, queued[1].EventID)
+	assert.Equal(t, queued[0].Payload, queued[1].Payload)
+	var payload Payload
+	require.NoError(t, json.Unmarshal(queued[0].Payload, &payload))
+	assert.Equal(t, queued[0].EventID, payload.ID)
+}
+
+func TestHandle_SkipsBlockedOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	event := domain.NewEvent(domain.EventUserFollowed, "bob", "alice")
+
+	deps.blockRepo.EXPECT().IsBlockedEither(gomock.Any(), "alice", "bob").Return(true, nil)
+	deps.webhookRepo.EXPECT().GetActiveForEvent(gomock.Any(), []string{"bob"}, domain.EventUserFollowed).Return(nil, nil)
+
+	require.NoError(t, uc.Handle(context.Background(), event))
+}
+
+func TestDeliverDue_SignsAndRecordsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	now := time.Now()
+	payload := json.RawMessage(`{"id":"e-1"}`)
+	delivery := &domain.WebhookDelivery{ID: "d-1", WebhookID: "wh-1", EventType: domain.EventUserFollowed, Payload: payload}
+
+	deps.webhookRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), now, DefaultParams.Lease, DefaultParams.BatchSize).
+		Return([]*domain.WebhookDelivery{delivery}, nil)
+	deps.webhookRepo.EXPECT().GetWebhook(gomock.Any(), "wh-1").
+		Return(&domain.Webhook{ID: "wh-1", URL: "https://example.com/hook", Secret: "s3cret"}, nil)
+	deps.sender.EXPECT().Send(gomock.Any(), "https://example.com/hook", gomock.Any(), []byte(payload)).
+		DoAndReturn(func(_ context.Context, _ string, headers map[string]string, body []byte) (*domain.WebhookResponse, error) {
+			assert.Equal(t, "d-1", headers[HeaderDelivery])
+			assert.Equal(t, Sign("s3cret", headers[HeaderTimestamp], body), headers[HeaderSignature])
+			return &domain.WebhookResponse{StatusCode: 204}, nil
+		})
+	deps.webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery).Return(nil)
+	deps.webhookRepo.EXPECT().RecordSuccess(gomock.Any(), "wh-1").Return(nil)
+
+	attempted, err := uc.DeliverDue(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Equal(t, domain.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestDeliverDue_SchedulesRetryAndGivesUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	retried := &domain.WebhookDelivery{ID: "d-1", WebhookID: "wh-1", Status: domain.WebhookDeliveryPending, Attempts: 2}
+	exhausted := &domain.WebhookDelivery{ID: "d-2", WebhookID: "wh-1", Status: domain.WebhookDeliveryPending, Attempts: DefaultParams.MaxAttempts - 1}
+
+	deps.webhookRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*domain.WebhookDelivery{retried, exhausted}, nil)
+	deps.webhookRepo.EXPECT().GetWebhook(gomock.Any(), "wh-1").
+		Return(&domain.Webhook{ID: "wh-1", URL: "https://example.com/hook"}, nil).Times(2)
+	deps.sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&domain.WebhookResponse{StatusCode: 500, Body: "oops"}, nil)
+	deps.sender.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection refused"))
+	deps.webhookRepo.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	deps.webhookRepo.EXPECT().RecordFailure(gomock.Any(), "wh-1", DefaultParams.DisableAfter).Return(false, nil).Times(2)
+
+	_, err := uc.DeliverDue(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryPending, retried.Status)
+	require.NotNil(t, retried.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(4*DefaultParams.BackoffBase), *retried.NextAttemptAt, time.Second)
+	assert.Equal(t, domain.WebhookDeliveryFailed, exhausted.Status)
+	assert.Nil(t, exhausted.NextAttemptAt)
+	assert.NotNil(t, exhausted.Error)
+}
+
+func TestBackoff_Capped(t *testing.T) {
+	backoff := func(attempts int) time.Duration {
+		return domain.RetryBackoff(DefaultParams.BackoffBase, DefaultParams.BackoffMax, attempts)
+	}
+
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, time.Minute, backoff(2))
+	assert.Equal(t, DefaultParams.BackoffMax, backoff(40))
+}
+
+func TestReplay_CopiesPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := newTestUseCase(ctrl)
+	original := &domain.WebhookDelivery{
+		ID: "d-1", WebhookID: "wh-1", EventID: "e-1", EventType: domain.EventPublicationCreated,
+		Payload: json.RawMessage(`{"id":"e-1"}`), Status: domain.WebhookDeliveryFailed, Attempts: 8,
+	}
+
+	deps.webhookRepo.EXPECT().GetDelivery(gomock.Any(), "alice", "wh-1", "d-1").Return(original, nil)
+	deps.webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Return(nil)
+	// Failing to dispatch now leaves the queued delivery to the scheduled run
+	deps.jobs.EXPECT().Enqueue(gomock.Any(), JobDeliver, nil, time.Time{}).Return(nil, errors.New("db down"))
+
+	replay, err := uc.Replay(context.Background(), "alice", "wh-1", "d-1")
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "d-1", replay.ID)
+	assert.Equal(t, original.Payload, replay.Payload)
+	assert.Equal(t, original.EventID, replay.EventID)
+	assert.Equal(t, domain.WebhookDeliveryPending, replay.Status)
+	assert.Equal(t, 0, replay.Attempts)
+	require.NotNil(t, replay.ReplayOf)
+	assert.Equal(t, "d-1", *replay.ReplayOf)
+}
@@ -0,0 +1,29 @@
+BEGIN;
+
+-- TRENDING (трендовые публикации и теги, пересчитываются периодически)
+CREATE TABLE IF NOT EXISTS trending_publications (
+  publication_id uuid PRIMARY KEY REFERENCES publications(id) ON DELETE CASCADE,
+  type publication_type NOT NULL,
+  language text NOT NULL,
+  score double precision NOT NULL CHECK (score >= 0),
+  likes_count int NOT NULL DEFAULT 0,
+  saves_count int NOT NULL DEFAULT 0,
+  comments_count int NOT NULL DEFAULT 0,
+  views_count int NOT NULL DEFAULT 0,
+  computed_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_trending_pubs_score ON trending_publications(score DESC);
+CREATE INDEX IF NOT EXISTS idx_trending_pubs_type_lang ON trending_publications(type, language, score DESC);
+
+CREATE TABLE IF NOT EXISTS trending_tags (
+  tag_id uuid NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  type publication_type NOT NULL,
+  language text NOT NULL,
+  score double precision NOT NULL CHECK (score >= 0),
+  publications_count int NOT NULL DEFAULT 0,
+  computed_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (tag_id, type, language)
+);
+CREATE INDEX IF NOT EXISTS idx_trending_tags_score ON trending_tags(score DESC);
+
+COMMIT;
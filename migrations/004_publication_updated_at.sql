@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Время последнего изменения публикации (для RSS/Atom/JSON Feed и условных GET-запросов)
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+UPDATE publications SET updated_at = publication_date WHERE updated_at IS NULL;
+ALTER TABLE publications ALTER COLUMN updated_at SET DEFAULT now();
+ALTER TABLE publications ALTER COLUMN updated_at SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_publications_updated ON publications(updated_at);
+
+COMMIT;
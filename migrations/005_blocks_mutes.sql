@@ -0,0 +1,22 @@
+BEGIN;
+
+-- BLOCKS (блокировка: стороны не видят контент друг друга и не могут взаимодействовать)
+CREATE TABLE IF NOT EXISTS user_blocks (
+  blocker_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  blocked_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (blocker_id, blocked_id),
+  CONSTRAINT chk_no_self_block CHECK (blocker_id != blocked_id)
+);
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked_id);
+
+-- MUTES (скрытие: контент скрывается только из лент и уведомлений того, кто скрыл)
+CREATE TABLE IF NOT EXISTS user_mutes (
+  muter_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  muted_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (muter_id, muted_id),
+  CONSTRAINT chk_no_self_mute CHECK (muter_id != muted_id)
+);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+-- Закрытый аккаунт: подписки на него требуют подтверждения
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_private boolean NOT NULL DEFAULT false;
+
+-- FOLLOW REQUESTS (запросы на подписку к закрытым аккаунтам)
+CREATE TABLE IF NOT EXISTS follow_requests (
+  requester_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  target_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (requester_id, target_id),
+  CONSTRAINT chk_no_self_request CHECK (requester_id != target_id)
+);
+CREATE INDEX IF NOT EXISTS idx_follow_requests_target ON follow_requests(target_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- DISMISSED SUGGESTIONS (пользователи, скрытые из рекомендаций «на кого подписаться»)
+CREATE TABLE IF NOT EXISTS dismissed_suggestions (
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  dismissed_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, dismissed_id),
+  CONSTRAINT chk_no_self_dismiss CHECK (user_id != dismissed_id)
+);
+
+COMMIT;
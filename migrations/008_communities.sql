@@ -0,0 +1,57 @@
+-- ENUMS
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname='community_join_policy') THEN
+    CREATE TYPE community_join_policy AS ENUM ('open','request','invite');
+  END IF;
+  IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname='community_role') THEN
+    CREATE TYPE community_role AS ENUM ('owner','moderator','member');
+  END IF;
+END$$;
+
+BEGIN;
+
+-- COMMUNITIES (сообщества)
+CREATE TABLE IF NOT EXISTS communities (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  name text NOT NULL UNIQUE,
+  description text,
+  icon_url text,
+  join_policy community_join_policy NOT NULL DEFAULT 'open',
+  owner_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Участники сообщества и их роли
+CREATE TABLE IF NOT EXISTS community_members (
+  community_id uuid NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role community_role NOT NULL DEFAULT 'member',
+  joined_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (community_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_community_members_user ON community_members(user_id);
+
+-- Заявки на вступление (политика 'request')
+CREATE TABLE IF NOT EXISTS community_join_requests (
+  community_id uuid NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (community_id, user_id)
+);
+
+-- Приглашения (политика 'invite')
+CREATE TABLE IF NOT EXISTS community_invites (
+  community_id uuid NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  invited_by uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (community_id, user_id)
+);
+
+-- Публикации внутри сообщества видны только его участникам
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS community_id uuid REFERENCES communities(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_publications_community ON publications(community_id, publication_date DESC)
+  WHERE community_id IS NOT NULL;
+
+COMMIT;
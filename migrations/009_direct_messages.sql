@@ -0,0 +1,51 @@
+BEGIN;
+
+-- CONVERSATIONS (личные и групповые переписки)
+CREATE TABLE IF NOT EXISTS conversations (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  is_group boolean NOT NULL DEFAULT false,
+  title text,
+  created_by uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- упорядоченная пара участников личной переписки, NULL для групп; не даёт создать дубликат
+  direct_key text UNIQUE,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  -- время последнего сообщения, по нему сортируется список переписок
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Участники переписки; last_read_at — отметка о прочтении
+CREATE TABLE IF NOT EXISTS conversation_members (
+  conversation_id uuid NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  joined_at timestamptz NOT NULL DEFAULT now(),
+  last_read_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (conversation_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_conversation_members_user ON conversation_members(user_id);
+
+-- MESSAGES
+CREATE TABLE IF NOT EXISTS messages (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  conversation_id uuid NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+  sender_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  content text,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_messages_conversation ON messages(conversation_id, created_at DESC);
+
+-- Вложения сообщений (переиспользуют media_assets)
+CREATE TABLE IF NOT EXISTS message_media (
+  message_id uuid NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+  media_id uuid NOT NULL REFERENCES media_assets(id) ON DELETE CASCADE,
+  ord integer NOT NULL DEFAULT 0 CHECK (ord >= 0),
+  PRIMARY KEY (message_id, media_id)
+);
+
+-- Настройки личных сообщений: писать могут только те, на кого подписан пользователь
+CREATE TABLE IF NOT EXISTS message_settings (
+  user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  from_following_only boolean NOT NULL DEFAULT false,
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+COMMIT;
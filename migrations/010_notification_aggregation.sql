@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Поиск непрочитанного уведомления того же типа о том же объекте при группировке
+CREATE INDEX IF NOT EXISTS idx_notifications_unread_target
+  ON notifications(user_id, type, (data->>'target_id'), created_at DESC)
+  WHERE is_read = false;
+
+COMMIT;
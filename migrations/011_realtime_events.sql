@@ -0,0 +1,18 @@
+BEGIN;
+
+-- REALTIME EVENTS (события для SSE и WebSocket)
+-- Каждая реплика API слушает канал realtime_events через LISTEN/NOTIFY и доставляет события
+-- своим подключениям; таблица хранит события недолго, чтобы клиент мог продолжить с Last-Event-ID
+CREATE TABLE IF NOT EXISTS realtime_events (
+  id bigserial PRIMARY KEY,
+  -- user:<id> — события пользователя, publication:<id> — события публикации
+  channel text NOT NULL,
+  type text NOT NULL,
+  data jsonb NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_realtime_events_channel ON realtime_events(channel, id);
+CREATE INDEX IF NOT EXISTS idx_realtime_events_created ON realtime_events(created_at);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- NOTIFICATION SETTINGS (настройки уведомлений пользователя)
+-- Строка создаётся при первом изменении; до этого действуют настройки по умолчанию — всё включено
+CREATE TABLE IF NOT EXISTS notification_settings (
+  user_id uuid PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  -- переключатели каналов по типам: {"like": {"in_app": true, "email": false, "push": true}, ...}
+  channels jsonb NOT NULL DEFAULT '{}'::jsonb,
+  -- часовой пояс IANA, в котором заданы тихие часы
+  time_zone text NOT NULL DEFAULT 'UTC',
+  -- тихие часы: уведомления сохраняются, но не отправляются в реальном времени; NULL — выключены
+  quiet_hours_start time,
+  quiet_hours_end time,
+  -- уведомлять только о действиях тех, на кого подписан пользователь
+  from_following_only boolean NOT NULL DEFAULT false,
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Email-дайджест: частота и время последней отправки хранятся вместе с настройками уведомлений
+ALTER TABLE notification_settings
+  ADD COLUMN IF NOT EXISTS digest_frequency text NOT NULL DEFAULT 'weekly'
+    CHECK (digest_frequency IN ('off', 'daily', 'weekly')),
+  ADD COLUMN IF NOT EXISTS digest_sent_at timestamptz;
+
+COMMIT;
@@ -0,0 +1,47 @@
+BEGIN;
+
+-- WEBHOOKS (исходящие вебхуки пользователей)
+CREATE TABLE IF NOT EXISTS webhooks (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  url text NOT NULL,
+  description text,
+  -- типы доменных событий, например {publication.created, comment.created}
+  event_types text[] NOT NULL,
+  -- ключ подписи HMAC-SHA256
+  secret text NOT NULL,
+  is_active boolean NOT NULL DEFAULT true,
+  -- число неудачных попыток подряд; при достижении порога вебхук отключается
+  failure_count int NOT NULL DEFAULT 0,
+  disabled_at timestamptz,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user ON webhooks(user_id);
+
+-- WEBHOOK DELIVERIES (журнал доставок)
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  webhook_id uuid NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+  event_type text NOT NULL,
+  payload jsonb NOT NULL,
+  status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  attempts int NOT NULL DEFAULT 0,
+  -- когда выполнить следующую попытку; у взятой в работу доставки сдвигается на время аренды
+  next_attempt_at timestamptz,
+  last_attempt_at timestamptz,
+  -- результат последней попытки
+  response_status int,
+  response_body text,
+  error text,
+  duration_ms int,
+  -- доставка, которую повторяет эта
+  replay_of uuid REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+-- OUTBOX (доменные события, записанные в одной транзакции с изменением)
+CREATE TABLE IF NOT EXISTS outbox (
+  id bigserial PRIMARY KEY,
+  event_type text NOT NULL,
+  actor_id uuid NOT NULL,
+  -- публикация, комментарий или пользователь, к которому относится событие
+  target_id uuid NOT NULL,
+  occurred_at timestamptz NOT NULL,
+  -- NULL, пока событие не обработано всеми подписчиками
+  processed_at timestamptz,
+  -- число неудачных попыток и ошибка последней из них
+  attempts int NOT NULL DEFAULT 0,
+  last_error text,
+  -- когда повторить попытку после ошибки
+  next_attempt_at timestamptz NOT NULL DEFAULT now(),
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(next_attempt_at, id) WHERE processed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_processed ON outbox(processed_at) WHERE processed_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+-- JOBS (очередь фоновых задач)
+CREATE TABLE IF NOT EXISTS jobs (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  queue text NOT NULL DEFAULT 'default',
+  -- тип задачи, по которому выбирается обработчик
+  type text NOT NULL,
+  payload jsonb NOT NULL DEFAULT '{}',
+  status text NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+  -- число начатых запусков
+  attempts int NOT NULL DEFAULT 0,
+  max_attempts int NOT NULL DEFAULT 5,
+  -- тайм-аут видимости: незавершённый за это время запуск отдаётся другому обработчику
+  timeout_seconds int NOT NULL DEFAULT 300,
+  -- когда задачу можно запускать; при повторе сдвигается с экспоненциальной задержкой
+  run_at timestamptz NOT NULL DEFAULT now(),
+  locked_until timestamptz,
+  last_error text,
+  -- ключ для защиты от повторной постановки, например запуск cron-расписания на нескольких репликах
+  unique_key text,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now(),
+  finished_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_unique_key ON jobs(unique_key) WHERE unique_key IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_queued ON jobs(queue, run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs(queue, locked_until) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Полнотекстовый поиск по публикациям: заголовок весит больше текста, текст — больше источника.
+-- Русская конфигурация учитывает словоформы («цитата» — «цитаты»), английская — английские
+ALTER TABLE publications
+  ADD COLUMN IF NOT EXISTS search_ru tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(content, '')), 'B') ||
+    setweight(to_tsvector('russian', coalesce(source, '')), 'C')
+  ) STORED,
+  ADD COLUMN IF NOT EXISTS search_en tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(source, '')), 'C')
+  ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_publications_search_ru ON publications USING GIN (search_ru);
+CREATE INDEX IF NOT EXISTS idx_publications_search_en ON publications USING GIN (search_en);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- SEARCH REINDEX TASKS (перестроение поисковых данных публикаций, POST /search/warmup)
+CREATE TABLE IF NOT EXISTS search_reindex_tasks (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  requested_by uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  -- фильтры публикаций: type, visibility, author_id
+  filters jsonb NOT NULL DEFAULT '{}',
+  status text NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+  total int NOT NULL DEFAULT 0,
+  processed int NOT NULL DEFAULT 0,
+  -- последняя обработанная публикация; повторный запуск продолжает с неё
+  cursor uuid,
+  errors text[] NOT NULL DEFAULT '{}',
+  created_at timestamptz NOT NULL DEFAULT now(),
+  started_at timestamptz,
+  finished_at timestamptz
+);
+
+COMMIT;
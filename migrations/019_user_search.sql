@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Нечёткий поиск пользователей: триграммы находят имена с опечатками («alxeander» — «alexander»),
+-- а GIN-индексы обслуживают и оператор похожести %, и ILIKE '%...%'
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_description_trgm ON users USING GIN (description gin_trgm_ops);
+
+COMMIT;
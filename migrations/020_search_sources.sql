@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Поиск по источникам публикаций в GET /search/all: ILIKE '%...%' обслуживается триграммным индексом
+CREATE INDEX IF NOT EXISTS idx_publications_source_trgm ON publications USING GIN (source gin_trgm_ops);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Подсказки слов из заголовков в SearchIndex.Suggest: ILIKE '%...%' обслуживается триграммным индексом
+CREATE INDEX IF NOT EXISTS idx_publications_title_trgm ON publications USING GIN (title gin_trgm_ops);
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+-- SAVED SEARCHES (сохранённые поиски с уведомлениями о новых публикациях, /search/saved)
+CREATE TABLE IF NOT EXISTS saved_searches (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name text NOT NULL,
+  query text NOT NULL,
+  -- фильтры публикаций: type, visibility, author_id, tags, date_from, date_to
+  filters jsonb NOT NULL DEFAULT '{}',
+  notify boolean NOT NULL DEFAULT true,
+  -- последний запуск; следующий ищет публикации новее него
+  last_run_at timestamptz,
+  -- найденное до этого момента считается просмотренным
+  seen_at timestamptz NOT NULL DEFAULT now(),
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user ON saved_searches(user_id);
+
+-- SAVED SEARCH MATCHES (публикации, найденные сохранённым поиском; каждая попадает в уведомление один раз)
+CREATE TABLE IF NOT EXISTS saved_search_matches (
+  saved_search_id uuid NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+  publication_id uuid NOT NULL REFERENCES publications(id) ON DELETE CASCADE,
+  found_at timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (saved_search_id, publication_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_search_matches_found ON saved_search_matches(saved_search_id, found_at DESC);
+
+COMMIT;
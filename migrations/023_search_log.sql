@@ -0,0 +1,30 @@
+BEGIN;
+
+-- SEARCH QUERIES (журнал поисковых запросов GET /search для подсказок GET /search/suggest)
+CREATE TABLE IF NOT EXISTS search_queries (
+  id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  -- NULL для анонимных запросов
+  user_id uuid REFERENCES users(id) ON DELETE SET NULL,
+  query text NOT NULL,
+  -- запрос в нижнем регистре со схлопнутыми пробелами; по нему группируются популярные запросы
+  normalized text NOT NULL,
+  results_count int NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- Поиск популярных запросов по префиксу: LIKE 'префикс%'
+CREATE INDEX IF NOT EXISTS idx_search_queries_normalized ON search_queries(normalized text_pattern_ops, created_at);
+CREATE INDEX IF NOT EXISTS idx_search_queries_created ON search_queries(created_at);
+
+-- SEARCH CLICKS (переходы из результатов поиска)
+CREATE TABLE IF NOT EXISTS search_clicks (
+  query_id uuid NOT NULL REFERENCES search_queries(id) ON DELETE CASCADE,
+  publication_id uuid NOT NULL REFERENCES publications(id) ON DELETE CASCADE,
+  -- позиция результата в выдаче, с нуля
+  position int NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_clicks_query ON search_clicks(query_id);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- OUTBOX: событие, не обработанное за outbox.max_attempts попыток, больше не повторяется
+-- и остаётся для разбора
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS failed_at timestamptz;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(next_attempt_at, id) WHERE processed_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_failed ON outbox(failed_at) WHERE failed_at IS NOT NULL;
+
+-- WEBHOOK DELIVERIES: id события из полезной нагрузки; повторная обработка события из outbox
+-- не создаёт второй доставки на тот же вебхук
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_id uuid;
+UPDATE webhook_deliveries SET event_id = (payload->>'id')::uuid WHERE event_id IS NULL;
+ALTER TABLE webhook_deliveries ALTER COLUMN event_id SET NOT NULL;
+
+-- Повторы вручную (replay_of) доставляют то же событие ещё раз
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id) WHERE replay_of IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- SEARCH CLICKS: повторный переход по тому же результату того же запроса не записывается
+DELETE FROM search_clicks a
+USING search_clicks b
+WHERE a.query_id = b.query_id AND a.publication_id = b.publication_id AND a.ctid > b.ctid;
+
+-- Уникальный индекс начинается с query_id и заменяет прежний индекс
+DROP INDEX IF EXISTS idx_search_clicks_query;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_search_clicks_query_publication ON search_clicks(query_id, publication_id);
+
+COMMIT;
@@ -9,11 +9,20 @@ import (
 
 // Config represents application configuration
 type Config struct {
-	Database DatabaseConfig `yaml:"database"`
-	JWT      JWTConfig      `yaml:"jwt"`
-	AI       AIConfig       `yaml:"ai"`
-	Server   ServerConfig   `yaml:"server"`
-	Media    MediaConfig    `yaml:"media"`
+	Database      DatabaseConfig      `yaml:"database"`
+	JWT           JWTConfig           `yaml:"jwt"`
+	AI            AIConfig            `yaml:"ai"`
+	Server        ServerConfig        `yaml:"server"`
+	Media         MediaConfig         `yaml:"media"`
+	Trending      TrendingConfig      `yaml:"trending"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Realtime      RealtimeConfig      `yaml:"realtime"`
+	Mail          MailConfig          `yaml:"mail"`
+	Digest        DigestConfig        `yaml:"digest"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
+	Outbox        OutboxConfig        `yaml:"outbox"`
+	Jobs          JobsConfig          `yaml:"jobs"`
+	Search        SearchConfig        `yaml:"search"`
 }
 
 // DatabaseConfig contains database connection settings
@@ -38,7 +47,8 @@ type AIConfig struct {
 
 // ServerConfig contains server settings
 type ServerConfig struct {
-	Port int `yaml:"port"` // default 8080
+	Port      int    `yaml:"port"`       // default 8080
+	PublicURL string `yaml:"public_url"` // base URL used in links, default http://localhost:<port>
 }
 
 // MediaConfig contains media upload settings
@@ -46,6 +56,74 @@ type MediaConfig struct {
 	MaxFileSize int64 `yaml:"max_file_size"` // in bytes, default 10MB
 }
 
+// TrendingConfig contains trending computation settings
+type TrendingConfig struct {
+	Schedule      string `yaml:"schedule"`        // cron schedule of recomputing scores, in UTC, default */10 * * * *
+	WindowHours   int    `yaml:"window_hours"`    // default 168 (7 days)
+	HalfLifeHours int    `yaml:"half_life_hours"` // default 24
+}
+
+// RealtimeConfig contains settings of the SSE and WebSocket notification streams
+type RealtimeConfig struct {
+	RetentionMinutes int `yaml:"retention_minutes"` // how long events are kept for resuming, default 60
+}
+
+// NotificationsConfig contains notification settings
+type NotificationsConfig struct {
+	Locale               string `yaml:"locale"`                 // language of notification texts: ru or en, default ru
+	AggregateWindowHours int    `yaml:"aggregate_window_hours"` // default 24
+}
+
+// MailConfig contains outgoing email settings
+type MailConfig struct {
+	Driver  string     `yaml:"driver"`   // smtp or file, default file
+	From    string     `yaml:"from"`     // default Sense <noreply@localhost>
+	FileDir string     `yaml:"file_dir"` // where the file driver writes .eml files, default mail
+	SMTP    SMTPConfig `yaml:"smtp"`
+}
+
+// SMTPConfig contains SMTP server settings
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"` // default 587
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// DigestConfig contains email digest settings
+type DigestConfig struct {
+	Schedule string `yaml:"schedule"` // cron schedule of looking for due digests, in UTC, default */15 * * * *
+	Secret   string `yaml:"secret"`   // key signing unsubscribe links, defaults to the JWT secret
+}
+
+// WebhooksConfig contains outgoing webhook settings
+type WebhooksConfig struct {
+	RetrySchedule        string `yaml:"retry_schedule"`         // cron schedule of looking for due retries, in UTC, default * * * * *
+	AllowPrivateNetworks bool   `yaml:"allow_private_networks"` // allow webhook URLs on loopback and private addresses, for local runs
+}
+
+// OutboxConfig contains transactional outbox settings
+type OutboxConfig struct {
+	PollInterval   int `yaml:"poll_interval"`   // how often pending events are looked for, in milliseconds, default 500
+	RetentionHours int `yaml:"retention_hours"` // how long processed events are kept, default 24
+	MaxAttempts    int `yaml:"max_attempts"`    // failed dispatches after which an event is given up on, default 10
+}
+
+// JobsConfig contains background job settings
+type JobsConfig struct {
+	Disabled      bool           `yaml:"disabled"`       // only enqueue jobs and leave running them to other replicas
+	Queues        map[string]int `yaml:"queues"`         // queue name to the number of its jobs run in parallel, default {default: 4}
+	RetentionDays int            `yaml:"retention_days"` // how long finished jobs are kept, default 7
+}
+
+// SearchConfig contains full-text search settings
+type SearchConfig struct {
+	Backend       string `yaml:"backend"`        // postgres or embedded, default postgres
+	IndexPath     string `yaml:"index_path"`     // where the embedded index is stored, default data/search.gob
+	FlushInterval int    `yaml:"flush_interval"` // how often the embedded index is written to disk, in seconds, default 10
+	SavedSchedule string `yaml:"saved_schedule"` // cron schedule of looking for new matches of saved searches, in UTC, default */15 * * * *
+}
+
 // Load loads configuration from YAML file
 func Load(configPath string) (*Config, error) {
 	// #nosec G304 -- configPath is expected to be provided by the application, not user input
@@ -63,12 +141,81 @@ func Load(configPath string) (*Config, error) {
 	if config.Server.Port == 0 {
 		config.Server.Port = 8080
 	}
+	if config.Server.PublicURL == "" {
+		config.Server.PublicURL = fmt.Sprintf("http://localhost:%d", config.Server.Port)
+	}
 	if config.JWT.Expiry == 0 {
 		config.JWT.Expiry = 86400 // 1 day
 	}
 	if config.Media.MaxFileSize == 0 {
 		config.Media.MaxFileSize = 10 * 1024 * 1024 // 10MB
 	}
+	if config.Trending.Schedule == "" {
+		config.Trending.Schedule = "*/10 * * * *"
+	}
+	if config.Trending.WindowHours == 0 {
+		config.Trending.WindowHours = 168 // 7 days
+	}
+	if config.Trending.HalfLifeHours == 0 {
+		config.Trending.HalfLifeHours = 24
+	}
+	if config.Notifications.Locale == "" {
+		config.Notifications.Locale = "ru"
+	}
+	if config.Notifications.AggregateWindowHours == 0 {
+		config.Notifications.AggregateWindowHours = 24
+	}
+	if config.Realtime.RetentionMinutes == 0 {
+		config.Realtime.RetentionMinutes = 60
+	}
+	if config.Mail.Driver == "" {
+		config.Mail.Driver = "file"
+	}
+	if config.Mail.From == "" {
+		config.Mail.From = "Sense <noreply@localhost>"
+	}
+	if config.Mail.FileDir == "" {
+		config.Mail.FileDir = "mail"
+	}
+	if config.Mail.SMTP.Port == 0 {
+		config.Mail.SMTP.Port = 587
+	}
+	if config.Digest.Schedule == "" {
+		config.Digest.Schedule = "*/15 * * * *"
+	}
+	if config.Digest.Secret == "" {
+		config.Digest.Secret = config.JWT.Secret
+	}
+	if config.Webhooks.RetrySchedule == "" {
+		config.Webhooks.RetrySchedule = "* * * * *"
+	}
+	if config.Outbox.PollInterval == 0 {
+		config.Outbox.PollInterval = 500
+	}
+	if config.Outbox.RetentionHours == 0 {
+		config.Outbox.RetentionHours = 24
+	}
+	if config.Outbox.MaxAttempts == 0 {
+		config.Outbox.MaxAttempts = 10
+	}
+	if len(config.Jobs.Queues) == 0 {
+		config.Jobs.Queues = map[string]int{"default": 4}
+	}
+	if config.Jobs.RetentionDays == 0 {
+		config.Jobs.RetentionDays = 7
+	}
+	if config.Search.Backend == "" {
+		config.Search.Backend = "postgres"
+	}
+	if config.Search.IndexPath == "" {
+		config.Search.IndexPath = "data/search.gob"
+	}
+	if config.Search.FlushInterval == 0 {
+		config.Search.FlushInterval = 10
+	}
+	if config.Search.SavedSchedule == "" {
+		config.Search.SavedSchedule = "*/15 * * * *"
+	}
 
 	return &config, nil
 }
@@ -78,4 +225,3 @@ func (c *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		c.Host, c.Port, c.User, c.Password, c.Name)
 }
-
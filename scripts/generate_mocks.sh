@@ -18,6 +18,20 @@ go run go.uber.org/mock/mockgen@latest -source=internal/domain/comment_repositor
 go run go.uber.org/mock/mockgen@latest -source=internal/domain/media_repository.go -destination="$MOCKS_DIR/mock_media_repository.go" -package=mocks
 go run go.uber.org/mock/mockgen@latest -source=internal/domain/recommendation_repository.go -destination="$MOCKS_DIR/mock_recommendation_repository.go" -package=mocks
 go run go.uber.org/mock/mockgen@latest -source=internal/domain/tag_repository.go -destination="$MOCKS_DIR/mock_tag_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/trending_repository.go -destination="$MOCKS_DIR/mock_trending_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/block_repository.go -destination="$MOCKS_DIR/mock_block_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/notification_repository.go -destination="$MOCKS_DIR/mock_notification_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/suggestion_repository.go -destination="$MOCKS_DIR/mock_suggestion_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/community_repository.go -destination="$MOCKS_DIR/mock_community_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/message_repository.go -destination="$MOCKS_DIR/mock_message_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/realtime.go -destination="$MOCKS_DIR/mock_realtime_publisher.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/event.go -destination="$MOCKS_DIR/mock_event_publisher.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/notification_settings_repository.go -destination="$MOCKS_DIR/mock_notification_settings_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/webhook_repository.go -destination="$MOCKS_DIR/mock_webhook_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/digest_repository.go -destination="$MOCKS_DIR/mock_digest_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/outbox_repository.go -destination="$MOCKS_DIR/mock_outbox_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/job_repository.go -destination="$MOCKS_DIR/mock_job_repository.go" -package=mocks
+go run go.uber.org/mock/mockgen@latest -source=internal/domain/search_repository.go -destination="$MOCKS_DIR/mock_search_repository.go" -package=mocks
 
 # Generate mocks for infrastructure services
 go run go.uber.org/mock/mockgen@latest -source=internal/infrastructure/jwt/token_interface.go -destination="$MOCKS_DIR/mock_token_service.go" -package=mocks
@@ -141,24 +141,50 @@ func TestSearchEndpoints(c *client.Client) error {
 		return fmt.Errorf("search warmup failed: %w", err)
 	}
 
-	if err := client.CheckStatus(resp, http.StatusOK); err != nil {
-		return fmt.Errorf("search warmup status check failed: %w", err)
-	}
+	// Only super users may reindex
+	if resp.StatusCode == http.StatusForbidden {
+		fmt.Println("   ✓ Search warmup correctly requires the super role")
+	} else {
+		if err := client.CheckStatus(resp, http.StatusAccepted); err != nil {
+			return fmt.Errorf("search warmup status check failed: %w", err)
+		}
 
-	var warmupResp struct {
-		Message string `json:"message"`
-		TaskID  string `json:"task_id"`
-	}
+		var warmupResp struct {
+			Message string `json:"message"`
+			TaskID  string `json:"task_id"`
+		}
 
-	if err := client.ParseResponse(resp, &warmupResp); err != nil {
-		return fmt.Errorf("search warmup parse failed: %w", err)
-	}
+		if err := client.ParseResponse(resp, &warmupResp); err != nil {
+			return fmt.Errorf("search warmup parse failed: %w", err)
+		}
 
-	if warmupResp.TaskID == "" {
-		return fmt.Errorf("search warmup did not return task_id")
-	}
+		if warmupResp.TaskID == "" {
+			return fmt.Errorf("search warmup did not return task_id")
+		}
+
+		fmt.Printf("   ✓ Search warmup initiated: TaskID=%s\n", warmupResp.TaskID)
+
+		resp, err = c.DoRequest("GET", "/search/warmup/"+warmupResp.TaskID, nil)
+		if err != nil {
+			return fmt.Errorf("get warmup task failed: %w", err)
+		}
 
-	fmt.Printf("   ✓ Search warmup initiated: TaskID=%s\n", warmupResp.TaskID)
+		if err := client.CheckStatus(resp, http.StatusOK); err != nil {
+			return fmt.Errorf("get warmup task status check failed: %w", err)
+		}
+
+		var taskResp struct {
+			Status    string `json:"status"`
+			Total     int    `json:"total"`
+			Processed int    `json:"processed"`
+		}
+
+		if err := client.ParseResponse(resp, &taskResp); err != nil {
+			return fmt.Errorf("get warmup task parse failed: %w", err)
+		}
+
+		fmt.Printf("   ✓ Warmup task: Status=%s, Processed=%d/%d\n", taskResp.Status, taskResp.Processed, taskResp.Total)
+	}
 
 	// Test 8: Get popular tags
 	fmt.Println("\n8. Testing GET /tags?limit=10")
@@ -226,6 +252,236 @@ func TestSearchEndpoints(c *client.Client) error {
 	// Restore token
 	c.SetToken(data.Tokens.User1)
 
+	// Test 11: Autocomplete users for mentions
+	fmt.Println("\n11. Testing GET /search/users/autocomplete?q=@us")
+	resp, err = c.DoRequest("GET", "/search/users/autocomplete?q=@us&limit=5", nil)
+	if err != nil {
+		return fmt.Errorf("autocomplete users failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("autocomplete users status check failed: %w", err)
+	}
+
+	var autocompleteResp struct {
+		Items []struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		} `json:"items"`
+	}
+
+	if err := client.ParseResponse(resp, &autocompleteResp); err != nil {
+		return fmt.Errorf("autocomplete users parse failed: %w", err)
+	}
+
+	fmt.Printf("   ✓ Autocomplete returned %d users\n", len(autocompleteResp.Items))
+
+	// Test 12: Unified search with facets
+	fmt.Println("\n12. Testing GET /search/all?q=test")
+	resp, err = c.DoRequest("GET", "/search/all?q=test&limit=3&type=post", nil)
+	if err != nil {
+		return fmt.Errorf("unified search failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("unified search status check failed: %w", err)
+	}
+
+	type group struct {
+		Items []interface{} `json:"items"`
+		Total int           `json:"total"`
+	}
+	var allResp struct {
+		Publications group `json:"publications"`
+		Users        group `json:"users"`
+		Tags         group `json:"tags"`
+		Sources      group `json:"sources"`
+		Facets       struct {
+			Types []interface{} `json:"types"`
+			Dates []struct {
+				Value string `json:"value"`
+				Count int    `json:"count"`
+			} `json:"dates"`
+		} `json:"facets"`
+	}
+
+	if err := client.ParseResponse(resp, &allResp); err != nil {
+		return fmt.Errorf("unified search parse failed: %w", err)
+	}
+
+	if len(allResp.Facets.Dates) == 0 {
+		return fmt.Errorf("unified search returned no date facets")
+	}
+
+	fmt.Printf("   ✓ Unified search: publications=%d, users=%d, tags=%d, sources=%d, date buckets=%d\n",
+		allResp.Publications.Total, allResp.Users.Total, allResp.Tags.Total, allResp.Sources.Total, len(allResp.Facets.Dates))
+
+	// Test 13: Saved searches
+	fmt.Println("\n13. Testing /search/saved")
+	resp, err = c.DoRequest("POST", "/search/saved", map[string]interface{}{
+		"name":    "Test",
+		"query":   "test",
+		"filters": map[string]string{"type": "post"},
+	})
+	if err != nil {
+		return fmt.Errorf("create saved search failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusCreated); err != nil {
+		return fmt.Errorf("create saved search status check failed: %w", err)
+	}
+
+	var savedResp struct {
+		ID       string `json:"id"`
+		Notify   bool   `json:"notify"`
+		NewCount int    `json:"new_count"`
+	}
+
+	if err := client.ParseResponse(resp, &savedResp); err != nil {
+		return fmt.Errorf("create saved search parse failed: %w", err)
+	}
+
+	if savedResp.ID == "" || !savedResp.Notify {
+		return fmt.Errorf("saved search created without id or notifications")
+	}
+
+	fmt.Printf("   ✓ Saved search created: ID=%s\n", savedResp.ID)
+
+	resp, err = c.DoRequest("GET", "/search/saved/"+savedResp.ID+"/new", nil)
+	if err != nil {
+		return fmt.Errorf("get new saved search matches failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("get new saved search matches status check failed: %w", err)
+	}
+
+	var newResp struct {
+		Items []interface{} `json:"items"`
+		Total int           `json:"total"`
+	}
+
+	if err := client.ParseResponse(resp, &newResp); err != nil {
+		return fmt.Errorf("get new saved search matches parse failed: %w", err)
+	}
+
+	fmt.Printf("   ✓ Unseen matches: %d\n", newResp.Total)
+
+	resp, err = c.DoRequest("POST", "/search/saved/"+savedResp.ID+"/seen", nil)
+	if err != nil {
+		return fmt.Errorf("mark saved search seen failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusNoContent); err != nil {
+		return fmt.Errorf("mark saved search seen status check failed: %w", err)
+	}
+
+	resp, err = c.DoRequest("DELETE", "/search/saved/"+savedResp.ID, nil)
+	if err != nil {
+		return fmt.Errorf("delete saved search failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusNoContent); err != nil {
+		return fmt.Errorf("delete saved search status check failed: %w", err)
+	}
+
+	resp, err = c.DoRequest("GET", "/search/saved/"+savedResp.ID, nil)
+	if err != nil {
+		return fmt.Errorf("get deleted saved search failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("expected 404 for deleted saved search, got %d", resp.StatusCode)
+	}
+
+	fmt.Println("   ✓ Saved search deleted")
+
+	// Test 14: Query suggestions, corrections and click-throughs
+	fmt.Println("\n14. Testing GET /search/suggest?q=te")
+	resp, err = c.DoRequest("GET", "/search/suggest?q=te&limit=5", nil)
+	if err != nil {
+		return fmt.Errorf("suggest queries failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("suggest queries status check failed: %w", err)
+	}
+
+	var suggestResp struct {
+		Items []struct {
+			Text   string `json:"text"`
+			Source string `json:"source"`
+		} `json:"items"`
+	}
+
+	if err := client.ParseResponse(resp, &suggestResp); err != nil {
+		return fmt.Errorf("suggest queries parse failed: %w", err)
+	}
+
+	fmt.Printf("   ✓ Suggestions returned %d items\n", len(suggestResp.Items))
+
+	resp, err = c.DoRequest("GET", "/search?q=tset&limit=5", nil)
+	if err != nil {
+		return fmt.Errorf("search with typo failed: %w", err)
+	}
+
+	if err := client.CheckStatus(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("search with typo status check failed: %w", err)
+	}
+
+	var typoResp struct {
+		Total      int     `json:"total"`
+		QueryID    string  `json:"query_id"`
+		DidYouMean *string `json:"did_you_mean"`
+	}
+
+	if err := client.ParseResponse(resp, &typoResp); err != nil {
+		return fmt.Errorf("search with typo parse failed: %w", err)
+	}
+
+	if typoResp.QueryID == "" {
+		return fmt.Errorf("first page of search returned no query_id")
+	}
+
+	if typoResp.DidYouMean != nil {
+		fmt.Printf("   ✓ Did you mean: %s\n", *typoResp.DidYouMean)
+	} else {
+		fmt.Printf("   ✓ No correction offered (found %d)\n", typoResp.Total)
+	}
+
+	if data.IDs.PublicationID != "" {
+		resp, err = c.DoRequest("POST", "/search/click", map[string]interface{}{
+			"query_id":       typoResp.QueryID,
+			"publication_id": data.IDs.PublicationID,
+			"position":       0,
+		})
+		if err != nil {
+			return fmt.Errorf("log search click failed: %w", err)
+		}
+
+		if err := client.CheckStatus(resp, http.StatusNoContent); err != nil {
+			return fmt.Errorf("log search click status check failed: %w", err)
+		}
+
+		fmt.Println("   ✓ Click-through logged")
+
+		// A repeated click on the same result is accepted but not counted again
+		resp, err = c.DoRequest("POST", "/search/click", map[string]interface{}{
+			"query_id":       typoResp.QueryID,
+			"publication_id": data.IDs.PublicationID,
+			"position":       0,
+		})
+		if err != nil {
+			return fmt.Errorf("repeat search click failed: %w", err)
+		}
+
+		if err := client.CheckStatus(resp, http.StatusNoContent); err != nil {
+			return fmt.Errorf("repeat search click status check failed: %w", err)
+		}
+
+		fmt.Println("   ✓ Repeated click-through accepted once")
+	}
+
 	fmt.Println("\n=== Search Endpoints Testing Complete ===")
 	return nil
 }